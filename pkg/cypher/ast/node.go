@@ -2,7 +2,7 @@
 package ast
 
 import (
-	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
@@ -14,6 +14,8 @@ type nodePattern struct {
 	labels     []string
 	alias      string
 	properties map[string]core.Expression
+	propsParam core.Expression // a single map parameter used instead of properties
+	predicate  core.Expression
 }
 
 // Node creates a new node pattern with the given labels
@@ -34,13 +36,15 @@ func (n *nodePattern) Named(alias string) core.NodeExpression {
 // WithLabels adds labels to this node pattern
 func (n *nodePattern) WithLabels(labels ...string) core.NodeExpression {
 	clone := *n
-	clone.labels = append(clone.labels, labels...)
+	clone.labels = append(append([]string{}, n.labels...), labels...)
 	return &clone
 }
 
 // WithProperties adds properties to this node pattern
 func (n *nodePattern) WithProperties(properties map[string]core.Expression) core.NodeExpression {
 	clone := *n
+	clone.properties = copyProperties(n.properties)
+	clone.propsParam = nil
 	for k, v := range properties {
 		clone.properties[k] = v
 	}
@@ -50,6 +54,8 @@ func (n *nodePattern) WithProperties(properties map[string]core.Expression) core
 // WithProps adds properties with automatic conversion to expressions
 func (n *nodePattern) WithProps(properties map[string]interface{}) core.NodeExpression {
 	clone := *n
+	clone.properties = copyProperties(n.properties)
+	clone.propsParam = nil
 	for k, v := range properties {
 		switch val := v.(type) {
 		case core.Expression:
@@ -61,6 +67,34 @@ func (n *nodePattern) WithProps(properties map[string]interface{}) core.NodeExpr
 	return &clone
 }
 
+// WithPropsParam uses a single map parameter for the properties of this
+// node pattern, e.g. (n:Person $props), replacing any individual properties
+func (n *nodePattern) WithPropsParam(param core.Expression) core.NodeExpression {
+	clone := *n
+	clone.properties = make(map[string]core.Expression)
+	clone.propsParam = param
+	return &clone
+}
+
+// Where adds an inline predicate to this node pattern,
+// e.g. (n:Person WHERE n.age > 18); it is only valid in MATCH patterns
+func (n *nodePattern) Where(condition core.Expression) core.NodeExpression {
+	clone := *n
+	clone.predicate = condition
+	return &clone
+}
+
+// Predicate returns the inline predicate of this node pattern, if any
+func (n *nodePattern) Predicate() core.Expression {
+	return n.predicate
+}
+
+// PropsParam returns the map parameter holding the properties of this node
+// pattern, if any
+func (n *nodePattern) PropsParam() core.Expression {
+	return n.propsParam
+}
+
 // Props is an alias for WithProps
 func (n *nodePattern) Props(properties map[string]interface{}) core.Expression {
 	return n.WithProps(properties)
@@ -68,58 +102,47 @@ func (n *nodePattern) Props(properties map[string]interface{}) core.Expression {
 
 // Property returns a property access expression for this node
 func (n *nodePattern) Property(propertyName string) core.PropertyExpression {
-	return &propertyExpression{
-		subject:      n,
-		propertyName: propertyName,
-	}
+	return expr.NewProperty(n, propertyName)
 }
 
-// RelationshipTo creates a relationship from this node to another
+// Prop is an alias for Property
+func (n *nodePattern) Prop(propertyName string) core.PropertyExpression {
+	return n.Property(propertyName)
+}
+
+// RelationshipTo creates a relationship from this node to another.
+// The other node may be any core.NodeExpression; a nil node makes the
+// statement fail to build with core.ErrNodeRequired.
 func (n *nodePattern) RelationshipTo(other core.NodeExpression, types ...string) core.RelationshipPattern {
-	if otherNode, ok := other.(*nodePattern); ok {
-		rel := &relationshipPattern{
-			startNode:  n,
-			endNode:    otherNode,
-			types:      types,
-			direction:  core.OUTGOING,
-			properties: make(map[string]core.Expression),
-		}
-		return rel
+	return &relationshipPattern{
+		startNode:  n,
+		endNode:    other,
+		types:      types,
+		direction:  core.OUTGOING,
+		properties: make(map[string]core.Expression),
 	}
-	// Handle case when other is not a *nodePattern
-	panic("other must be created with Node() function")
 }
 
 // RelationshipFrom creates a relationship from another node to this one
 func (n *nodePattern) RelationshipFrom(other core.NodeExpression, types ...string) core.RelationshipPattern {
-	if otherNode, ok := other.(*nodePattern); ok {
-		rel := &relationshipPattern{
-			startNode:  otherNode,
-			endNode:    n,
-			types:      types,
-			direction:  core.INCOMING,
-			properties: make(map[string]core.Expression),
-		}
-		return rel
+	return &relationshipPattern{
+		startNode:  other,
+		endNode:    n,
+		types:      types,
+		direction:  core.INCOMING,
+		properties: make(map[string]core.Expression),
 	}
-	// Handle case when other is not a *nodePattern
-	panic("other must be created with Node() function")
 }
 
 // RelationshipBetween creates an undirected relationship between this node and another
 func (n *nodePattern) RelationshipBetween(other core.NodeExpression, types ...string) core.RelationshipPattern {
-	if otherNode, ok := other.(*nodePattern); ok {
-		rel := &relationshipPattern{
-			startNode:  n,
-			endNode:    otherNode,
-			types:      types,
-			direction:  core.BIDIRECTIONAL,
-			properties: make(map[string]core.Expression),
-		}
-		return rel
+	return &relationshipPattern{
+		startNode:  n,
+		endNode:    other,
+		types:      types,
+		direction:  core.BIDIRECTIONAL,
+		properties: make(map[string]core.Expression),
 	}
-	// Handle case when other is not a *nodePattern
-	panic("other must be created with Node() function")
 }
 
 // SymbolicName returns the alias of this node pattern
@@ -148,19 +171,16 @@ func (n *nodePattern) String() string {
 	}
 
 	// Write properties if present
-	if len(n.properties) > 0 {
-		sb.WriteString(" {")
-		first := true
-		for k, v := range n.properties {
-			if !first {
-				sb.WriteString(", ")
-			}
-			first = false
-			sb.WriteString(k)
-			sb.WriteString(": ")
-			sb.WriteString(v.String())
-		}
-		sb.WriteString("}")
+	if n.propsParam != nil {
+		sb.WriteString(" ")
+		sb.WriteString(n.propsParam.String())
+	} else {
+		writeProperties(&sb, n.properties)
+	}
+
+	if n.predicate != nil {
+		sb.WriteString(" WHERE ")
+		sb.WriteString(n.predicate.String())
 	}
 
 	sb.WriteString(")")
@@ -184,103 +204,75 @@ func (n *nodePattern) Not() core.Expression {
 
 // Expressions returns all expressions contained in this node pattern
 func (n *nodePattern) Expressions() []core.Expression {
-	result := make([]core.Expression, 0, len(n.properties))
-	for _, prop := range n.properties {
-		result = append(result, prop)
+	result := make([]core.Expression, 0, len(n.properties)+2)
+	for _, key := range sortedPropertyKeys(n.properties) {
+		result = append(result, n.properties[key])
+	}
+	if n.propsParam != nil {
+		result = append(result, n.propsParam)
+	}
+	if n.predicate != nil {
+		result = append(result, n.predicate)
 	}
 	return result
 }
 
-// propertyExpression represents a property access expression (e.g., n.name)
-type propertyExpression struct {
-	subject      core.Expression
-	propertyName string
-}
-
-// Accept implements the Expression interface
-func (p *propertyExpression) Accept(visitor core.ExpressionVisitor) any {
-	return visitor.Visit(p)
-}
-
-// String returns a string representation of this property expression
-func (p *propertyExpression) String() string {
-	if namedExpr, ok := p.subject.(core.NamedExpression); ok && namedExpr.SymbolicName() != "" {
-		return fmt.Sprintf("%s.%s", namedExpr.SymbolicName(), p.propertyName)
+// WithChildren returns a copy of this node pattern with the expressions
+// returned by Expressions replaced, in the same order
+func (n *nodePattern) WithChildren(children []core.Expression) core.Expression {
+	if len(children) != len(n.Expressions()) {
+		return n
 	}
-	return fmt.Sprintf("%s.%s", p.subject.String(), p.propertyName)
-}
-
-// Eq creates an equals comparison with the given value
-func (p *propertyExpression) Eq(value any) core.Expression {
-	return expr.Equals(p, expr.LiteralFromValue(value))
-}
-
-// Gt creates a greater-than comparison with the given value
-func (p *propertyExpression) Gt(value any) core.Expression {
-	return expr.GreaterThan(p, expr.LiteralFromValue(value))
-}
-
-// Lt creates a less-than comparison with the given value
-func (p *propertyExpression) Lt(value any) core.Expression {
-	return expr.LessThan(p, expr.LiteralFromValue(value))
-}
-
-// Gte creates a greater-than-or-equal comparison with the given value
-func (p *propertyExpression) Gte(value any) core.Expression {
-	return expr.GreaterThanEqual(p, expr.LiteralFromValue(value))
-}
-
-// Lte creates a less-than-or-equal comparison with the given value
-func (p *propertyExpression) Lte(value any) core.Expression {
-	return expr.LessThanEqual(p, expr.LiteralFromValue(value))
-}
-
-// IsNull creates a null check
-func (p *propertyExpression) IsNull() core.Expression {
-	return expr.IsNull(p)
-}
-
-// IsNotNull creates a not-null check
-func (p *propertyExpression) IsNotNull() core.Expression {
-	return expr.Not(expr.IsNull(p))
-}
-
-// In creates an IN comparison with the given values
-func (p *propertyExpression) In(values ...any) core.Expression {
-	return expr.In(p, values...)
-}
-
-// StartsWith creates a STARTS WITH comparison
-func (p *propertyExpression) StartsWith(value string) core.Expression {
-	return expr.StartsWith(p, value)
-}
-
-// EndsWith creates an ENDS WITH comparison
-func (p *propertyExpression) EndsWith(value string) core.Expression {
-	return expr.EndsWith(p, value)
-}
-
-// Contains creates a CONTAINS comparison
-func (p *propertyExpression) Contains(value string) core.Expression {
-	return expr.Contains(p, value)
-}
-
-// RegularExpression creates a =~ comparison with a regular expression
-func (p *propertyExpression) RegularExpression(pattern string) core.Expression {
-	return expr.RegularExpression(p, pattern)
+	clone := *n
+	clone.properties = make(map[string]core.Expression, len(n.properties))
+	for i, key := range sortedPropertyKeys(n.properties) {
+		clone.properties[key] = children[i]
+	}
+	rest := children[len(n.properties):]
+	if n.propsParam != nil {
+		clone.propsParam, rest = rest[0], rest[1:]
+	}
+	if n.predicate != nil {
+		clone.predicate = rest[0]
+	}
+	return &clone
 }
 
-// And creates a logical AND with another expression
-func (p *propertyExpression) And(other core.Expression) core.Expression {
-	return expr.And(p, other)
+// sortedPropertyKeys returns the keys of a property map in sorted order, so
+// that properties render and number their parameters the same way every time
+func sortedPropertyKeys(properties map[string]core.Expression) []string {
+	keys := make([]string, 0, len(properties))
+	for k := range properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
-// Or creates a logical OR with another expression
-func (p *propertyExpression) Or(other core.Expression) core.Expression {
-	return expr.Or(p, other)
+// writeProperties writes a property map as " {key: value, ...}", or nothing
+// when the map is empty. Keys that aren't plain identifiers are backquoted.
+func writeProperties(sb *strings.Builder, properties map[string]core.Expression) {
+	if len(properties) == 0 {
+		return
+	}
+	sb.WriteString(" {")
+	for i, k := range sortedPropertyKeys(properties) {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(expr.QuoteIdentifier(k))
+		sb.WriteString(": ")
+		sb.WriteString(properties[k].String())
+	}
+	sb.WriteString("}")
 }
 
-// Not creates a logical NOT of this expression
-func (p *propertyExpression) Not() core.Expression {
-	return expr.Not(p)
+// copyProperties returns a shallow copy of a property map so that clones
+// never share (and mutate) the map of the pattern they were derived from
+func copyProperties(properties map[string]core.Expression) map[string]core.Expression {
+	result := make(map[string]core.Expression, len(properties))
+	for k, v := range properties {
+		result[k] = v
+	}
+	return result
 }
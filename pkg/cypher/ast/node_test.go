@@ -74,6 +74,51 @@ func TestNodeWithPropertiesExpressions(t *testing.T) {
 	}
 }
 
+func TestNodeWithPropsDoesNotMutateOriginal(t *testing.T) {
+	base := Node("Person").Named("p").WithProps(map[string]interface{}{"name": "John"})
+	first := base.WithProps(map[string]interface{}{"age": 30})
+	second := base.WithLabels("Actor")
+	third := base.WithLabels("Director")
+
+	if contains(base.String(), "age") {
+		t.Errorf("base = %q, should not be modified by WithProps()", base.String())
+	}
+	if !contains(first.String(), "age") {
+		t.Errorf("first = %q, should contain age", first.String())
+	}
+	if contains(second.String(), "Director") {
+		t.Errorf("second = %q, should not contain Director", second.String())
+	}
+	if contains(third.String(), "Actor") {
+		t.Errorf("third = %q, should not contain Actor", third.String())
+	}
+}
+
+func TestNodeWhere(t *testing.T) {
+	base := Node("Person").Named("n").WithProps(map[string]interface{}{"active": true})
+	filtered := base.Where(base.Property("age").Gt(18))
+
+	expected := "(n:Person {active: true} WHERE (n.age > 18))"
+	if result := filtered.String(); result != expected {
+		t.Errorf("Node().Where() = %q, want %q", result, expected)
+	}
+	if contains(base.String(), "WHERE") {
+		t.Errorf("base = %q, should not be modified by Where()", base.String())
+	}
+}
+
+func TestNodeWithPropsParam(t *testing.T) {
+	props := expr.Param("props", map[string]any{"name": "John"})
+	node := Node("Person").Named("n").WithProps(map[string]interface{}{"age": 30}).WithPropsParam(props)
+
+	if result := node.String(); result != "(n:Person $props)" {
+		t.Errorf("Node().WithPropsParam() = %q, want '(n:Person $props)'", result)
+	}
+	if result := node.WithProps(map[string]interface{}{"age": 30}).String(); result != "(n:Person {age: 30})" {
+		t.Errorf("WithProps() after WithPropsParam() = %q, want '(n:Person {age: 30})'", result)
+	}
+}
+
 func TestNodeProperty(t *testing.T) {
 	node := Node("Person").Named("p")
 	prop := node.Property("name")
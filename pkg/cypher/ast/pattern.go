@@ -1,6 +1,7 @@
 package ast
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
@@ -50,6 +51,12 @@ func (p *PatternExpression) String() string {
 	return builder.String()
 }
 
+// Named returns a copy of this pattern bound to a path variable, rendered
+// as p = (a)-[r]->(b)
+func (p *PatternExpression) Named(alias string) *PatternExpression {
+	return &PatternExpression{elements: p.elements, alias: alias}
+}
+
 // SymbolicName returns the alias of this pattern
 func (p *PatternExpression) SymbolicName() string {
 	return p.alias
@@ -64,6 +71,21 @@ func (p *PatternExpression) Expressions() []core.Expression {
 	return result
 }
 
+// WithChildren returns a copy of this pattern with its elements replaced; a
+// replacement that is not a pattern element keeps the original
+func (p *PatternExpression) WithChildren(children []core.Expression) core.Expression {
+	if len(children) != len(p.elements) {
+		return p
+	}
+	clone := &PatternExpression{elements: append([]core.PatternElement{}, p.elements...), alias: p.alias}
+	for i, child := range children {
+		if element, ok := child.(core.PatternElement); ok {
+			clone.elements[i] = element
+		}
+	}
+	return clone
+}
+
 // And creates a logical AND with another expression
 func (p *PatternExpression) And(other core.Expression) core.Expression {
 	return expr.And(p, other)
@@ -80,17 +102,162 @@ func (p *PatternExpression) Not() core.Expression {
 }
 
 // Pattern creates a new pattern from the given elements
-func Pattern(elements ...core.PatternElement) core.Expression {
+func Pattern(elements ...core.PatternElement) *PatternExpression {
 	return &PatternExpression{
 		elements: elements,
 	}
 }
 
 // Path is an alias for Pattern
-func Path(elements ...core.PatternElement) core.Expression {
+func Path(elements ...core.PatternElement) *PatternExpression {
 	return Pattern(elements...)
 }
 
+// PatternUnionExpression represents alternative graph patterns matched as one,
+// e.g. ((a)-[:R]->(b) | (a)-[:S]->(b))
+type PatternUnionExpression struct {
+	patterns []core.Expression
+}
+
+// Accept implements the Expression interface
+func (p *PatternUnionExpression) Accept(visitor core.ExpressionVisitor) any {
+	return visitor.Visit(p)
+}
+
+// String returns the alternatives separated by | inside parentheses
+func (p *PatternUnionExpression) String() string {
+	alternatives := make([]string, len(p.patterns))
+	for i, pattern := range p.patterns {
+		if pattern != nil {
+			alternatives[i] = pattern.String()
+		}
+	}
+	return "(" + strings.Join(alternatives, " | ") + ")"
+}
+
+// Expressions returns the alternative patterns
+func (p *PatternUnionExpression) Expressions() []core.Expression {
+	return p.patterns
+}
+
+// Validate reports an error when fewer than two alternatives are given or one
+// is nil or does not start and end with a node
+func (p *PatternUnionExpression) Validate() error {
+	if len(p.patterns) < 2 {
+		return core.NewError(core.ErrInvalidPattern, "PatternUnion requires at least two patterns")
+	}
+	for _, pattern := range p.patterns {
+		if pattern == nil {
+			return core.NewError(core.ErrInvalidPattern, "PatternUnion does not accept nil patterns")
+		}
+		if !nodeBounded(pattern) {
+			return core.NewError(core.ErrInvalidPattern,
+				fmt.Sprintf("PatternUnion patterns must start and end with a node, got %s", pattern))
+		}
+	}
+	return nil
+}
+
+// nodeBounded reports whether a pattern starts and ends with a node. A
+// relationship on its own renders without its nodes, e.g. -[:KNOWS]->.
+func nodeBounded(pattern core.Expression) bool {
+	switch p := pattern.(type) {
+	case core.RelationshipPattern:
+		return false
+	case *PatternExpression:
+		if len(p.elements) == 0 {
+			return false
+		}
+		_, first := p.elements[0].(core.NodeExpression)
+		_, last := p.elements[len(p.elements)-1].(core.NodeExpression)
+		return first && last
+	}
+	return true
+}
+
+// And creates a logical AND with another expression
+func (p *PatternUnionExpression) And(other core.Expression) core.Expression {
+	return expr.And(p, other)
+}
+
+// Or creates a logical OR with another expression
+func (p *PatternUnionExpression) Or(other core.Expression) core.Expression {
+	return expr.Or(p, other)
+}
+
+// Not creates a logical NOT of this expression
+func (p *PatternUnionExpression) Not() core.Expression {
+	return expr.Not(p)
+}
+
+// PatternUnion creates a parenthesized alternation of graph patterns. Unlike
+// relationship type alternation ([:R|S]), each alternative is a whole pattern.
+func PatternUnion(patterns ...core.Expression) core.Expression {
+	return &PatternUnionExpression{patterns: patterns}
+}
+
+// PatternPredicateExpression uses a pattern as a condition that holds when the
+// pattern matches, e.g. WHERE (a)-[:KNOWS]->(b) or WHERE NOT (a)-[:BLOCKED]->(b)
+type PatternPredicateExpression struct {
+	pattern core.Expression
+}
+
+// Accept implements the Expression interface
+func (p *PatternPredicateExpression) Accept(visitor core.ExpressionVisitor) any {
+	return visitor.Visit(p)
+}
+
+// String returns the pattern itself
+func (p *PatternPredicateExpression) String() string {
+	if p.pattern == nil {
+		return ""
+	}
+	return p.pattern.String()
+}
+
+// Expressions returns the pattern so that its parameters are collected
+func (p *PatternPredicateExpression) Expressions() []core.Expression {
+	return []core.Expression{p.pattern}
+}
+
+// Validate reports an error when no pattern is given or it does not start
+// and end with a node
+func (p *PatternPredicateExpression) Validate() error {
+	if p.pattern == nil {
+		return core.NewError(core.ErrEmptyPattern, "PatternPredicate requires a pattern")
+	}
+	if !nodeBounded(p.pattern) {
+		return core.NewError(core.ErrInvalidPattern,
+			fmt.Sprintf("PatternPredicate pattern must start and end with a node, got %s", p.pattern))
+	}
+	return nil
+}
+
+// And creates a logical AND with another expression
+func (p *PatternPredicateExpression) And(other core.Expression) core.Expression {
+	return expr.And(p, other)
+}
+
+// Or creates a logical OR with another expression
+func (p *PatternPredicateExpression) Or(other core.Expression) core.Expression {
+	return expr.Or(p, other)
+}
+
+// Xor creates a logical XOR with another boolean expression
+func (p *PatternPredicateExpression) Xor(other core.BooleanExpression) core.BooleanExpression {
+	return expr.Xor(p, other).(core.BooleanExpression)
+}
+
+// Not negates the predicate, rendering NOT followed by the pattern
+func (p *PatternPredicateExpression) Not() core.Expression {
+	return expr.Not(p)
+}
+
+// PatternPredicate creates a condition that holds when the pattern matches
+func PatternPredicate(pattern core.Expression) *PatternPredicateExpression {
+	return &PatternPredicateExpression{pattern: pattern}
+}
+
 // RelationshipChain represents a chain of relationships
 type RelationshipChain struct {
 	startNode     core.NodeExpression
@@ -134,6 +301,117 @@ func (r *RelationshipChain) Not() core.Expression {
 	return expr.Not(r)
 }
 
+// Expressions returns the nodes and relationships of this chain
+func (r *RelationshipChain) Expressions() []core.Expression {
+	result := []core.Expression{r.startNode}
+	for i, rel := range r.relationships {
+		result = append(result, rel, r.endNodes[i])
+	}
+	return result
+}
+
+// WithChildren returns a copy of this chain with its nodes and relationships
+// replaced in the order of Expressions; a replacement that is not a node or
+// relationship pattern respectively keeps the original
+func (r *RelationshipChain) WithChildren(children []core.Expression) core.Expression {
+	if len(children) != 1+2*len(r.relationships) {
+		return r
+	}
+	clone := &RelationshipChain{
+		startNode:     r.startNode,
+		relationships: append([]core.RelationshipPattern{}, r.relationships...),
+		endNodes:      append([]core.NodeExpression{}, r.endNodes...),
+	}
+	if node, ok := children[0].(core.NodeExpression); ok {
+		clone.startNode = node
+	}
+	for i := range r.relationships {
+		if rel, ok := children[1+2*i].(core.RelationshipPattern); ok {
+			clone.relationships[i] = rel
+		}
+		if node, ok := children[2+2*i].(core.NodeExpression); ok {
+			clone.endNodes[i] = node
+		}
+	}
+	return clone
+}
+
+// Named sets the alias of the last relationship of this chain
+func (r *RelationshipChain) Named(alias string) core.PathPattern {
+	return r.withLast(r.last().Named(alias))
+}
+
+// WithProperties adds properties to the last relationship of this chain
+func (r *RelationshipChain) WithProperties(properties map[string]core.Expression) core.PathPattern {
+	return r.withLast(r.last().WithProperties(properties))
+}
+
+// WithProps adds properties to the last relationship of this chain with
+// automatic conversion to expressions
+func (r *RelationshipChain) WithProps(properties map[string]interface{}) core.PathPattern {
+	return r.withLast(r.last().WithProps(properties))
+}
+
+// Props is an alias for WithProps
+func (r *RelationshipChain) Props(properties map[string]interface{}) core.PathPattern {
+	return r.WithProps(properties)
+}
+
+// RelationshipTo adds a relationship from the last node of this chain to another
+func (r *RelationshipChain) RelationshipTo(other core.NodeExpression, types ...string) core.PathPattern {
+	return r.extend(r.lastNode().RelationshipTo(other, types...))
+}
+
+// RelationshipFrom adds a relationship from another node to the last node of this chain
+func (r *RelationshipChain) RelationshipFrom(other core.NodeExpression, types ...string) core.PathPattern {
+	return r.extend(r.lastNode().RelationshipFrom(other, types...))
+}
+
+// RelationshipBetween adds an undirected relationship between the last node of this chain and another
+func (r *RelationshipChain) RelationshipBetween(other core.NodeExpression, types ...string) core.PathPattern {
+	return r.extend(r.lastNode().RelationshipBetween(other, types...))
+}
+
+// last returns the last relationship of this chain
+func (r *RelationshipChain) last() core.RelationshipPattern {
+	return r.relationships[len(r.relationships)-1]
+}
+
+// lastNode returns the node the chain ends with
+func (r *RelationshipChain) lastNode() core.NodeExpression {
+	return r.endNodes[len(r.endNodes)-1]
+}
+
+// withLast returns a copy of this chain with its last relationship replaced
+func (r *RelationshipChain) withLast(rel core.RelationshipPattern) *RelationshipChain {
+	clone := *r
+	clone.relationships = append([]core.RelationshipPattern{}, r.relationships...)
+	clone.relationships[len(clone.relationships)-1] = rel
+	return &clone
+}
+
+// extend returns a copy of this chain with rel added at its end
+func (r *RelationshipChain) extend(rel core.RelationshipPattern) *RelationshipChain {
+	clone := *r
+	clone.relationships = append(append([]core.RelationshipPattern{}, r.relationships...), rel)
+	clone.endNodes = append(append([]core.NodeExpression{}, r.endNodes...), farNode(rel))
+	return &clone
+}
+
+// startChain creates a chain that consists of a single relationship, starting
+// with the node on its left: the end node of an incoming relationship
+func startChain(rel core.RelationshipPattern) *RelationshipChain {
+	start := rel.StartNode()
+	if rel.Direction() == core.INCOMING {
+		start = rel.EndNode()
+	}
+	return &RelationshipChain{
+		startNode:     start,
+		relationships: []core.RelationshipPattern{rel},
+		endNodes:      []core.NodeExpression{farNode(rel)},
+	}
+}
+
 // Chain creates a new relationship chain
 func Chain(startNode core.NodeExpression, relationships ...core.RelationshipPattern) core.Expression {
 	if len(relationships) == 0 {
@@ -142,7 +420,7 @@ func Chain(startNode core.NodeExpression, relationships ...core.RelationshipPatt
 
 	endNodes := make([]core.NodeExpression, len(relationships))
 	for i, rel := range relationships {
-		endNodes[i] = rel.EndNode()
+		endNodes[i] = farNode(rel)
 	}
 
 	return &RelationshipChain{
@@ -152,12 +430,29 @@ func Chain(startNode core.NodeExpression, relationships ...core.RelationshipPatt
 	}
 }
 
+// farNode returns the node a relationship leads to when the pattern is read
+// from left to right. An incoming relationship, (a)<-[:R]-(b), is created by
+// a.RelationshipFrom(b) and starts at b, so the chain continues with its
+// start node; every other direction continues with the end node.
+func farNode(rel core.RelationshipPattern) core.NodeExpression {
+	if rel.Direction() == core.INCOMING {
+		return rel.StartNode()
+	}
+	return rel.EndNode()
+}
+
 // RelateNodes creates a simple relationship between two nodes
 func RelateNodes(fromNode core.NodeExpression, relType string, toNode core.NodeExpression) core.Expression {
 	rel := fromNode.RelationshipTo(toNode, relType)
 	return Pattern(fromNode, rel, toNode)
 }
 
+// RelateNodesFrom creates an incoming relationship between two nodes, (fromNode)<-[:R]-(toNode)
+func RelateNodesFrom(fromNode core.NodeExpression, relType string, toNode core.NodeExpression) core.Expression {
+	rel := fromNode.RelationshipFrom(toNode, relType)
+	return Pattern(fromNode, rel, toNode)
+}
+
 // RelateBidirectionally creates a bidirectional relationship between two nodes
 func RelateBidirectionally(node1 core.NodeExpression, relType string, node2 core.NodeExpression) core.Expression {
 	rel := node1.RelationshipBetween(node2, relType)
@@ -116,3 +116,63 @@ func TestChainEmpty(t *testing.T) {
 }
 
 
+
+func TestChainMixedDirections(t *testing.T) {
+	a := Node("A").Named("a")
+	b := Node("B").Named("b")
+	c := Node("C").Named("c")
+
+	tests := []struct {
+		name string
+		expr interface{ String() string }
+		want string
+	}{
+		{"incoming then outgoing", Chain(a, a.RelationshipFrom(b, "R"), b.RelationshipTo(c, "S")), "(a:A)<-[:`R`]-(b:B)-[:`S`]->(c:C)"},
+		{"outgoing then incoming", Chain(a, a.RelationshipTo(b, "R"), b.RelationshipFrom(c, "S")), "(a:A)-[:`R`]->(b:B)<-[:`S`]-(c:C)"},
+		{"undirected then incoming", Chain(a, a.RelationshipBetween(b, "R"), b.RelationshipFrom(c, "S")), "(a:A)-[:`R`]-(b:B)<-[:`S`]-(c:C)"},
+		{"pattern", Pattern(a, a.RelationshipFrom(b, "R"), b, b.RelationshipTo(c, "S"), c), "(a:A)<-[:`R`]-(b:B)-[:`S`]->(c:C)"},
+		{"single incoming", Pattern(a, a.RelationshipFrom(b, "R"), b), "(a:A)<-[:`R`]-(b:B)"},
+	}
+	for _, tt := range tests {
+		if got := tt.expr.String(); got != tt.want {
+			t.Errorf("%s: String() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestChainedRelationshipsWithPropertiesAndAliases(t *testing.T) {
+	a := Node("A").Named("a")
+	b := Node("B").Named("b")
+	c := Node("C").Named("c")
+	d := Node("D").Named("d")
+
+	path := a.RelationshipTo(b, "R").Named("r").Props(map[string]interface{}{"since": 2020}).
+		RelationshipFrom(c, "S").Named("s").
+		RelationshipBetween(d, "T").Props(map[string]interface{}{"weight": 1.5})
+
+	want := "(a:A)-[r:`R` {since: 2020}]->(b:B)<-[s:`S`]-(c:C)-[:`T` {weight: 1.5}]-(d:D)"
+	if got := path.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	incoming := a.RelationshipFrom(b, "R").RelationshipTo(c, "S")
+	if got, want := incoming.String(), "(a:A)<-[:`R`]-(b:B)-[:`S`]->(c:C)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	// Configuring a segment returns a new path and leaves the original unchanged
+	base := a.RelationshipTo(b, "R").RelationshipTo(c, "S")
+	_ = base.Named("s")
+	if got, want := base.String(), "(a:A)-[:`R`]->(b:B)-[:`S`]->(c:C)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestRelateNodesFrom(t *testing.T) {
+	person := Node("Person").Named("p")
+	movie := Node("Movie").Named("m")
+
+	if got, want := RelateNodesFrom(movie, "ACTED_IN", person).String(), "(m:Movie)<-[:`ACTED_IN`]-(p:Person)"; got != want {
+		t.Errorf("RelateNodesFrom() = %q, want %q", got, want)
+	}
+}
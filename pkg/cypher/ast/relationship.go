@@ -27,9 +27,7 @@ func (r *relationshipPattern) Named(alias string) core.RelationshipPattern {
 // WithProperties adds properties to this relationship pattern
 func (r *relationshipPattern) WithProperties(properties map[string]core.Expression) core.RelationshipPattern {
 	clone := *r
-	if clone.properties == nil {
-		clone.properties = make(map[string]core.Expression)
-	}
+	clone.properties = copyProperties(r.properties)
 	for k, v := range properties {
 		clone.properties[k] = v
 	}
@@ -39,9 +37,7 @@ func (r *relationshipPattern) WithProperties(properties map[string]core.Expressi
 // WithProps adds properties with automatic conversion to expressions
 func (r *relationshipPattern) WithProps(properties map[string]interface{}) core.RelationshipPattern {
 	clone := *r
-	if clone.properties == nil {
-		clone.properties = make(map[string]core.Expression)
-	}
+	clone.properties = copyProperties(r.properties)
 	for k, v := range properties {
 		clone.properties[k] = expr.LiteralFromValue(v)
 	}
@@ -55,10 +51,7 @@ func (r *relationshipPattern) Props(properties map[string]interface{}) core.Rela
 
 // Property returns a property access expression for this relationship
 func (r *relationshipPattern) Property(propertyName string) core.PropertyExpression {
-	return &propertyExpression{
-		subject:      r,
-		propertyName: propertyName,
-	}
+	return expr.NewProperty(r, propertyName)
 }
 
 // Prop is an alias for Property
@@ -86,6 +79,23 @@ func (r *relationshipPattern) Types() []string {
 	return r.types
 }
 
+// RelationshipTo continues the pattern with a relationship from the node this
+// relationship leads to, to another node, e.g. (a)-[:R]->(b)-[:S]->(c)
+func (r *relationshipPattern) RelationshipTo(other core.NodeExpression, types ...string) core.PathPattern {
+	return startChain(r).RelationshipTo(other, types...)
+}
+
+// RelationshipFrom continues the pattern with a relationship from another
+// node to the node this relationship leads to, e.g. (a)-[:R]->(b)<-[:S]-(c)
+func (r *relationshipPattern) RelationshipFrom(other core.NodeExpression, types ...string) core.PathPattern {
+	return startChain(r).RelationshipFrom(other, types...)
+}
+
+// RelationshipBetween continues the pattern with an undirected relationship
+func (r *relationshipPattern) RelationshipBetween(other core.NodeExpression, types ...string) core.PathPattern {
+	return startChain(r).RelationshipBetween(other, types...)
+}
+
 // Accept implements the Expression interface
 func (r *relationshipPattern) Accept(visitor core.ExpressionVisitor) any {
 	return visitor.Visit(r)
@@ -135,6 +145,8 @@ func (r *relationshipPattern) String() string {
 		sb.WriteString("`")
 	}
 
+	writeProperties(&sb, r.properties)
+
 	sb.WriteString("]")
 
 	// End with the appropriate arrow
@@ -150,21 +162,38 @@ func (r *relationshipPattern) String() string {
 	return sb.String()
 }
 
+// Validate reports an error when the relationship is missing one of its nodes
+func (r *relationshipPattern) Validate() error {
+	if isNilNode(r.startNode) || isNilNode(r.endNode) {
+		return core.NewError(core.ErrNodeRequired, "relationship "+r.String()+" requires a start and an end node")
+	}
+	return nil
+}
+
+// isNilNode reports whether a node is nil, including a typed nil pointer
+func isNilNode(node core.NodeExpression) bool {
+	if node == nil {
+		return true
+	}
+	n, ok := node.(*nodePattern)
+	return ok && n == nil
+}
+
 // Expressions returns all expressions contained in this relationship pattern
 func (r *relationshipPattern) Expressions() []core.Expression {
 	result := make([]core.Expression, 0, len(r.properties)+2)
 
 	// Add start and end nodes if they exist
-	if r.startNode != nil {
+	if !isNilNode(r.startNode) {
 		result = append(result, r.startNode)
 	}
-	if r.endNode != nil {
+	if !isNilNode(r.endNode) {
 		result = append(result, r.endNode)
 	}
 
 	// Add properties
-	for _, prop := range r.properties {
-		result = append(result, prop)
+	for _, key := range sortedPropertyKeys(r.properties) {
+		result = append(result, r.properties[key])
 	}
 
 	return result
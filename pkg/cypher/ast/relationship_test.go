@@ -1,6 +1,7 @@
 package ast
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
@@ -159,3 +160,36 @@ func TestRelationshipStringRepresentation(t *testing.T) {
 }
 
 
+
+// wrappedNode is a core.NodeExpression that was not created by Node()
+type wrappedNode struct {
+	core.NodeExpression
+}
+
+func TestRelationshipToForeignNode(t *testing.T) {
+	person := Node("Person").Named("p")
+	movie := wrappedNode{Node("Movie").Named("m")}
+
+	rel := person.RelationshipTo(movie, "ACTED_IN")
+	if rel.EndNode() != movie {
+		t.Errorf("EndNode() = %v, want the wrapped node", rel.EndNode())
+	}
+	if err := rel.(interface{ Validate() error }).Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestRelationshipWithNilNode(t *testing.T) {
+	person := Node("Person").Named("p")
+
+	for name, rel := range map[string]core.RelationshipPattern{
+		"to":      person.RelationshipTo(nil, "KNOWS"),
+		"from":    person.RelationshipFrom(nil, "KNOWS"),
+		"between": person.RelationshipBetween(nil, "KNOWS"),
+	} {
+		err := rel.(interface{ Validate() error }).Validate()
+		if !errors.Is(err, core.ErrNodeRequired) {
+			t.Errorf("%s: Validate() error = %v, want core.ErrNodeRequired", name, err)
+		}
+	}
+}
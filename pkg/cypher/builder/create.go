@@ -45,6 +45,15 @@ func (c *createBuilder) Set(expression core.Expression) SetBuilder {
 	}
 }
 
+// AppendRaw appends a raw Cypher string with its parameters after this clause
+func (c *createBuilder) AppendRaw(cypher string, params map[string]any) core.Buildable {
+	return &rawBuilder{
+		cypher: cypher,
+		params: params,
+		prev:   c,
+	}
+}
+
 // Build builds this CREATE into a complete statement
 func (c *createBuilder) Build() (core.Statement, error) {
 	// If this builder has a previous clause, we need to build that first
@@ -58,11 +67,24 @@ func (c *createBuilder) Build() (core.Statement, error) {
 		}
 	}
 
-	// Collect parameters
-	paramsMap := make(map[string]any)
+	if c.pattern == nil {
+		return nil, core.NewError(core.ErrEmptyPattern, "pattern is required for CREATE clause")
+	}
 
-	// Extract parameters from pattern
-	util.ExtractParameters(c.pattern, paramsMap)
+	if err := util.ValidateExpressions(c.pattern); err != nil {
+		return nil, err
+	}
+
+	if util.HasInlinePredicate(c.pattern) {
+		return nil, core.NewError(core.ErrInvalidPattern, "inline WHERE predicates are only allowed in MATCH patterns")
+	}
+
+	// Collect parameters, starting with those of the previous clauses so that
+	// unnamed parameters are numbered across the whole statement
+	params := util.NewParameters(prevStmt)
+	if err := params.Add(c.pattern); err != nil {
+		return nil, err
+	}
 
 	// Build CREATE clause
 	parts := []string{"CREATE", c.pattern.String()}
@@ -70,19 +92,11 @@ func (c *createBuilder) Build() (core.Statement, error) {
 	// Create the query string
 	query := strings.Join(parts, " ")
 
-	// Merge with previous statement if any
+	// Prepend the previous clauses if any
 	if prevStmt != nil {
-		// Merge previous parameters
-		prevParams := prevStmt.Params()
-		if prevParams != nil {
-			for k, v := range prevParams {
-				paramsMap[k] = v
-			}
-		}
-
-		return core.NewStatement(prevStmt.Cypher()+" "+query, paramsMap), nil
+		query = prevStmt.Cypher() + " " + query
 	}
 
-	// Create a new statement
-	return core.NewStatement(query, paramsMap), nil
+	return withClauses(core.NewStatement(params.Render(query), params.Values()), prevStmt,
+		clause("CREATE", c.pattern)), nil
 }
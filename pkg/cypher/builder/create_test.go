@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/ast"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/expr"
 )
 
 func TestCreate(t *testing.T) {
@@ -74,3 +76,39 @@ func TestCreateRelationship(t *testing.T) {
 	}
 }
 
+func TestCreateWithPropsParam(t *testing.T) {
+	props := map[string]any{"name": "John", "age": 30}
+	node := ast.Node("Person").Named("n").WithPropsParam(core.NewParameter("props", props))
+
+	stmt, err := Create(node).Build()
+	if err != nil {
+		t.Fatalf("Create().Build() error = %v", err)
+	}
+	if cypher := stmt.Cypher(); cypher != "CREATE (n:Person $props)" {
+		t.Errorf("Cypher() = %q, want 'CREATE (n:Person $props)'", cypher)
+	}
+	if got, ok := stmt.Params()["props"].(map[string]any); !ok || got["name"] != "John" {
+		t.Errorf("Params() = %v, should register the props parameter", stmt.Params())
+	}
+}
+
+func TestCreateSetReturning(t *testing.T) {
+	person := ast.Node("Person").Named("p")
+	stmt, err := Create(person).
+		Set(expr.SetProperty(person.Property("name"), core.NewParameter("", "John"))).
+		And(expr.SetLabels(person, "Active")).
+		With(expr.NewVariableExpression("p")).
+		Returning(expr.NewVariableExpression("p")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	want := "CREATE (p:Person) SET p.name = $p0, p:Active WITH p RETURN p"
+	if stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+	if stmt.Params()["p0"] != "John" {
+		t.Errorf("Params() = %v, should carry the SET parameter", stmt.Params())
+	}
+}
@@ -0,0 +1,253 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+)
+
+// Debuggable is implemented by every builder of this package
+type Debuggable interface {
+	// Debug describes the clause chain that ends with this builder
+	Debug() string
+}
+
+// debugClause describes a single builder of a chain
+type debugClause struct {
+	keyword string
+	fields  []string
+	prev    core.Buildable
+}
+
+// field adds a name=value entry to the description
+func (c *debugClause) field(name string, value any) {
+	c.fields = append(c.fields, fmt.Sprintf("%s=%v", name, value))
+}
+
+// debugDescriber is implemented by builders that can describe themselves
+type debugDescriber interface {
+	describe() debugClause
+}
+
+// debugChain describes the clause chain ending with b: a summary line such as
+// "MATCH -> WHERE -> RETURN" followed by one line per clause with the
+// expressions and settings each builder holds. Unlike Build, it never fails,
+// so it can be used on chains that produce wrong Cypher or an error.
+func debugChain(b core.Buildable) string {
+	var clauses []debugClause
+	for current := b; current != nil; {
+		describer, ok := current.(debugDescriber)
+		if !ok {
+			clauses = append(clauses, debugClause{keyword: fmt.Sprintf("%T", current)})
+			break
+		}
+		clause := describer.describe()
+		clauses = append(clauses, clause)
+		current = clause.prev
+	}
+
+	// Clauses were collected from the last one backwards
+	keywords := make([]string, len(clauses))
+	lines := make([]string, len(clauses))
+	for i := range clauses {
+		clause := clauses[len(clauses)-1-i]
+		keywords[i] = clause.keyword
+		lines[i] = strings.TrimSpace(fmt.Sprintf("[%d] %s %s", i, clause.keyword, strings.Join(clause.fields, " ")))
+	}
+
+	return strings.Join(keywords, " -> ") + "\n" + strings.Join(lines, "\n")
+}
+
+// debugExpression renders an expression for Debug, including its Go type
+func debugExpression(expr core.Expression) string {
+	if expr == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("%s (%T)", expr.String(), expr)
+}
+
+// debugExpressions renders a list of expressions for Debug
+func debugExpressions(exprs []core.Expression) string {
+	items := make([]string, len(exprs))
+	for i, expr := range exprs {
+		items[i] = debugExpression(expr)
+	}
+	return "[" + strings.Join(items, ", ") + "]"
+}
+
+// debugProjection adds the fields shared by WITH and RETURN
+func debugProjection(c *debugClause, orderBy []core.Expression, orderDir string, skip, limit int) {
+	if len(orderBy) > 0 {
+		c.field("orderBy", debugExpressions(orderBy))
+	}
+	if orderDir != "" {
+		c.field("direction", orderDir)
+	}
+	if skip > 0 {
+		c.field("skip", skip)
+	}
+	if limit > 0 {
+		c.field("limit", limit)
+	}
+}
+
+func (m *matchBuilder) describe() debugClause {
+	c := debugClause{keyword: "MATCH", prev: m.prev}
+	if m.optional {
+		c.keyword = "OPTIONAL MATCH"
+	}
+	c.field("patterns", debugExpressions(m.patterns))
+	if m.whereClause != nil {
+		c.field("where", debugExpression(m.whereClause))
+	}
+	return c
+}
+
+func (w *whereBuilder) describe() debugClause {
+	c := debugClause{keyword: "WHERE", prev: w.prev}
+	c.field("condition", debugExpression(w.condition))
+	return c
+}
+
+func (w *withBuilder) describe() debugClause {
+	c := debugClause{keyword: "WITH", prev: w.prev}
+	if w.distinct {
+		c.field("distinct", true)
+	}
+	c.field("items", debugExpressions(w.expressions))
+	if w.whereClause != nil {
+		c.field("where", debugExpression(w.whereClause))
+	}
+	debugProjection(&c, w.orderBy, w.orderDir, w.skipValue, w.limitValue)
+	return c
+}
+
+func (r *returnBuilder) describe() debugClause {
+	c := debugClause{keyword: "RETURN", prev: r.prev}
+	if r.distinct {
+		c.field("distinct", true)
+	}
+	if r.returnAll {
+		c.field("items", "*")
+	} else {
+		c.field("items", debugExpressions(r.expressions))
+	}
+	debugProjection(&c, r.orderBy, r.orderDir, r.skipValue, r.limitValue)
+	return c
+}
+
+func (c *createBuilder) describe() debugClause {
+	d := debugClause{keyword: "CREATE", prev: c.prev}
+	d.field("pattern", debugExpression(c.pattern))
+	return d
+}
+
+func (m *mergeBuilder) describe() debugClause {
+	c := debugClause{keyword: "MERGE", prev: m.prev}
+	c.field("pattern", debugExpression(m.pattern))
+	if len(m.onCreateExprs) > 0 {
+		c.field("onCreate", debugExpressions(m.onCreateExprs))
+	}
+	if len(m.onMatchExprs) > 0 {
+		c.field("onMatch", debugExpressions(m.onMatchExprs))
+	}
+	return c
+}
+
+func (s *setBuilder) describe() debugClause {
+	c := debugClause{keyword: "SET", prev: s.prev}
+	c.field("items", debugExpressions(s.expressions))
+	return c
+}
+
+func (d *deleteBuilder) describe() debugClause {
+	c := debugClause{keyword: "DELETE", prev: d.prev}
+	if d.detach {
+		c.keyword = "DETACH DELETE"
+	}
+	c.field("items", debugExpressions(d.expressions))
+	return c
+}
+
+func (r *removeBuilder) describe() debugClause {
+	c := debugClause{keyword: "REMOVE", prev: r.prev}
+	c.field("items", debugExpressions(r.expressions))
+	return c
+}
+
+func (u *unwindBuilder) describe() debugClause {
+	c := debugClause{keyword: "UNWIND", prev: u.prev}
+	c.field("expression", debugExpression(u.expression))
+	c.field("alias", u.alias)
+	return c
+}
+
+func (o *orderByBuilder) describe() debugClause {
+	c := debugClause{keyword: "ORDER BY", prev: o.prev}
+	debugProjection(&c, o.expressions, o.direction, o.skipValue, o.limitValue)
+	return c
+}
+
+func (s *skipBuilder) describe() debugClause {
+	c := debugClause{keyword: "SKIP", prev: s.prev}
+	c.field("count", s.skip)
+	return c
+}
+
+func (l *limitBuilder) describe() debugClause {
+	c := debugClause{keyword: "LIMIT", prev: l.prev}
+	c.field("count", l.limit)
+	return c
+}
+
+func (r *rawBuilder) describe() debugClause {
+	c := debugClause{keyword: "RAW", prev: r.prev}
+	c.field("cypher", fmt.Sprintf("%q", r.cypher))
+	if len(r.params) > 0 {
+		c.field("params", core.NewStatement(r.cypher, r.params).ParamsString())
+	}
+	return c
+}
+
+// Debug describes the clause chain that ends with this builder
+func (m *matchBuilder) Debug() string { return debugChain(m) }
+
+// Debug describes the clause chain that ends with this builder
+func (w *whereBuilder) Debug() string { return debugChain(w) }
+
+// Debug describes the clause chain that ends with this builder
+func (w *withBuilder) Debug() string { return debugChain(w) }
+
+// Debug describes the clause chain that ends with this builder
+func (r *returnBuilder) Debug() string { return debugChain(r) }
+
+// Debug describes the clause chain that ends with this builder
+func (c *createBuilder) Debug() string { return debugChain(c) }
+
+// Debug describes the clause chain that ends with this builder
+func (m *mergeBuilder) Debug() string { return debugChain(m) }
+
+// Debug describes the clause chain that ends with this builder
+func (s *setBuilder) Debug() string { return debugChain(s) }
+
+// Debug describes the clause chain that ends with this builder
+func (d *deleteBuilder) Debug() string { return debugChain(d) }
+
+// Debug describes the clause chain that ends with this builder
+func (r *removeBuilder) Debug() string { return debugChain(r) }
+
+// Debug describes the clause chain that ends with this builder
+func (u *unwindBuilder) Debug() string { return debugChain(u) }
+
+// Debug describes the clause chain that ends with this builder
+func (o *orderByBuilder) Debug() string { return debugChain(o) }
+
+// Debug describes the clause chain that ends with this builder
+func (s *skipBuilder) Debug() string { return debugChain(s) }
+
+// Debug describes the clause chain that ends with this builder
+func (l *limitBuilder) Debug() string { return debugChain(l) }
+
+// Debug describes the clause chain that ends with this builder
+func (r *rawBuilder) Debug() string { return debugChain(r) }
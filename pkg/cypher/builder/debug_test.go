@@ -0,0 +1,61 @@
+package builder
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/ast"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/expr"
+)
+
+func TestDebug(t *testing.T) {
+	person := ast.Node("Person").Named("p")
+	movie := ast.Node("Movie").Named("m")
+	p := expr.NewVariableExpression("p")
+
+	builder := Match(person).
+		Where(expr.GreaterThan(person.Property("age"), expr.Integer(30))).
+		With(p).
+		Match(movie).
+		Returning(p, expr.NewVariableExpression("m")).
+		Limit(5)
+
+	debug := builder.Debug()
+	lines := strings.Split(debug, "\n")
+	if lines[0] != "MATCH -> WITH -> MATCH -> RETURN" {
+		t.Errorf("Debug() summary = %q, want %q", lines[0], "MATCH -> WITH -> MATCH -> RETURN")
+	}
+	if len(lines) != 5 {
+		t.Fatalf("Debug() = %q, want a summary and one line per clause", debug)
+	}
+
+	expected := []string{
+		"[0] MATCH patterns=[(p:Person) (*ast.nodePattern)] where=(p.age > 30) (*expr.ComparisonExpression)",
+		"[1] WITH items=[p (*expr.VariableExpression)]",
+		"[2] MATCH patterns=[(m:Movie) (*ast.nodePattern)]",
+		"[3] RETURN items=[p (*expr.VariableExpression), m (*expr.VariableExpression)] limit=5",
+	}
+	for i, want := range expected {
+		if lines[i+1] != want {
+			t.Errorf("Debug() line %d = %q, want %q", i+1, lines[i+1], want)
+		}
+	}
+}
+
+func TestDebugDoesNotBuild(t *testing.T) {
+	// A MATCH without patterns fails to build but can still be described
+	debug := Match().Where(nil).Returning().Debug()
+	if !strings.HasPrefix(debug, "MATCH -> RETURN\n[0] MATCH patterns=[]") {
+		t.Errorf("Debug() = %q, should describe the invalid chain", debug)
+	}
+}
+
+func TestDebugRawParams(t *testing.T) {
+	raw := Create(ast.Node("Person").Named("p")).
+		AppendRaw("SET p.name = $name, p.age = $age", map[string]any{"name": "Ann", "age": 30}).(Debuggable)
+
+	want := `[1] RAW cypher="SET p.name = $name, p.age = $age" params={age: 30, name: "Ann"}`
+	if lines := strings.Split(raw.Debug(), "\n"); lines[len(lines)-1] != want {
+		t.Errorf("Debug() last line = %q, want %q", lines[len(lines)-1], want)
+	}
+}
@@ -2,6 +2,7 @@ package builder
 
 import (
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/internal/util"
 )
 
 // deleteBuilder implements the DeleteBuilder interface
@@ -27,26 +28,40 @@ func (d *deleteBuilder) Returning(expressions ...core.Expression) ReturnBuilder
 	}
 }
 
+// AppendRaw appends a raw Cypher string with its parameters after this clause
+func (d *deleteBuilder) AppendRaw(cypher string, params map[string]any) core.Buildable {
+	return &rawBuilder{
+		cypher: cypher,
+		params: params,
+		prev:   d,
+	}
+}
+
 // Build builds this DELETE into a complete statement
 func (d *deleteBuilder) Build() (core.Statement, error) {
-	// Simple implementation for now
 	var cypher string
-
 	// If this builder has a previous clause, we need to build that first
+	var prevStmt core.Statement
 	if d.prev != nil {
-		prevStmt, err := d.prev.Build()
+		var err error
+		prevStmt, err = d.prev.Build()
 		if err != nil {
 			return nil, err
 		}
 		cypher = prevStmt.Cypher() + " "
 	}
 
+	params := util.NewParameters(prevStmt)
+	if err := params.Add(d.expressions...); err != nil {
+		return nil, err
+	}
+
 	// Add DELETE or DETACH DELETE keyword
+	keyword := "DELETE"
 	if d.detach {
-		cypher += "DETACH DELETE "
-	} else {
-		cypher += "DELETE "
+		keyword = "DETACH DELETE"
 	}
+	cypher += keyword + " "
 
 	// Add expressions
 	for i, expr := range d.expressions {
@@ -56,5 +71,6 @@ func (d *deleteBuilder) Build() (core.Statement, error) {
 		cypher += expr.String()
 	}
 
-	return core.NewStatement(cypher, nil), nil
+	return withClauses(core.NewStatement(params.Render(cypher), params.Values()), prevStmt,
+		clause(keyword, d.expressions...)), nil
 }
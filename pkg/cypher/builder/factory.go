@@ -1,21 +1,23 @@
 package builder
 
 import (
+	"strings"
+
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
 )
 
-// Match creates a new MATCH clause
-func Match(pattern core.Expression) MatchBuilder {
+// Match creates a new MATCH clause; multiple patterns are comma-separated
+func Match(patterns ...core.Expression) MatchBuilder {
 	return &matchBuilder{
-		pattern:  pattern,
+		patterns: patterns,
 		optional: false,
 	}
 }
 
 // OptionalMatch creates a new OPTIONAL MATCH clause
-func OptionalMatch(pattern core.Expression) MatchBuilder {
+func OptionalMatch(patterns ...core.Expression) MatchBuilder {
 	return &matchBuilder{
-		pattern:  pattern,
+		patterns: patterns,
 		optional: true,
 	}
 }
@@ -113,3 +115,19 @@ func Unwind(expression core.Expression, alias string) UnwindBuilder {
 		alias:      alias,
 	}
 }
+
+// UnwindParam creates an UNWIND clause over a list passed as a parameter,
+// e.g. UnwindParam(rows, "row") renders UNWIND $rows AS row. The parameter
+// is named after the alias: an "s" is appended, or "List" when the alias
+// already ends with an "s".
+func UnwindParam(value any, alias string) UnwindBuilder {
+	return Unwind(core.NewParameter(unwindParamName(alias), value), alias)
+}
+
+// unwindParamName derives the name of the list parameter from the UNWIND alias
+func unwindParamName(alias string) string {
+	if strings.HasSuffix(alias, "s") {
+		return alias + "List"
+	}
+	return alias + "s"
+}
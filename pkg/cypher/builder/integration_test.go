@@ -1,7 +1,10 @@
 package builder
 
 import (
+	"errors"
+	"reflect"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/ast"
@@ -148,3 +151,327 @@ func TestQueryWithMerge(t *testing.T) {
 	}
 }
 
+func TestRelationshipPropertyAlias(t *testing.T) {
+	person := ast.Node("Person").Named("p")
+	movie := ast.Node("Movie").Named("m")
+
+	actedIn := person.RelationshipTo(movie, "ACTED_IN").Named("rel")
+	stmt, err := Match(ast.Pattern(person, actedIn, movie)).
+		Where(actedIn.Property("role").Eq("Neo")).
+		Returning(actedIn.Property("role").As("role")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if cypher := stmt.Cypher(); !strings.Contains(cypher, "rel.role = 'Neo'") || !strings.Contains(cypher, "rel.role AS role") {
+		t.Errorf("Cypher() = %q, should reference the relationship as rel", cypher)
+	}
+
+	unnamed := person.RelationshipTo(movie, "ACTED_IN")
+	_, err = Match(ast.Pattern(person, unnamed, movie)).
+		Where(unnamed.Property("role").Eq("Neo")).
+		Returning(person).
+		Build()
+	if !errors.Is(err, core.ErrMissingAlias) {
+		t.Errorf("Build() error = %v, want core.ErrMissingAlias for an unnamed relationship", err)
+	}
+
+	_, err = Match(ast.Pattern(person, unnamed, movie)).
+		Returning(unnamed.Property("role").As("role")).
+		Build()
+	if !errors.Is(err, core.ErrMissingAlias) {
+		t.Errorf("Build() error = %v, want core.ErrMissingAlias for an aliased property of an unnamed relationship", err)
+	}
+}
+
+func TestAppendRaw(t *testing.T) {
+	person := ast.Node("Person").Named("p")
+
+	stmt, err := Match(person).
+		Where(person.Property("name").Eq(core.NewParameter("name", "Tom"))).
+		Returning(expr.NewVariableExpression("p")).
+		AppendRaw("UNION MATCH (p:Person {id: $id}) RETURN p", map[string]any{"id": 7}).
+		Build()
+	if err != nil {
+		t.Fatalf("AppendRaw().Build() error = %v", err)
+	}
+
+	expected := "MATCH (p:Person) WHERE (p.name = $name) RETURN p UNION MATCH (p:Person {id: $id}) RETURN p"
+	if cypher := stmt.Cypher(); cypher != expected {
+		t.Errorf("Cypher() = %q, want %q", cypher, expected)
+	}
+	if params := stmt.Params(); params["name"] != "Tom" || params["id"] != 7 {
+		t.Errorf("Params() = %v, should merge the clause and raw parameters", params)
+	}
+
+	stmt, err = Create(person).AppendRaw("RETURN p", nil).Build()
+	if err != nil {
+		t.Fatalf("Create().AppendRaw().Build() error = %v", err)
+	}
+	if cypher := stmt.Cypher(); cypher != "CREATE (p:Person) RETURN p" {
+		t.Errorf("Cypher() = %q, want 'CREATE (p:Person) RETURN p'", cypher)
+	}
+}
+
+func TestTypedBuildErrors(t *testing.T) {
+	person := ast.Node("Person").Named("p")
+	p := expr.NewVariableExpression("p")
+
+	tests := []struct {
+		name    string
+		builder core.Buildable
+		want    error
+		general error
+	}{
+		{"nil where", Where(nil), core.ErrNilCondition, core.ErrInvalidExpression},
+		{"nil and where", Where(expr.Boolean(true)).AndWhere(nil), core.ErrNilCondition, core.ErrInvalidExpression},
+		{"nil match where", Match(person).Where(nil).Returning(p), core.ErrNilCondition, core.ErrInvalidExpression},
+		{"nil with where", Match(person).With(p).Where(nil).Returning(p), core.ErrNilCondition, core.ErrInvalidExpression},
+		{"empty match", Match(), core.ErrEmptyPattern, core.ErrInvalidPattern},
+		{"nil create", Create(nil), core.ErrEmptyPattern, core.ErrInvalidPattern},
+		{"nil merge", Merge(nil), core.ErrEmptyPattern, core.ErrInvalidPattern},
+		{"empty return", Match(person).Returning(), core.ErrEmptyReturn, core.ErrInvalidQuery},
+		{"no labels", Match(person).Set(expr.SetLabels(p)), core.ErrNoLabels, core.ErrInvalidExpression},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.builder.Build()
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Build() error = %v, want %v", err, tt.want)
+			}
+			if !errors.Is(err, tt.general) {
+				t.Errorf("Build() error = %v, should also match %v", err, tt.general)
+			}
+		})
+	}
+}
+
+func TestRelationshipWithNilNodeFailsToBuild(t *testing.T) {
+	person := ast.Node("Person").Named("p")
+	rel := person.RelationshipTo(nil, "KNOWS")
+
+	for name, builder := range map[string]core.Buildable{
+		"match":  Match(rel),
+		"create": Create(rel),
+		"merge":  Merge(rel),
+	} {
+		if _, err := builder.Build(); !errors.Is(err, core.ErrNodeRequired) {
+			t.Errorf("%s: Build() error = %v, want core.ErrNodeRequired", name, err)
+		}
+	}
+}
+
+func TestMapRenderingIsStable(t *testing.T) {
+	props := map[string]any{"name": "John", "age": 30, "city": "Berlin", "active": true, "id": 7}
+
+	tests := []struct {
+		name   string
+		render func() string
+		want   string
+	}{
+		{"node properties", func() string { return ast.Node("Person").Named("p").WithProps(props).String() },
+			"(p:Person {active: true, age: 30, city: 'Berlin', id: 7, name: 'John'})"},
+		{"map literal", func() string { return expr.LiteralFromValue(props).String() },
+			"{active: true, age: 30, city: 'Berlin', id: 7, name: 'John'}"},
+		{"core literal", func() string { return core.NewLiteral(props).String() },
+			"{active: true, age: 30, city: 'Berlin', id: 7, name: 'John'}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				if got := tt.render(); got != tt.want {
+					t.Fatalf("render #%d = %q, want %q", i, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestFragmentReuse(t *testing.T) {
+	person := ast.Node("Person").Named("p")
+	active := expr.Fragment(expr.RawCypher("p.active = $active"), map[string]any{"active": true})
+
+	first, err := Match(person).Where(active).Returning(expr.NewVariableExpression("p")).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if want := "MATCH (p:Person) WHERE p.active = $active RETURN p"; first.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", first.Cypher(), want)
+	}
+	if first.Params()["active"] != true {
+		t.Errorf("Params() = %v, should include the fragment parameter", first.Params())
+	}
+
+	second, err := Match(person).
+		Where(active.And(expr.GreaterThan(person.Property("age"), core.NewParameter("age", 30)))).
+		Returning(expr.NewVariableExpression("p")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if want := "MATCH (p:Person) WHERE (p.active = $active AND (p.age > $age)) RETURN p"; second.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", second.Cypher(), want)
+	}
+	if !reflect.DeepEqual(second.Params(), map[string]any{"active": true, "age": 30}) {
+		t.Errorf("Params() = %v, want active and age", second.Params())
+	}
+
+	scoped := active.WithParam("active", false)
+	if active.Params["active"] != true || scoped.Params["active"] != false {
+		t.Errorf("WithParam() should not change the original fragment")
+	}
+}
+
+func TestUnnamedParametersAreNumberedDeterministically(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		person := ast.Node("Person").Named("p").WithProperties(map[string]core.Expression{
+			"name": core.NewParameter("", "John"),
+			"age":  core.NewParameter("", 30),
+			"city": core.NewParameter("", "Berlin"),
+		})
+		stmt, err := Match(person).
+			Where(person.Property("active").Eq(core.NewParameter("", true))).
+			Returning(expr.NewVariableExpression("p")).
+			Build()
+		if err != nil {
+			t.Fatalf("Build() error = %v", err)
+		}
+
+		wantCypher := "MATCH (p:Person {age: $p0, city: $p1, name: $p2}) WHERE (p.active = $p3) RETURN p"
+		if stmt.Cypher() != wantCypher {
+			t.Fatalf("build #%d Cypher() = %q, want %q", i, stmt.Cypher(), wantCypher)
+		}
+		wantParams := map[string]any{"p0": 30, "p1": "Berlin", "p2": "John", "p3": true}
+		if !reflect.DeepEqual(stmt.Params(), wantParams) {
+			t.Fatalf("build #%d Params() = %v, want %v", i, stmt.Params(), wantParams)
+		}
+	}
+}
+
+func TestUnnamedParametersKeepTheirNamesAcrossBuilds(t *testing.T) {
+	person := ast.Node("Person").Named("p")
+	query := Match(person).
+		Where(person.Property("name").Eq(core.NewParameter("", "John"))).
+		Returning(expr.NewVariableExpression("p"))
+
+	first, err := query.Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	second, err := query.Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if first.Cypher() != second.Cypher() || !reflect.DeepEqual(first.Params(), second.Params()) {
+		t.Errorf("rebuilding changed the statement: %q %v, then %q %v",
+			first.Cypher(), first.Params(), second.Cypher(), second.Params())
+	}
+	if first.Params()["p0"] != "John" {
+		t.Errorf("Params() = %v, want p0 = John", first.Params())
+	}
+}
+
+func TestUnnamedParameterSharedBetweenStatements(t *testing.T) {
+	person := ast.Node("Person").Named("p")
+	shared := core.NewParameter("", 30)
+	build := func(property, value string) core.Statement {
+		stmt, err := Match(person).
+			Where(expr.And(person.Property(property).Eq(core.NewParameter("", value)), person.Property("age").Gt(shared))).
+			Returning(expr.NewVariableExpression("p")).
+			Build()
+		if err != nil {
+			t.Fatalf("Build() error = %v", err)
+		}
+		return stmt
+	}
+
+	first := build("a", "A")
+	second := build("b", "B")
+	if want := "MATCH (p:Person) WHERE ((p.b = $p0) AND (p.age > $p1)) RETURN p"; second.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", second.Cypher(), want)
+	}
+	if want := map[string]any{"p0": "B", "p1": 30}; !reflect.DeepEqual(second.Params(), want) {
+		t.Errorf("Params() = %v, want %v", second.Params(), want)
+	}
+	if want := map[string]any{"p0": "A", "p1": 30}; !reflect.DeepEqual(first.Params(), want) {
+		t.Errorf("first Params() = %v, want %v", first.Params(), want)
+	}
+	if shared.String() == "$p1" {
+		t.Errorf("building named the shared parameter itself: %s", shared)
+	}
+}
+
+func TestUnnamedParametersSkipNamesTakenByTheUser(t *testing.T) {
+	person := ast.Node("Person").Named("p")
+	stmt, err := Match(person).
+		Where(expr.And(person.Property("a").Eq(core.NewParameter("", "A")), person.Property("b").Eq(core.NewParameter("p0", "B")))).
+		Returning(expr.NewVariableExpression("p")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if want := "MATCH (p:Person) WHERE ((p.a = $p1) AND (p.b = $p0)) RETURN p"; stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+	if want := map[string]any{"p0": "B", "p1": "A"}; !reflect.DeepEqual(stmt.Params(), want) {
+		t.Errorf("Params() = %v, want %v", stmt.Params(), want)
+	}
+
+	// A later clause cannot rename the parameters of an earlier one
+	_, err = Match(person).
+		Where(person.Property("a").Eq(core.NewParameter("", "A"))).
+		Returning(expr.As(core.NewParameter("p0", "B"), "b")).
+		Build()
+	if !errors.Is(err, core.ErrInvalidParameter) {
+		t.Errorf("Build() error = %v, want core.ErrInvalidParameter", err)
+	}
+}
+
+func TestUnnamedParametersConcurrentBuilds(t *testing.T) {
+	person := ast.Node("Person").Named("p")
+	shared := core.NewParameter("", 30)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			stmt, err := Match(person).
+				Where(expr.And(person.Property("name").Eq(core.NewParameter("", i)), person.Property("age").Gt(shared))).
+				Returning(expr.NewVariableExpression("p")).
+				Build()
+			if err != nil {
+				t.Errorf("Build() error = %v", err)
+				return
+			}
+			if want := map[string]any{"p0": i, "p1": 30}; !reflect.DeepEqual(stmt.Params(), want) {
+				t.Errorf("Params() = %v, want %v", stmt.Params(), want)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestUnnamedParametersWithEqualValuesShareAName(t *testing.T) {
+	person := ast.Node("Person").Named("p")
+	stmt, err := Match(person).
+		Where(expr.And(
+			person.Property("city").Eq(core.NewParameter("", "Berlin")),
+			expr.Or(person.Property("born").Eq(core.NewParameter("", "Berlin")), person.Property("age").Gt(core.NewParameter("", 30))),
+		)).
+		Returning(expr.NewVariableExpression("p")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	wantCypher := "MATCH (p:Person) WHERE ((p.city = $p0) AND ((p.born = $p0) OR (p.age > $p1))) RETURN p"
+	if stmt.Cypher() != wantCypher {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), wantCypher)
+	}
+	wantParams := map[string]any{"p0": "Berlin", "p1": 30}
+	if !reflect.DeepEqual(stmt.Params(), wantParams) {
+		t.Errorf("Params() = %v, want %v", stmt.Params(), wantParams)
+	}
+}
@@ -8,12 +8,17 @@ import (
 // MatchBuilder builds MATCH clauses
 type MatchBuilder interface {
 	core.Buildable
+	Debuggable
 	// Where adds a WHERE clause
 	Where(condition core.Expression) MatchBuilder
+	// WhereIf adds a WHERE clause only when cond is true
+	WhereIf(cond bool, condition core.Expression) MatchBuilder
 	// OptionalMatch adds an OPTIONAL MATCH clause
-	OptionalMatch(pattern core.Expression) MatchBuilder
+	OptionalMatch(patterns ...core.Expression) MatchBuilder
 	// Match adds a MATCH clause
-	Match(pattern core.Expression) MatchBuilder
+	Match(patterns ...core.Expression) MatchBuilder
+	// AndMatch adds a pattern to this MATCH clause, comma-separated
+	AndMatch(pattern core.Expression) MatchBuilder
 	// Create adds a CREATE clause
 	Create(pattern core.Expression) CreateBuilder
 	// Merge adds a MERGE clause
@@ -37,6 +42,7 @@ type MatchBuilder interface {
 // WhereBuilder builds WHERE clauses
 type WhereBuilder interface {
 	core.Buildable
+	Debuggable
 	// Where adds another condition with AND
 	AndWhere(condition core.Expression) WhereBuilder
 	// OrWhere adds another condition with OR
@@ -50,18 +56,29 @@ type WhereBuilder interface {
 // WithBuilder builds WITH clauses
 type WithBuilder interface {
 	core.Buildable
+	Debuggable
+	// Distinct makes this a WITH DISTINCT clause
+	Distinct() WithBuilder
 	// Where adds a WHERE clause
 	Where(condition core.Expression) WithBuilder
+	// WhereIf adds a WHERE clause only when cond is true
+	WhereIf(cond bool, condition core.Expression) WithBuilder
 	// OrderBy adds an ORDER BY clause
 	OrderBy(expressions ...core.Expression) WithOrderable
+	// OrderByIf adds an ORDER BY clause only when cond is true
+	OrderByIf(cond bool, expressions ...core.Expression) WithOrderable
 	// Skip adds a SKIP clause
 	Skip(count int) WithBuilder
+	// SkipIf adds a SKIP clause only when cond is true
+	SkipIf(cond bool, count int) WithBuilder
 	// Limit adds a LIMIT clause
 	Limit(count int) WithBuilder
+	// LimitIf adds a LIMIT clause only when cond is true
+	LimitIf(cond bool, count int) WithBuilder
 	// Match adds a MATCH clause
-	Match(pattern core.Expression) MatchBuilder
+	Match(patterns ...core.Expression) MatchBuilder
 	// OptionalMatch adds an OPTIONAL MATCH clause
-	OptionalMatch(pattern core.Expression) MatchBuilder
+	OptionalMatch(patterns ...core.Expression) MatchBuilder
 	// Create adds a CREATE clause
 	Create(pattern core.Expression) CreateBuilder
 	// Merge adds a MERGE clause
@@ -82,15 +99,27 @@ type WithBuilder interface {
 	Unwind(expression core.Expression, alias string) UnwindBuilder
 }
 
-// ReturnBuilder builds RETURN clauses
+// ReturnBuilder builds RETURN clauses. Build fails with core.ErrEmptyReturn
+// when no items are given, since RETURN needs at least one.
 type ReturnBuilder interface {
 	core.Buildable
+	Debuggable
+	// Distinct makes this a RETURN DISTINCT clause
+	Distinct() ReturnBuilder
 	// OrderBy adds an ORDER BY clause
 	OrderBy(expressions ...core.Expression) ReturnOrderable
+	// OrderByIf adds an ORDER BY clause only when cond is true
+	OrderByIf(cond bool, expressions ...core.Expression) ReturnOrderable
 	// Skip adds a SKIP clause
 	Skip(count int) ReturnBuilder
+	// SkipIf adds a SKIP clause only when cond is true
+	SkipIf(cond bool, count int) ReturnBuilder
 	// Limit adds a LIMIT clause
 	Limit(count int) ReturnBuilder
+	// LimitIf adds a LIMIT clause only when cond is true
+	LimitIf(cond bool, count int) ReturnBuilder
+	// AppendRaw appends a raw Cypher string with its parameters after this clause
+	AppendRaw(cypher string, params map[string]any) core.Buildable
 }
 
 // ReturnOrderable is a ReturnBuilder that supports ORDER BY
@@ -114,6 +143,7 @@ type WithOrderable interface {
 // CreateBuilder builds CREATE clauses
 type CreateBuilder interface {
 	core.Buildable
+	Debuggable
 	// Create adds another CREATE clause
 	Create(pattern core.Expression) CreateBuilder
 	// With adds a WITH clause
@@ -122,61 +152,76 @@ type CreateBuilder interface {
 	Returning(expressions ...core.Expression) ReturnBuilder
 	// Set adds a SET clause
 	Set(expression core.Expression) SetBuilder
+	// AppendRaw appends a raw Cypher string with its parameters after this clause
+	AppendRaw(cypher string, params map[string]any) core.Buildable
 }
 
 // MergeBuilder builds MERGE clauses
 type MergeBuilder interface {
 	core.Buildable
-	// OnCreate adds an ON CREATE SET clause
-	OnCreate(expression core.Expression) MergeBuilder
-	// OnMatch adds an ON MATCH SET clause
-	OnMatch(expression core.Expression) MergeBuilder
+	Debuggable
+	// OnCreate adds items, such as SetMutate or SetLabels, to the ON CREATE SET clause
+	OnCreate(expressions ...core.Expression) MergeBuilder
+	// OnMatch adds items to the ON MATCH SET clause
+	OnMatch(expressions ...core.Expression) MergeBuilder
 	// With adds a WITH clause
 	With(expressions ...core.Expression) WithBuilder
 	// Return adds a RETURN clause
 	Returning(expressions ...core.Expression) ReturnBuilder
+	// AppendRaw appends a raw Cypher string with its parameters after this clause
+	AppendRaw(cypher string, params map[string]any) core.Buildable
 }
 
 // DeleteBuilder builds DELETE clauses
 type DeleteBuilder interface {
 	core.Buildable
+	Debuggable
 	// With adds a WITH clause
 	With(expressions ...core.Expression) WithBuilder
 	// Return adds a RETURN clause
 	Returning(expressions ...core.Expression) ReturnBuilder
+	// AppendRaw appends a raw Cypher string with its parameters after this clause
+	AppendRaw(cypher string, params map[string]any) core.Buildable
 }
 
 // SetBuilder builds SET clauses
 type SetBuilder interface {
 	core.Buildable
+	Debuggable
 	// And adds another SET operation
 	And(expression core.Expression) SetBuilder
 	// With adds a WITH clause
 	With(expressions ...core.Expression) WithBuilder
 	// Return adds a RETURN clause
 	Returning(expressions ...core.Expression) ReturnBuilder
+	// AppendRaw appends a raw Cypher string with its parameters after this clause
+	AppendRaw(cypher string, params map[string]any) core.Buildable
 }
 
 // RemoveBuilder builds REMOVE clauses
 type RemoveBuilder interface {
 	core.Buildable
+	Debuggable
 	// And adds another REMOVE operation
 	And(expression core.Expression) RemoveBuilder
 	// With adds a WITH clause
 	With(expressions ...core.Expression) WithBuilder
 	// Return adds a RETURN clause
 	Returning(expressions ...core.Expression) ReturnBuilder
+	// AppendRaw appends a raw Cypher string with its parameters after this clause
+	AppendRaw(cypher string, params map[string]any) core.Buildable
 }
 
 // UnwindBuilder builds UNWIND clauses
 type UnwindBuilder interface {
 	core.Buildable
+	Debuggable
 	// Where adds a WHERE clause
 	Where(condition core.Expression) WhereBuilder
 	// Match adds a MATCH clause
-	Match(pattern core.Expression) MatchBuilder
+	Match(patterns ...core.Expression) MatchBuilder
 	// OptionalMatch adds an OPTIONAL MATCH clause
-	OptionalMatch(pattern core.Expression) MatchBuilder
+	OptionalMatch(patterns ...core.Expression) MatchBuilder
 	// With adds a WITH clause
 	With(expressions ...core.Expression) WithBuilder
 	// Return adds a RETURN clause
@@ -186,6 +231,7 @@ type UnwindBuilder interface {
 // OrderByBuilder builds ORDER BY clauses
 type OrderByBuilder interface {
 	core.Buildable
+	Debuggable
 	// Asc specifies ascending order
 	Asc() OrderByBuilder
 	// Desc specifies descending order
@@ -199,11 +245,13 @@ type OrderByBuilder interface {
 // LimitBuilder builds LIMIT clauses
 type LimitBuilder interface {
 	core.Buildable
+	Debuggable
 }
 
 // SkipBuilder builds SKIP clauses
 type SkipBuilder interface {
 	core.Buildable
+	Debuggable
 	// Limit adds a LIMIT clause
 	Limit(count int) LimitBuilder
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/expr"
 )
 
 // limitBuilder implements the LimitBuilder interface
@@ -14,20 +15,26 @@ type limitBuilder struct {
 
 // Build builds this LIMIT into a complete statement
 func (l *limitBuilder) Build() (core.Statement, error) {
-	// Simple implementation for now
 	var cypher string
+	paramsMap := make(map[string]any)
 
 	// If this builder has a previous clause, we need to build that first
+	var prevStmt core.Statement
 	if l.prev != nil {
-		prevStmt, err := l.prev.Build()
+		var err error
+		prevStmt, err = l.prev.Build()
 		if err != nil {
 			return nil, err
 		}
 		cypher = prevStmt.Cypher() + " "
+		for k, v := range prevStmt.Params() {
+			paramsMap[k] = v
+		}
 	}
 
 	// Add LIMIT clause
 	cypher += fmt.Sprintf("LIMIT %d", l.limit)
 
-	return core.NewStatement(cypher, nil), nil
+	return withClauses(core.NewStatement(cypher, paramsMap), prevStmt,
+		clause("LIMIT", expr.Integer(int64(l.limit)))), nil
 }
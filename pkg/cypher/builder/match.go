@@ -9,32 +9,53 @@ import (
 
 // matchBuilder implements the MatchBuilder interface
 type matchBuilder struct {
-	pattern     core.Expression
+	patterns    []core.Expression
 	optional    bool
 	whereClause core.Expression
-	prev        core.Buildable
+	// hasWhere records that Where was called, so a nil condition fails the
+	// build instead of silently dropping the filter
+	hasWhere bool
+	prev     core.Buildable
 }
 
 // Where adds a WHERE clause to this MATCH
 func (m *matchBuilder) Where(condition core.Expression) MatchBuilder {
 	clone := *m
 	clone.whereClause = condition
+	clone.hasWhere = true
+	return &clone
+}
+
+// WhereIf adds a WHERE clause only when cond is true, so optional filters
+// don't need an if statement around the chain
+func (m *matchBuilder) WhereIf(cond bool, condition core.Expression) MatchBuilder {
+	if !cond {
+		return m
+	}
+	return m.Where(condition)
+}
+
+// AndMatch adds a pattern to this MATCH clause, rendering MATCH (a), (b)
+// instead of the separate clauses MATCH (a) MATCH (b)
+func (m *matchBuilder) AndMatch(pattern core.Expression) MatchBuilder {
+	clone := *m
+	clone.patterns = append(append([]core.Expression{}, m.patterns...), pattern)
 	return &clone
 }
 
 // OptionalMatch adds an OPTIONAL MATCH clause
-func (m *matchBuilder) OptionalMatch(pattern core.Expression) MatchBuilder {
+func (m *matchBuilder) OptionalMatch(patterns ...core.Expression) MatchBuilder {
 	return &matchBuilder{
-		pattern:  pattern,
+		patterns: patterns,
 		optional: true,
 		prev:     m,
 	}
 }
 
 // Match adds a MATCH clause
-func (m *matchBuilder) Match(pattern core.Expression) MatchBuilder {
+func (m *matchBuilder) Match(patterns ...core.Expression) MatchBuilder {
 	return &matchBuilder{
-		pattern:  pattern,
+		patterns: patterns,
 		optional: false,
 		prev:     m,
 	}
@@ -128,16 +149,18 @@ func (m *matchBuilder) Build() (core.Statement, error) {
 		}
 	}
 
-	// Collect parameters
-	paramsMap := make(map[string]any)
+	if m.hasWhere && m.whereClause == nil {
+		return nil, core.NewError(core.ErrNilCondition, "match where condition cannot be nil")
+	}
 
-	// Extract parameters from pattern and where clause
-	if m.pattern != nil {
-		util.ExtractParameters(m.pattern, paramsMap)
+	if err := util.ValidateExpressions(append(append([]core.Expression{}, m.patterns...), m.whereClause)...); err != nil {
+		return nil, err
 	}
 
-	if m.whereClause != nil {
-		util.ExtractParameters(m.whereClause, paramsMap)
+	// Collect parameters from the patterns and the where clause
+	params := util.NewParameters(prevStmt)
+	if err := params.Add(append(append([]core.Expression{}, m.patterns...), m.whereClause)...); err != nil {
+		return nil, err
 	}
 
 	// Build MATCH clause
@@ -149,11 +172,18 @@ func (m *matchBuilder) Build() (core.Statement, error) {
 		parts = append(parts, "MATCH")
 	}
 
-	if m.pattern == nil {
-		return nil, core.NewError(core.ErrInvalidPattern, "pattern is required for MATCH clause")
+	if len(m.patterns) == 0 {
+		return nil, core.NewError(core.ErrEmptyPattern, "pattern is required for MATCH clause")
 	}
 
-	parts = append(parts, m.pattern.String())
+	patterns := make([]string, len(m.patterns))
+	for i, pattern := range m.patterns {
+		if pattern == nil {
+			return nil, core.NewError(core.ErrEmptyPattern, "pattern is required for MATCH clause")
+		}
+		patterns[i] = pattern.String()
+	}
+	parts = append(parts, strings.Join(patterns, ", "))
 
 	// Add WHERE clause if present
 	if m.whereClause != nil {
@@ -163,25 +193,12 @@ func (m *matchBuilder) Build() (core.Statement, error) {
 	// Create the query string
 	query := strings.Join(parts, " ")
 
-	// Merge with previous statement if any
+	// Prepend the previous clauses if any
 	if prevStmt != nil {
-		// Merge previous parameters
-		prevParams := prevStmt.Params()
-		if prevParams != nil {
-			for k, v := range prevParams {
-				paramsMap[k] = v
-			}
-		}
-
-		return core.NewStatement(prevStmt.Cypher()+" "+query, paramsMap), nil
+		query = prevStmt.Cypher() + " " + query
 	}
 
-	// Create a new statement
-	return core.NewStatement(query, paramsMap), nil
-}
-
-// Helper function to extract parameters from expressions recursively (deprecated, use util.ExtractParameters instead)
-func extractParameters(expr core.Expression, paramsMap map[string]any) {
-	// This function is deprecated and should be removed in a future version
-	util.ExtractParameters(expr, paramsMap)
+	return withClauses(core.NewStatement(params.Render(query), params.Values()), prevStmt,
+		clause(parts[0], m.patterns...),
+		clauseIf(m.whereClause != nil, "WHERE", m.whereClause)), nil
 }
@@ -1,6 +1,8 @@
 package builder
 
 import (
+	"errors"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -88,6 +90,25 @@ func TestMultipleMatches(t *testing.T) {
 	}
 }
 
+func TestMultipleMatchesWithWhere(t *testing.T) {
+	person := ast.Node("Person").Named("p")
+	movie := ast.Node("Movie").Named("m")
+	stmt, err := Match(person).
+		Where(person.Property("age").Gt(30)).
+		Match(movie).
+		Where(movie.Property("year").Lt(2000)).
+		Returning(expr.NewVariableExpression("p"), expr.NewVariableExpression("m")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	want := "MATCH (p:Person) WHERE (p.age > 30) MATCH (m:Movie) WHERE (m.year < 2000) RETURN p, m"
+	if got := stmt.Cypher(); got != want {
+		t.Errorf("Cypher() = %q, want %q", got, want)
+	}
+}
+
 func TestMatchWithCreate(t *testing.T) {
 	node1 := ast.Node("Person").Named("p")
 	node2 := ast.Node("Movie").Named("m")
@@ -185,3 +206,191 @@ func TestMatchWithWith(t *testing.T) {
 	}
 }
 
+func TestMatchBuilderReuse(t *testing.T) {
+	node := ast.Node("Person").Named("p")
+	base := Match(node)
+
+	first, err := base.Where(node.Property("age").Gt(30)).Returning(node).Build()
+	if err != nil {
+		t.Fatalf("first Build() error = %v", err)
+	}
+	second, err := base.Where(node.Property("name").Eq("John")).Returning(node).Build()
+	if err != nil {
+		t.Fatalf("second Build() error = %v", err)
+	}
+
+	if strings.Contains(first.Cypher(), "name") {
+		t.Errorf("first Cypher() = %q, should not contain the second condition", first.Cypher())
+	}
+	if strings.Contains(second.Cypher(), "age") {
+		t.Errorf("second Cypher() = %q, should not contain the first condition", second.Cypher())
+	}
+
+	plain, err := base.Build()
+	if err != nil {
+		t.Fatalf("base Build() error = %v", err)
+	}
+	if strings.Contains(plain.Cypher(), "WHERE") {
+		t.Errorf("base Cypher() = %q, should not be modified by derived builders", plain.Cypher())
+	}
+}
+
+func TestSetBuilderReuse(t *testing.T) {
+	node := ast.Node("Person").Named("p")
+	base := Match(node).
+		Set(expr.Equals(expr.Property("p", "a"), expr.Integer(1))).
+		And(expr.Equals(expr.Property("p", "b"), expr.Integer(2)))
+
+	first, _ := base.And(expr.Equals(expr.Property("p", "c"), expr.Integer(3))).Build()
+	second, _ := base.And(expr.Equals(expr.Property("p", "d"), expr.Integer(4))).Build()
+
+	if strings.Contains(first.Cypher(), "p.d") {
+		t.Errorf("first Cypher() = %q, should not contain p.d", first.Cypher())
+	}
+	if strings.Contains(second.Cypher(), "p.c") {
+		t.Errorf("second Cypher() = %q, should not contain p.c", second.Cypher())
+	}
+}
+
+func TestMatchWithInlinePredicate(t *testing.T) {
+	person := ast.Node("Person").Named("p")
+	adult := person.Where(person.Property("age").Gt(core.NewParameter("minAge", 18)))
+	movie := ast.Node("Movie").Named("m")
+
+	stmt, err := Match(ast.Pattern(adult, adult.RelationshipTo(movie, "ACTED_IN"), movie)).
+		Returning(movie).
+		Build()
+	if err != nil {
+		t.Fatalf("Match().Build() error = %v", err)
+	}
+
+	cypher := stmt.Cypher()
+	if !strings.HasPrefix(cypher, "MATCH (p:Person WHERE (p.age > $minAge))-[") {
+		t.Errorf("Cypher() = %q, should render the predicate inside the node pattern", cypher)
+	}
+	if stmt.Params()["minAge"] != 18 {
+		t.Errorf("Params() = %v, should include the inline predicate parameter", stmt.Params())
+	}
+}
+
+func TestInlinePredicateOutsideMatch(t *testing.T) {
+	person := ast.Node("Person").Named("p")
+	adult := person.Where(person.Property("age").Gt(18))
+
+	if _, err := Create(adult).Build(); !errors.Is(err, core.ErrInvalidPattern) {
+		t.Errorf("Create().Build() error = %v, want core.ErrInvalidPattern", err)
+	}
+	if _, err := Merge(adult).Build(); !errors.Is(err, core.ErrInvalidPattern) {
+		t.Errorf("Merge().Build() error = %v, want core.ErrInvalidPattern", err)
+	}
+}
+
+func TestMatchMultiplePatterns(t *testing.T) {
+	person := ast.Node("Person").Named("p").WithProps(map[string]interface{}{
+		"name": core.NewParameter("name", "Tom Hanks"),
+	})
+	movie := ast.Node("Movie").Named("m").WithProps(map[string]interface{}{
+		"title": core.NewParameter("title", "Big"),
+	})
+
+	stmt, err := Match(person, movie).
+		Create(ast.Pattern(person, person.RelationshipTo(movie, "ACTED_IN"), movie)).
+		Build()
+	if err != nil {
+		t.Fatalf("Match().Build() error = %v", err)
+	}
+
+	cypher := stmt.Cypher()
+	if !strings.HasPrefix(cypher, "MATCH (p:Person {name: $name}), (m:Movie {title: $title}) CREATE") {
+		t.Errorf("Cypher() = %q, should match both patterns in one clause", cypher)
+	}
+	params := stmt.Params()
+	if params["name"] != "Tom Hanks" || params["title"] != "Big" {
+		t.Errorf("Params() = %v, should include parameters of every pattern", params)
+	}
+
+	if _, err := Match().Build(); !errors.Is(err, core.ErrInvalidPattern) {
+		t.Errorf("Match().Build() error = %v, want core.ErrInvalidPattern", err)
+	}
+}
+
+func TestAndMatch(t *testing.T) {
+	person := ast.Node("Person").Named("p")
+	movie := ast.Node("Movie").Named("m")
+
+	base := Match(person)
+	combined, err := base.AndMatch(movie).Returning(person, movie).Build()
+	if err != nil {
+		t.Fatalf("Match().AndMatch().Build() error = %v", err)
+	}
+	if cypher := combined.Cypher(); !strings.HasPrefix(cypher, "MATCH (p:Person), (m:Movie) RETURN") {
+		t.Errorf("Cypher() = %q, should combine the patterns in one MATCH", cypher)
+	}
+
+	separate, err := base.Match(movie).Build()
+	if err != nil {
+		t.Fatalf("Match().Match().Build() error = %v", err)
+	}
+	if cypher := separate.Cypher(); cypher != "MATCH (p:Person) MATCH (m:Movie)" {
+		t.Errorf("Cypher() = %q, want separate MATCH clauses", cypher)
+	}
+}
+
+func TestMatchPatternUnion(t *testing.T) {
+	a := ast.Node("Person").Named("a").WithProps(map[string]any{"id": core.NewParameter("id", 1)})
+	b := ast.Node("Person").Named("b")
+
+	stmt, err := Match(ast.PatternUnion(
+		ast.Pattern(a, a.RelationshipTo(b, "KNOWS"), b),
+		ast.Pattern(a, a.RelationshipTo(b, "WORKS_WITH"), b),
+	)).Returning(expr.NewVariableExpression("b")).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	want := "MATCH ((a:Person {id: $id})-[:`KNOWS`]->(b:Person) | (a:Person {id: $id})-[:`WORKS_WITH`]->(b:Person)) RETURN b"
+	if stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+	if stmt.Params()["id"] != 1 {
+		t.Errorf("Params() = %v, should include the id parameter", stmt.Params())
+	}
+
+	_, err = Match(ast.PatternUnion(ast.Pattern(a, a.RelationshipTo(b, "KNOWS"), b))).Returning(expr.NewVariableExpression("b")).Build()
+	if !errors.Is(err, core.ErrInvalidPattern) {
+		t.Errorf("Build() error = %v, want core.ErrInvalidPattern", err)
+	}
+
+	_, err = Match(ast.PatternUnion(
+		ast.Pattern(a, a.RelationshipTo(b, "KNOWS"), b),
+		a.RelationshipTo(b, "WORKS_WITH"),
+	)).Returning(expr.NewVariableExpression("b")).Build()
+	if !errors.Is(err, core.ErrInvalidPattern) {
+		t.Errorf("Build() with a bare relationship error = %v, want core.ErrInvalidPattern", err)
+	}
+}
+
+func TestMatchMultiHopWithRelationshipProperties(t *testing.T) {
+	person := ast.Node("Person").Named("p")
+	friend := ast.Node("Person").Named("f")
+	movie := ast.Node("Movie").Named("m")
+
+	path := person.RelationshipTo(friend, "KNOWS").Named("k").Props(map[string]interface{}{"since": core.NewParameter("since", 2020)}).
+		RelationshipTo(movie, "RATED").Named("r")
+
+	stmt, err := Match(path).
+		Where(expr.GreaterThan(expr.NewVariableExpression("r.stars"), core.NewParameter("stars", 3))).
+		Returning(expr.NewVariableExpression("m")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	want := "MATCH (p:Person)-[k:`KNOWS` {since: $since}]->(f:Person)-[r:`RATED`]->(m:Movie) WHERE (r.stars > $stars) RETURN m"
+	if stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+	if !reflect.DeepEqual(stmt.Params(), map[string]any{"since": 2020, "stars": 3}) {
+		t.Errorf("Params() = %v, want since and stars", stmt.Params())
+	}
+}
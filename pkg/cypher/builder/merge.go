@@ -2,6 +2,7 @@ package builder
 
 import (
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/internal/util"
 )
 
 // mergeBuilder implements the MergeBuilder interface
@@ -12,17 +13,17 @@ type mergeBuilder struct {
 	prev          core.Buildable
 }
 
-// OnCreate adds an ON CREATE SET clause
-func (m *mergeBuilder) OnCreate(expression core.Expression) MergeBuilder {
+// OnCreate adds items to the ON CREATE SET clause; repeated calls add to the same clause
+func (m *mergeBuilder) OnCreate(expressions ...core.Expression) MergeBuilder {
 	clone := *m
-	clone.onCreateExprs = append(clone.onCreateExprs, expression)
+	clone.onCreateExprs = append(append([]core.Expression{}, clone.onCreateExprs...), expressions...)
 	return &clone
 }
 
-// OnMatch adds an ON MATCH SET clause
-func (m *mergeBuilder) OnMatch(expression core.Expression) MergeBuilder {
+// OnMatch adds items to the ON MATCH SET clause; repeated calls add to the same clause
+func (m *mergeBuilder) OnMatch(expressions ...core.Expression) MergeBuilder {
 	clone := *m
-	clone.onMatchExprs = append(clone.onMatchExprs, expression)
+	clone.onMatchExprs = append(append([]core.Expression{}, clone.onMatchExprs...), expressions...)
 	return &clone
 }
 
@@ -42,27 +43,63 @@ func (m *mergeBuilder) Returning(expressions ...core.Expression) ReturnBuilder {
 	}
 }
 
+// AppendRaw appends a raw Cypher string with its parameters after this clause
+func (m *mergeBuilder) AppendRaw(cypher string, params map[string]any) core.Buildable {
+	return &rawBuilder{
+		cypher: cypher,
+		params: params,
+		prev:   m,
+	}
+}
+
 // Build builds this MERGE into a complete statement
 func (m *mergeBuilder) Build() (core.Statement, error) {
-	// Simple implementation for now
 	var cypher string
-
 	// If this builder has a previous clause, we need to build that first
+	var prevStmt core.Statement
 	if m.prev != nil {
-		prevStmt, err := m.prev.Build()
+		var err error
+		prevStmt, err = m.prev.Build()
 		if err != nil {
 			return nil, err
 		}
 		cypher = prevStmt.Cypher() + " "
 	}
 
+	if m.pattern == nil {
+		return nil, core.NewError(core.ErrEmptyPattern, "pattern is required for MERGE clause")
+	}
+
+	if util.HasInlinePredicate(m.pattern) {
+		return nil, core.NewError(core.ErrInvalidPattern, "inline WHERE predicates are only allowed in MATCH patterns")
+	}
+
+	onCreate, err := setItems(m.onCreateExprs)
+	if err != nil {
+		return nil, err
+	}
+	onMatch, err := setItems(m.onMatchExprs)
+	if err != nil {
+		return nil, err
+	}
+	items := append(append([]core.Expression{}, onCreate...), onMatch...)
+	if err := util.ValidateExpressions(append([]core.Expression{m.pattern}, items...)...); err != nil {
+		return nil, err
+	}
+
+	// Collect parameters from the pattern and the ON CREATE / ON MATCH items
+	params := util.NewParameters(prevStmt)
+	if err := params.Add(append([]core.Expression{m.pattern}, items...)...); err != nil {
+		return nil, err
+	}
+
 	// Add MERGE keyword and pattern
 	cypher += "MERGE " + m.pattern.String()
 
 	// Add ON CREATE SET clause if present
-	if len(m.onCreateExprs) > 0 {
+	if len(onCreate) > 0 {
 		cypher += " ON CREATE SET "
-		for i, expr := range m.onCreateExprs {
+		for i, expr := range onCreate {
 			if i > 0 {
 				cypher += ", "
 			}
@@ -71,9 +108,9 @@ func (m *mergeBuilder) Build() (core.Statement, error) {
 	}
 
 	// Add ON MATCH SET clause if present
-	if len(m.onMatchExprs) > 0 {
+	if len(onMatch) > 0 {
 		cypher += " ON MATCH SET "
-		for i, expr := range m.onMatchExprs {
+		for i, expr := range onMatch {
 			if i > 0 {
 				cypher += ", "
 			}
@@ -81,5 +118,8 @@ func (m *mergeBuilder) Build() (core.Statement, error) {
 		}
 	}
 
-	return core.NewStatement(cypher, nil), nil
+	return withClauses(core.NewStatement(params.Render(cypher), params.Values()), prevStmt,
+		clause("MERGE", m.pattern),
+		clauseIf(len(onCreate) > 0, "ON CREATE SET", onCreate...),
+		clauseIf(len(onMatch) > 0, "ON MATCH SET", onMatch...)), nil
 }
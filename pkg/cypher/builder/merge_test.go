@@ -1,6 +1,7 @@
 package builder
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
@@ -95,3 +96,79 @@ func TestMergeWithReturn(t *testing.T) {
 	}
 }
 
+func TestMergeOnCreateSetMutate(t *testing.T) {
+	person := ast.Node("Person").Named("p").WithProps(map[string]interface{}{
+		"id": core.NewParameter("id", 42),
+	})
+	props := core.NewParameter("props", map[string]any{"name": "John"})
+
+	stmt, err := Merge(person).
+		OnCreate(expr.SetMutate(person, props)).
+		OnMatch(expr.Equals(person.Property("seen"), expr.Boolean(true))).
+		Build()
+	if err != nil {
+		t.Fatalf("Merge().OnCreate().Build() error = %v", err)
+	}
+
+	cypher := stmt.Cypher()
+	if !strings.HasPrefix(cypher, "MERGE (p:Person {id: $id}) ON CREATE SET p += $props ON MATCH SET") {
+		t.Errorf("Cypher() = %q, should merge the props map on create", cypher)
+	}
+	params := stmt.Params()
+	if params["id"] != 42 || params["props"] == nil {
+		t.Errorf("Params() = %v, should include id and props", params)
+	}
+
+	_, err = Merge(person).OnCreate(expr.SetMutate(ast.Node("Person"), props)).Build()
+	if !errors.Is(err, core.ErrMissingAlias) {
+		t.Errorf("SetMutate() on an unnamed node Build() error = %v, want core.ErrMissingAlias", err)
+	}
+}
+
+func TestMergeOnCreateMultipleItems(t *testing.T) {
+	person := ast.Node("Person").Named("p").WithProps(map[string]interface{}{
+		"id": core.NewParameter("id", 42),
+	})
+
+	stmt, err := Merge(person).
+		OnCreate(
+			expr.SetMutate(person, core.NewParameter("props", map[string]any{"name": "John"})),
+			expr.SetLabels(person, "New", "Needs Review"),
+		).
+		OnMatch(expr.SetMutate(person, core.NewParameter("seen", map[string]any{"seen": true}))).
+		OnMatch(expr.SetLabels(person, "Seen")).
+		Build()
+	if err != nil {
+		t.Fatalf("Merge().OnCreate().OnMatch().Build() error = %v", err)
+	}
+
+	want := "MERGE (p:Person {id: $id}) ON CREATE SET p += $props, p:New:`Needs Review` ON MATCH SET p += $seen, p:Seen"
+	if stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+	params := stmt.Params()
+	if len(params) != 3 || params["props"] == nil || params["seen"] == nil {
+		t.Errorf("Params() = %v, should include id, props and seen", params)
+	}
+
+	_, err = Merge(person).OnCreate(expr.SetLabels(person)).Build()
+	if !errors.Is(err, core.ErrInvalidExpression) {
+		t.Errorf("SetLabels() without labels Build() error = %v, want core.ErrInvalidExpression", err)
+	}
+}
+
+func TestMergeSetsTimestamp(t *testing.T) {
+	person := ast.Node("Person").Named("p").WithProps(map[string]any{"id": core.NewParameter("id", 1)})
+	stmt, err := Merge(person).
+		OnCreate(expr.SetProperty(person.Property("created"), expr.Timestamp())).
+		OnMatch(expr.SetProperty(person.Property("updated"), expr.Timestamp())).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	want := "MERGE (p:Person {id: $id}) ON CREATE SET p.created = timestamp() ON MATCH SET p.updated = timestamp()"
+	if stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/expr"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/internal/util"
 )
 
 // orderByBuilder implements the OrderByBuilder interface
@@ -45,27 +47,32 @@ func (o *orderByBuilder) Limit(count int) OrderByBuilder {
 
 // Build builds this ORDER BY into a complete statement
 func (o *orderByBuilder) Build() (core.Statement, error) {
-	// Simple implementation for now
 	var cypher string
-
 	// If this builder has a previous clause, we need to build that first
+	var prevStmt core.Statement
 	if o.prev != nil {
-		prevStmt, err := o.prev.Build()
+		var err error
+		prevStmt, err = o.prev.Build()
 		if err != nil {
 			return nil, err
 		}
 		cypher = prevStmt.Cypher() + " "
 	}
 
+	params := util.NewParameters(prevStmt)
+	if err := params.Add(o.expressions...); err != nil {
+		return nil, err
+	}
+
 	// Add ORDER BY clause
 	cypher += "ORDER BY "
 
 	// Add expressions with direction
-	for i, expr := range o.expressions {
+	for i, item := range o.expressions {
 		if i > 0 {
 			cypher += ", "
 		}
-		cypher += expr.String()
+		cypher += expr.SortKey(item).String()
 		if o.direction != "" {
 			cypher += " " + o.direction
 		}
@@ -81,5 +88,8 @@ func (o *orderByBuilder) Build() (core.Statement, error) {
 		cypher += fmt.Sprintf(" LIMIT %d", o.limitValue)
 	}
 
-	return core.NewStatement(cypher, nil), nil
+	return withClauses(core.NewStatement(params.Render(cypher), params.Values()), prevStmt,
+		orderClause(o.expressions, o.direction, true),
+		countClause("SKIP", o.skipValue),
+		countClause("LIMIT", o.limitValue)), nil
 }
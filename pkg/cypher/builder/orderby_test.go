@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/ast"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/expr"
 )
 
 func TestOrderBy(t *testing.T) {
@@ -87,3 +89,40 @@ func TestOrderByMultipleExpressions(t *testing.T) {
 	}
 }
 
+func TestOrderByReturnAlias(t *testing.T) {
+	n := ast.Node("Person").Named("n")
+	count := expr.CountStar().As("c")
+
+	tests := []struct {
+		name    string
+		builder interface {
+			Build() (core.Statement, error)
+		}
+		want string
+	}{
+		{
+			name:    "alias variable",
+			builder: Match(n).Returning(n.Property("country"), count).OrderBy(expr.Desc(expr.NewVariableExpression("c"))),
+			want:    "MATCH (n:Person) RETURN n.country, count(*) AS c ORDER BY c DESC",
+		},
+		{
+			name:    "aliased item",
+			builder: Match(n).Returning(n.Property("country"), count).OrderBy(expr.Desc(count)),
+			want:    "MATCH (n:Person) RETURN n.country, count(*) AS c ORDER BY c DESC",
+		},
+		{
+			name:    "aliased item in WITH",
+			builder: Match(n).With(n.Property("country"), count).OrderBy(count).Limit(3).Returning(expr.NewVariableExpression("c")),
+			want:    "MATCH (n:Person) WITH n.country, count(*) AS c ORDER BY c LIMIT 3 RETURN c",
+		},
+	}
+	for _, tt := range tests {
+		stmt, err := tt.builder.Build()
+		if err != nil {
+			t.Fatalf("%s: Build() error = %v", tt.name, err)
+		}
+		if stmt.Cypher() != tt.want {
+			t.Errorf("%s: Cypher() = %q, want %q", tt.name, stmt.Cypher(), tt.want)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package builder
+
+import (
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/expr"
+)
+
+// rawBuilder appends a raw Cypher string to the end of a query.
+// It is an escape hatch for trailing clauses the DSL cannot express;
+// the text is inserted as-is, so values should be passed as parameters.
+type rawBuilder struct {
+	cypher string
+	params map[string]any
+	prev   core.Buildable
+}
+
+// Build builds the previous clauses and appends the raw string
+func (r *rawBuilder) Build() (core.Statement, error) {
+	paramsMap := make(map[string]any)
+	cypher := r.cypher
+
+	var prevStmt core.Statement
+	if r.prev != nil {
+		var err error
+		prevStmt, err = r.prev.Build()
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range prevStmt.Params() {
+			paramsMap[k] = v
+		}
+		if cypher != "" {
+			cypher = prevStmt.Cypher() + " " + cypher
+		} else {
+			cypher = prevStmt.Cypher()
+		}
+	}
+
+	for k, v := range r.params {
+		paramsMap[k] = v
+	}
+
+	return withClauses(core.NewStatement(cypher, paramsMap), prevStmt,
+		clauseIf(r.cypher != "", "", expr.RawCypherWithParams(r.cypher, r.params))), nil
+}
@@ -2,6 +2,7 @@ package builder
 
 import (
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/internal/util"
 )
 
 // removeBuilder implements the RemoveBuilder interface
@@ -13,7 +14,7 @@ type removeBuilder struct {
 // And adds another REMOVE operation
 func (r *removeBuilder) And(expression core.Expression) RemoveBuilder {
 	clone := *r
-	clone.expressions = append(clone.expressions, expression)
+	clone.expressions = append(append([]core.Expression{}, clone.expressions...), expression)
 	return &clone
 }
 
@@ -33,20 +34,34 @@ func (r *removeBuilder) Returning(expressions ...core.Expression) ReturnBuilder
 	}
 }
 
+// AppendRaw appends a raw Cypher string with its parameters after this clause
+func (r *removeBuilder) AppendRaw(cypher string, params map[string]any) core.Buildable {
+	return &rawBuilder{
+		cypher: cypher,
+		params: params,
+		prev:   r,
+	}
+}
+
 // Build builds this REMOVE into a complete statement
 func (r *removeBuilder) Build() (core.Statement, error) {
-	// Simple implementation for now
 	var cypher string
-
 	// If this builder has a previous clause, we need to build that first
+	var prevStmt core.Statement
 	if r.prev != nil {
-		prevStmt, err := r.prev.Build()
+		var err error
+		prevStmt, err = r.prev.Build()
 		if err != nil {
 			return nil, err
 		}
 		cypher = prevStmt.Cypher() + " "
 	}
 
+	params := util.NewParameters(prevStmt)
+	if err := params.Add(r.expressions...); err != nil {
+		return nil, err
+	}
+
 	// Add REMOVE keyword
 	cypher += "REMOVE "
 
@@ -58,5 +73,6 @@ func (r *removeBuilder) Build() (core.Statement, error) {
 		cypher += expr.String()
 	}
 
-	return core.NewStatement(cypher, nil), nil
+	return withClauses(core.NewStatement(params.Render(cypher), params.Values()), prevStmt,
+		clause("REMOVE", r.expressions...)), nil
 }
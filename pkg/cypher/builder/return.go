@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/expr"
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/internal/util"
 )
 
@@ -21,6 +22,13 @@ type returnBuilder struct {
 	prev           core.Buildable
 }
 
+// Distinct makes this a RETURN DISTINCT clause
+func (r *returnBuilder) Distinct() ReturnBuilder {
+	clone := *r
+	clone.distinct = true
+	return &clone
+}
+
 // OrderBy adds an ORDER BY clause
 func (r *returnBuilder) OrderBy(expressions ...core.Expression) ReturnOrderable {
 	clone := *r
@@ -28,6 +36,14 @@ func (r *returnBuilder) OrderBy(expressions ...core.Expression) ReturnOrderable
 	return &clone
 }
 
+// OrderByIf adds an ORDER BY clause only when cond is true
+func (r *returnBuilder) OrderByIf(cond bool, expressions ...core.Expression) ReturnOrderable {
+	if !cond {
+		return r
+	}
+	return r.OrderBy(expressions...)
+}
+
 // Skip adds a SKIP clause
 func (r *returnBuilder) Skip(count int) ReturnBuilder {
 	clone := *r
@@ -35,6 +51,14 @@ func (r *returnBuilder) Skip(count int) ReturnBuilder {
 	return &clone
 }
 
+// SkipIf adds a SKIP clause only when cond is true
+func (r *returnBuilder) SkipIf(cond bool, count int) ReturnBuilder {
+	if !cond {
+		return r
+	}
+	return r.Skip(count)
+}
+
 // Limit adds a LIMIT clause
 func (r *returnBuilder) Limit(count int) ReturnBuilder {
 	clone := *r
@@ -42,6 +66,14 @@ func (r *returnBuilder) Limit(count int) ReturnBuilder {
 	return &clone
 }
 
+// LimitIf adds a LIMIT clause only when cond is true
+func (r *returnBuilder) LimitIf(cond bool, count int) ReturnBuilder {
+	if !cond {
+		return r
+	}
+	return r.Limit(count)
+}
+
 // Asc specifies ascending order
 func (r *returnBuilder) Asc() ReturnBuilder {
 	clone := *r
@@ -56,6 +88,15 @@ func (r *returnBuilder) Desc() ReturnBuilder {
 	return &clone
 }
 
+// AppendRaw appends a raw Cypher string with its parameters after this clause
+func (r *returnBuilder) AppendRaw(cypher string, params map[string]any) core.Buildable {
+	return &rawBuilder{
+		cypher: cypher,
+		params: params,
+		prev:   r,
+	}
+}
+
 // Build builds this RETURN into a complete statement
 func (r *returnBuilder) Build() (core.Statement, error) {
 	// If this builder has a previous clause, we need to build that first
@@ -69,33 +110,40 @@ func (r *returnBuilder) Build() (core.Statement, error) {
 		}
 	}
 
-	// Collect all parameters from expressions
-	paramsMap := make(map[string]any)
-
-	// Extract parameters from expressions
-	for _, expr := range r.expressions {
-		util.ExtractParameters(expr, paramsMap)
+	if !r.returnAll && len(r.expressions) == 0 {
+		return nil, core.NewError(core.ErrEmptyReturn, "RETURN requires at least one expression")
+	}
+	for i, expression := range r.expressions {
+		if expression == nil {
+			return nil, core.NewError(core.ErrInvalidExpression, fmt.Sprintf("RETURN item %d is nil", i))
+		}
 	}
 
-	// Extract parameters from ORDER BY expressions if present
-	for _, expr := range r.orderBy {
-		util.ExtractParameters(expr, paramsMap)
+	if err := util.ValidateExpressions(append(append([]core.Expression{}, r.expressions...), r.orderBy...)...); err != nil {
+		return nil, err
 	}
 
-	// Build RETURN clause
-	var parts []string
+	// Collect parameters from the items as they are rendered and the ORDER BY
+	// expressions
+	items := projectionItems(r.expressions)
+	params := util.NewParameters(prevStmt)
+	if err := params.Add(append(append([]core.Expression{}, items...), r.orderBy...)...); err != nil {
+		return nil, err
+	}
 
+	// Build RETURN clause; the order is RETURN [DISTINCT] items ORDER BY SKIP LIMIT
+	returnKeyword := "RETURN"
 	if r.distinct {
-		parts = append(parts, "RETURN DISTINCT")
-	} else if r.returnAll {
-		parts = append(parts, "RETURN *")
-	} else {
-		parts = append(parts, "RETURN")
+		returnKeyword = "RETURN DISTINCT"
 	}
+	parts := []string{returnKeyword}
 
-	if !r.returnAll {
-		exprs := make([]string, len(r.expressions))
-		for i, expr := range r.expressions {
+	if r.returnAll {
+		items = []core.Expression{expr.RawCypher("*")}
+		parts = append(parts, "*")
+	} else {
+		exprs := make([]string, len(items))
+		for i, expr := range items {
 			exprs[i] = expr.String()
 		}
 		parts = append(parts, strings.Join(exprs, ", "))
@@ -104,8 +152,8 @@ func (r *returnBuilder) Build() (core.Statement, error) {
 	// Add ORDER BY if present
 	if len(r.orderBy) > 0 {
 		orderExprs := make([]string, len(r.orderBy))
-		for i, expr := range r.orderBy {
-			orderExprs[i] = expr.String()
+		for i, item := range r.orderBy {
+			orderExprs[i] = expr.SortKey(item).String()
 		}
 
 		parts = append(parts, "ORDER BY "+strings.Join(orderExprs, ", "))
@@ -128,19 +176,14 @@ func (r *returnBuilder) Build() (core.Statement, error) {
 	// Create the query string
 	query := strings.Join(parts, " ")
 
-	// Merge with previous statement if any
+	// Prepend the previous clauses if any
 	if prevStmt != nil {
-		// Merge previous parameters
-		prevParams := prevStmt.Params()
-		if prevParams != nil {
-			for k, v := range prevParams {
-				paramsMap[k] = v
-			}
-		}
-
-		return core.NewStatement(prevStmt.Cypher()+" "+query, paramsMap), nil
+		query = prevStmt.Cypher() + " " + query
 	}
 
-	// Create a new statement
-	return core.NewStatement(query, paramsMap), nil
+	return withClauses(core.NewStatement(params.Render(query), params.Values()), prevStmt,
+		clause(returnKeyword, items...),
+		orderClause(r.orderBy, r.orderDir, false),
+		countClause("SKIP", r.skipValue),
+		countClause("LIMIT", r.limitValue)), nil
 }
@@ -1,11 +1,15 @@
 package builder
 
 import (
+	"errors"
+	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/ast"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/expr"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/validation"
 )
 
 func TestReturn(t *testing.T) {
@@ -78,3 +82,316 @@ func TestReturnMultipleExpressions(t *testing.T) {
 	}
 }
 
+func TestReturnDistinctWithOrderBySkipLimit(t *testing.T) {
+	node := ast.Node("Person").Named("p")
+	name := node.Property("name")
+
+	tests := []struct {
+		name    string
+		builder ReturnBuilder
+	}{
+		{"distinct first", Match(node).Returning(name).Distinct().OrderBy(name).Desc().Skip(10).Limit(5)},
+		{"distinct last", Match(node).Returning(name).OrderBy(name).Desc().Skip(10).Limit(5).Distinct()},
+	}
+
+	expected := "MATCH (p:Person) RETURN DISTINCT p.name ORDER BY p.name DESC SKIP 10 LIMIT 5"
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stmt, err := tt.builder.Build()
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			if cypher := stmt.Cypher(); cypher != expected {
+				t.Errorf("Cypher() = %q, want %q", cypher, expected)
+			}
+		})
+	}
+}
+
+func TestConditionalClauses(t *testing.T) {
+	node := ast.Node("Person").Named("p")
+	name := node.Property("name")
+	adult := expr.GreaterThan(node.Property("age"), expr.Integer(17))
+	pName := expr.NewVariableExpression("p")
+
+	tests := []struct {
+		name     string
+		builder  core.Buildable
+		expected string
+	}{
+		{"all applied",
+			Match(node).WhereIf(true, adult).Returning(name).OrderByIf(true, name).Desc().SkipIf(true, 10).LimitIf(true, 5),
+			"MATCH (p:Person) WHERE (p.age > 17) RETURN p.name ORDER BY p.name DESC SKIP 10 LIMIT 5"},
+		{"none applied",
+			Match(node).WhereIf(false, adult).Returning(name).OrderByIf(false, name).Desc().SkipIf(false, 10).LimitIf(false, 5),
+			"MATCH (p:Person) RETURN p.name"},
+		{"with applied",
+			Match(node).With(pName).WhereIf(true, adult).OrderByIf(true, name).SkipIf(true, 1).LimitIf(true, 2).Returning(pName),
+			"MATCH (p:Person) WITH p ORDER BY p.name SKIP 1 LIMIT 2 WHERE (p.age > 17) RETURN p"},
+		{"with skipped",
+			Match(node).With(pName).WhereIf(false, adult).OrderByIf(false, name).SkipIf(false, 1).LimitIf(false, 2).Returning(pName),
+			"MATCH (p:Person) WITH p RETURN p"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stmt, err := tt.builder.Build()
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			if cypher := stmt.Cypher(); cypher != tt.expected {
+				t.Errorf("Cypher() = %q, want %q", cypher, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPropertyAsInReturn(t *testing.T) {
+	person := ast.Node("Person").Named("p")
+	stmt, err := Match(person).Returning(person.Property("name").As("n")).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if !strings.Contains(stmt.Cypher(), "RETURN p.name AS n") {
+		t.Errorf("Cypher() = %q, should contain 'RETURN p.name AS n'", stmt.Cypher())
+	}
+}
+
+func TestAggregationAsInReturn(t *testing.T) {
+	person := ast.Node("Person").Named("p")
+	stmt, err := Match(person).
+		Returning(expr.Count(person).As("total"), expr.Collect(person.Property("name")).As("names")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if !strings.Contains(stmt.Cypher(), "RETURN count(p) AS total, collect(p.name) AS names") {
+		t.Errorf("Cypher() = %q, should contain 'RETURN count(p) AS total, collect(p.name) AS names'", stmt.Cypher())
+	}
+
+	distinct := expr.Count(expr.Distinct(person)).As("people").String()
+	if distinct != "count(DISTINCT p) AS people" {
+		t.Errorf("expr.Count(expr.Distinct(p)).As() = %q, want 'count(DISTINCT p) AS people'", distinct)
+	}
+}
+
+func TestComparisonAndFunctionAsInReturn(t *testing.T) {
+	person := ast.Node("Person").Named("p")
+	stmt, err := Match(person).
+		Returning(
+			expr.GreaterThan(person.Property("age"), expr.LiteralFromValue(30)).As("older"),
+			expr.ToUpper(person.Property("name")).As("name"),
+			expr.NullSafeEquals(person.Property("nick"), person.Property("name")).As("sameName"),
+		).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	want := "MATCH (p:Person) RETURN (p.age > 30) AS older, toUpper(p.name) AS name, ((p.nick = p.name) OR ((p.nick IS NULL) AND (p.name IS NULL))) AS sameName"
+	if stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+}
+
+func TestReturnMapProjection(t *testing.T) {
+	person := ast.Node("Person").Named("p")
+	result := expr.As(expr.Map(map[string]core.Expression{
+		"id":         person.Property("id"),
+		"name":       person.Property("name"),
+		"score":      core.NewParameter("", 5),
+		"tags":       expr.List(core.NewParameter("", "a"), core.NewParameter("tag", "b")),
+		"first name": person.Property("firstName"),
+	}), "result")
+
+	stmt, err := Match(person).Returning(result).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	want := "MATCH (p:Person) RETURN {`first name`: p.firstName, id: p.id, name: p.name, score: $p0, tags: [$p1, $tag]} AS result"
+	if stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+	wantParams := map[string]any{"p0": 5, "p1": "a", "tag": "b"}
+	if !reflect.DeepEqual(stmt.Params(), wantParams) {
+		t.Errorf("Params() = %v, want %v", stmt.Params(), wantParams)
+	}
+
+	_, err = Match(person).Returning(expr.As(expr.Map(map[string]core.Expression{"x": ast.Node("Person").Property("x")}), "r")).Build()
+	if !errors.Is(err, core.ErrMissingAlias) {
+		t.Errorf("Build() error = %v, want core.ErrMissingAlias for a property of an unnamed node", err)
+	}
+}
+
+func TestStatisticalAggregations(t *testing.T) {
+	person := ast.Node("Person").Named("p")
+	age := person.Property("age")
+
+	stmt, err := Match(person).
+		Returning(
+			expr.PercentileCont(age, expr.Float(0.95)).As("p95"),
+			expr.PercentileDisc(age, core.NewParameter("percentile", 0.5)).As("median"),
+			expr.StDev(age).As("sd"),
+			expr.StDevP(age).As("sdp"),
+		).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	want := "MATCH (p:Person) RETURN percentileCont(p.age, 0.95) AS p95, percentileDisc(p.age, $percentile) AS median, stDev(p.age) AS sd, stDevP(p.age) AS sdp"
+	if stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+	if stmt.Params()["percentile"] != 0.5 {
+		t.Errorf("Params() = %v, should include the percentile parameter", stmt.Params())
+	}
+
+	for name, percentile := range map[string]core.Expression{
+		"out of range": expr.Float(95),
+		"string":       expr.LiteralFromValue("0.95"),
+		"property":     person.Property("p"),
+	} {
+		_, err := Match(person).Returning(expr.PercentileCont(age, percentile)).Build()
+		if !errors.Is(err, core.ErrInvalidExpression) {
+			t.Errorf("%s: Build() error = %v, want core.ErrInvalidExpression", name, err)
+		}
+	}
+}
+
+func TestDistinctInAggregations(t *testing.T) {
+	person := ast.Node("Person").Named("p")
+	age := person.Property("age")
+
+	stmt, err := Match(person).
+		Returning(
+			expr.Sum(expr.Distinct(age)).As("total"),
+			expr.Avg(expr.Distinct(age)).As("mean"),
+			expr.Min(expr.Distinct(age)).As("youngest"),
+			expr.Max(expr.Distinct(age)).As("oldest"),
+			expr.StDev(expr.Distinct(age)).As("sd"),
+			expr.PercentileCont(expr.Distinct(age), expr.Float(0.5)).As("median"),
+			expr.Collect(expr.Distinct(core.NewParameter("tag", "vip"))).As("tags"),
+		).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	want := "MATCH (p:Person) RETURN sum(DISTINCT p.age) AS total, avg(DISTINCT p.age) AS mean, " +
+		"min(DISTINCT p.age) AS youngest, max(DISTINCT p.age) AS oldest, stDev(DISTINCT p.age) AS sd, " +
+		"percentileCont(DISTINCT p.age, 0.5) AS median, collect(DISTINCT $tag) AS tags"
+	if stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+	if stmt.Params()["tag"] != "vip" {
+		t.Errorf("Params() = %v, should include the parameter inside DISTINCT", stmt.Params())
+	}
+}
+
+func TestReturnRelationshipAndItsType(t *testing.T) {
+	people := ast.Node("Person").Named("p")
+	relatedTo := ast.Node("Person").Named("o")
+	rel := people.RelationshipTo(relatedTo, "KNOWS").Named("r")
+
+	stmt, err := Match(ast.Pattern(people, rel, relatedTo)).
+		Returning(people.Property("name"), expr.As(expr.Function("type", rel), "relType"), rel, relatedTo).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	want := "MATCH (p:Person)-[r:`KNOWS`]->(o:Person) RETURN p.name, type(r) AS relType, r, o"
+	if stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+
+	stmt, err = Match(ast.Pattern(people, rel, relatedTo)).
+		With(rel, expr.Function("type", rel)).
+		Returning(expr.NewVariableExpression("r")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	want = "MATCH (p:Person)-[r:`KNOWS`]->(o:Person) WITH r, type(r) RETURN r"
+	if stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+}
+
+func TestReturnNamedPathAndLength(t *testing.T) {
+	a := ast.Node("Person").Named("a")
+	b := ast.Node("Person").Named("b")
+	c := ast.Node("City").Named("c")
+	path := ast.Path(a, a.RelationshipTo(b, "KNOWS"), b, b.RelationshipTo(c, "LIVES_IN"), c).Named("p")
+
+	stmt, err := Match(path).
+		Where(a.Property("name").Eq(core.NewParameter("name", "Ann"))).
+		Returning(path, expr.Length(path).As("hops")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	want := "MATCH p = (a:Person)-[:`KNOWS`]->(b:Person)-[:`LIVES_IN`]->(c:City) WHERE (a.name = $name) RETURN p, length(p) AS hops"
+	if stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+	if path.SymbolicName() != "p" || ast.Path(a).SymbolicName() != "" {
+		t.Errorf("Named() should only name the returned copy")
+	}
+}
+
+func TestReturnInAsBoolean(t *testing.T) {
+	order := ast.Node("Order").Named("n")
+	status := order.Property("status")
+
+	stmt, err := Match(order).
+		Returning(
+			expr.As(status.In("a", "b"), "isSpecial"),
+			expr.As(expr.InParam(status, []string{"x", "y"}), "isListed"),
+			expr.As(status.In(core.NewParameter("first", "c")), "isFirst"),
+		).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	want := "MATCH (n:Order) RETURN (n.status IN ['a', 'b']) AS isSpecial, (n.status IN $p0) AS isListed, (n.status IN [$first]) AS isFirst"
+	if stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+	if !reflect.DeepEqual(stmt.Params(), map[string]any{"p0": []string{"x", "y"}, "first": "c"}) {
+		t.Errorf("Params() = %v, want p0 and first", stmt.Params())
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	person := ast.Node("Person").Named("p")
+	country := person.Property("country")
+
+	stmt, err := Match(person).
+		Returning(expr.GroupBy(country).Aggregate(expr.CountStar().As("people"), expr.Avg(person.Property("age")).As("age"))).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	want := "MATCH (p:Person) RETURN p.country, count(*) AS people, avg(p.age) AS age"
+	if stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+	if err := validation.NewValidator(validation.ValidationLevelStrict).ValidateStatement(stmt); err != nil {
+		t.Errorf("ValidateStatement() error = %v", err)
+	}
+
+	invalid := map[string]core.Expression{
+		"aggregate key":    expr.GroupBy(expr.CountStar()).Aggregate(expr.Sum(person.Property("age"))),
+		"plain aggregate":  expr.GroupBy(country).Aggregate(person.Property("name")),
+		"no aggregates":    expr.GroupBy(country).Aggregate(),
+		"nested aggregate": expr.GroupBy(expr.Concat(expr.Count(expr.NewVariableExpression("p")), expr.LiteralFromValue("x"))).Aggregate(expr.CountStar()),
+	}
+	for name, projection := range invalid {
+		if _, err := Match(person).Returning(projection).Build(); !errors.Is(err, core.ErrInvalidExpression) {
+			t.Errorf("%s: Build() error = %v, want core.ErrInvalidExpression", name, err)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/expr"
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/internal/util"
 )
 
@@ -16,7 +17,7 @@ type setBuilder struct {
 // And adds another SET operation
 func (s *setBuilder) And(expression core.Expression) SetBuilder {
 	clone := *s
-	clone.expressions = append(clone.expressions, expression)
+	clone.expressions = append(append([]core.Expression{}, clone.expressions...), expression)
 	return &clone
 }
 
@@ -36,6 +37,15 @@ func (s *setBuilder) Returning(expressions ...core.Expression) ReturnBuilder {
 	}
 }
 
+// AppendRaw appends a raw Cypher string with its parameters after this clause
+func (s *setBuilder) AppendRaw(cypher string, params map[string]any) core.Buildable {
+	return &rawBuilder{
+		cypher: cypher,
+		params: params,
+		prev:   s,
+	}
+}
+
 // Build builds this SET into a complete statement
 func (s *setBuilder) Build() (core.Statement, error) {
 	// If this builder has a previous clause, we need to build that first
@@ -49,18 +59,27 @@ func (s *setBuilder) Build() (core.Statement, error) {
 		}
 	}
 
-	// Collect parameters
-	paramsMap := make(map[string]any)
+	items, err := setItems(s.expressions)
+	if err != nil {
+		return nil, err
+	}
+	if err := util.ValidateExpressions(items...); err != nil {
+		return nil, err
+	}
+
+	// Collect parameters, starting with those of the previous clauses so that
+	// unnamed parameters are numbered across the whole statement
+	params := util.NewParameters(prevStmt)
+	if err := params.Add(items...); err != nil {
+		return nil, err
+	}
 
-	// Build SET clause
+	// Build SET clause, keeping the items in the order they were added
 	parts := []string{"SET"}
 
-	exprStrings := make([]string, len(s.expressions))
-	for i, expr := range s.expressions {
+	exprStrings := make([]string, len(items))
+	for i, expr := range items {
 		exprStrings[i] = expr.String()
-
-		// Collect parameters from the expression if it's a parameter expression
-		util.ExtractParameters(expr, paramsMap)
 	}
 
 	parts = append(parts, strings.Join(exprStrings, ", "))
@@ -68,19 +87,24 @@ func (s *setBuilder) Build() (core.Statement, error) {
 	// Create the query string
 	query := strings.Join(parts, " ")
 
-	// Merge with previous statement if any
+	// Prepend the previous clauses if any
 	if prevStmt != nil {
-		// Merge previous parameters
-		prevParams := prevStmt.Params()
-		if prevParams != nil {
-			for k, v := range prevParams {
-				paramsMap[k] = v
-			}
-		}
-
-		return core.NewStatement(prevStmt.Cypher()+" "+query, paramsMap), nil
+		query = prevStmt.Cypher() + " " + query
 	}
 
-	// Create a new statement
-	return core.NewStatement(query, paramsMap), nil
+	return withClauses(core.NewStatement(params.Render(query), params.Values()), prevStmt,
+		clause("SET", items...)), nil
+}
+
+// setItems converts the expressions of a SET clause into SET items, see expr.SetItem
+func setItems(expressions []core.Expression) ([]core.Expression, error) {
+	items := make([]core.Expression, len(expressions))
+	for i, expression := range expressions {
+		item, err := expr.SetItem(expression)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = item
+	}
+	return items, nil
 }
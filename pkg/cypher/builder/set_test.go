@@ -1,6 +1,7 @@
 package builder
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
@@ -55,3 +56,37 @@ func TestSetWithReturn(t *testing.T) {
 	}
 }
 
+func TestSetMixesLabelsAndPropertiesInOrder(t *testing.T) {
+	node := ast.Node("User").Named("n")
+	stmt, err := Match(node).
+		Set(expr.SetLabels(node, "Admin")).
+		And(expr.SetProperty(node.Property("role"), "x")).
+		And(expr.SetLabels(node, "Needs Review")).
+		And(expr.SetMutate(node, core.NewParameter("props", map[string]any{"a": 1}))).
+		And(node.Property("active").Eq(true)).
+		Build()
+	if err != nil {
+		t.Fatalf("Set().And().Build() error = %v", err)
+	}
+
+	expected := "MATCH (n:User) SET n:Admin, n.role = 'x', n:`Needs Review`, n += $props, n.active = true"
+	if cypher := stmt.Cypher(); cypher != expected {
+		t.Errorf("Cypher() = %q, want %q", cypher, expected)
+	}
+}
+
+func TestSetRejectsExpressionsThatAreNotItems(t *testing.T) {
+	node := ast.Node("User").Named("n")
+	for name, item := range map[string]core.Expression{
+		"comparison": node.Property("age").Gt(3),
+		"property":   node.Property("age"),
+		"nil":        nil,
+	} {
+		if _, err := Match(node).Set(item).Build(); !errors.Is(err, core.ErrInvalidExpression) {
+			t.Errorf("%s: Build() error = %v, want core.ErrInvalidExpression", name, err)
+		}
+		if _, err := Merge(node).OnCreate(item).Build(); !errors.Is(err, core.ErrInvalidExpression) {
+			t.Errorf("%s: Merge().OnCreate().Build() error = %v, want core.ErrInvalidExpression", name, err)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/expr"
 )
 
 // skipBuilder implements the SkipBuilder interface
@@ -22,20 +23,26 @@ func (s *skipBuilder) Limit(count int) LimitBuilder {
 
 // Build builds this SKIP into a complete statement
 func (s *skipBuilder) Build() (core.Statement, error) {
-	// Simple implementation for now
 	var cypher string
+	paramsMap := make(map[string]any)
 
 	// If this builder has a previous clause, we need to build that first
+	var prevStmt core.Statement
 	if s.prev != nil {
-		prevStmt, err := s.prev.Build()
+		var err error
+		prevStmt, err = s.prev.Build()
 		if err != nil {
 			return nil, err
 		}
 		cypher = prevStmt.Cypher() + " "
+		for k, v := range prevStmt.Params() {
+			paramsMap[k] = v
+		}
 	}
 
 	// Add SKIP clause
 	cypher += fmt.Sprintf("SKIP %d", s.skip)
 
-	return core.NewStatement(cypher, nil), nil
+	return withClauses(core.NewStatement(cypher, paramsMap), prevStmt,
+		clause("SKIP", expr.Integer(int64(s.skip)))), nil
 }
@@ -0,0 +1,72 @@
+package builder
+
+import (
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/expr"
+)
+
+// withClauses records on a statement the clauses of the previous statement,
+// if any, followed by the clauses the builder rendered; nil clauses are skipped
+func withClauses(statement *core.StatementImpl, prev core.Statement, clauses ...*core.Clause) core.Statement {
+	var all []*core.Clause
+	if prev != nil {
+		all = append(all, prev.Clauses()...)
+	}
+	for _, c := range clauses {
+		if c != nil {
+			all = append(all, c)
+		}
+	}
+	return statement.WithClauses(all)
+}
+
+// clause creates a clause with the given keyword and expressions
+func clause(keyword string, expressions ...core.Expression) *core.Clause {
+	return &core.Clause{Keyword: keyword, Expressions: expressions}
+}
+
+// clauseIf creates a clause only when cond is true, for the optional parts of a builder
+func clauseIf(cond bool, keyword string, expressions ...core.Expression) *core.Clause {
+	if !cond {
+		return nil
+	}
+	return clause(keyword, expressions...)
+}
+
+// orderClause creates the ORDER BY clause of items as they are rendered:
+// aliased items by their alias and, when a direction is given, wrapped in it.
+// The direction applies to every item or, as in RETURN ... ORDER BY a, b DESC,
+// only to the last one.
+func orderClause(items []core.Expression, direction string, everyItem bool) *core.Clause {
+	if len(items) == 0 {
+		return nil
+	}
+	keys := make([]core.Expression, len(items))
+	for i, item := range items {
+		keys[i] = expr.SortKey(item)
+		if direction == "" || (!everyItem && i < len(items)-1) {
+			continue
+		}
+		if direction == "DESC" {
+			keys[i] = expr.Desc(keys[i])
+		} else {
+			keys[i] = expr.Asc(keys[i])
+		}
+	}
+	return clause("ORDER BY", keys...)
+}
+
+// countClause creates a SKIP or LIMIT clause when count is set
+func countClause(keyword string, count int) *core.Clause {
+	return clauseIf(count > 0, keyword, expr.Integer(int64(count)))
+}
+
+// projectionItems returns the RETURN or WITH items as they are rendered, see
+// expr.ProjectionItem
+func projectionItems(expressions []core.Expression) []core.Expression {
+	items := make([]core.Expression, len(expressions))
+	for i, expression := range expressions {
+		items[i] = expr.ProjectionItem(expression)
+	}
+	return items
+}
@@ -0,0 +1,77 @@
+package builder
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+)
+
+// StatementsBuilder collects built statements that are run one after the
+// other, either in a single transaction or as a cypher-shell script. The
+// statements of the schema package can be mixed with data statements in a
+// script, but Neo4j does not allow schema and data changes in one transaction.
+type StatementsBuilder struct {
+	statements []core.Statement
+}
+
+// Statements creates a StatementsBuilder holding the given statements
+func Statements(statements ...core.Statement) *StatementsBuilder {
+	return (&StatementsBuilder{}).Add(statements...)
+}
+
+// Add appends statements, skipping nil ones
+func (s *StatementsBuilder) Add(statements ...core.Statement) *StatementsBuilder {
+	for _, statement := range statements {
+		if statement != nil {
+			s.statements = append(s.statements, statement)
+		}
+	}
+	return s
+}
+
+// Statements returns the collected statements in the order they were added
+func (s *StatementsBuilder) Statements() []core.Statement {
+	return append([]core.Statement{}, s.statements...)
+}
+
+// Cypher returns the Cypher query of each statement
+func (s *StatementsBuilder) Cypher() []string {
+	queries := make([]string, len(s.statements))
+	for i, statement := range s.statements {
+		queries[i] = statement.Cypher()
+	}
+	return queries
+}
+
+// Script returns the statements as a single script for cypher-shell, each
+// one terminated by a semicolon and on its own line
+func (s *StatementsBuilder) Script() string {
+	if len(s.statements) == 0 {
+		return ""
+	}
+	return strings.Join(s.Cypher(), ";\n") + ";\n"
+}
+
+// Params merges the parameters of all statements, as a script shares them.
+// When statements bind the same name to different values the later value is
+// kept and the name is reported in collisions, sorted.
+func (s *StatementsBuilder) Params() (params map[string]any, collisions []string) {
+	params = make(map[string]any)
+	collided := make(map[string]bool)
+	for _, statement := range s.statements {
+		for name, value := range statement.Params() {
+			if previous, ok := params[name]; ok && !reflect.DeepEqual(previous, value) {
+				collided[name] = true
+			}
+			params[name] = value
+		}
+	}
+
+	for name := range collided {
+		collisions = append(collisions, name)
+	}
+	sort.Strings(collisions)
+	return params, collisions
+}
@@ -0,0 +1,36 @@
+package builder
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+)
+
+func TestStatementsScript(t *testing.T) {
+	statements := Statements(
+		core.NewStatement("CREATE (a:Person {name: $name})", map[string]any{"name": "Ann", "age": 30}),
+		nil,
+	).Add(core.NewStatement("MATCH (a:Person {name: $name}) SET a.age = $age", map[string]any{"name": "Bob", "age": 30}))
+
+	if got := len(statements.Statements()); got != 2 {
+		t.Fatalf("Statements() has %d statements, want 2", got)
+	}
+
+	want := "CREATE (a:Person {name: $name});\nMATCH (a:Person {name: $name}) SET a.age = $age;\n"
+	if got := statements.Script(); got != want {
+		t.Errorf("Script() = %q, want %q", got, want)
+	}
+
+	params, collisions := statements.Params()
+	if want := map[string]any{"name": "Bob", "age": 30}; !reflect.DeepEqual(params, want) {
+		t.Errorf("Params() = %v, want %v", params, want)
+	}
+	if want := []string{"name"}; !reflect.DeepEqual(collisions, want) {
+		t.Errorf("Params() collisions = %v, want %v", collisions, want)
+	}
+
+	if got := Statements().Script(); got != "" {
+		t.Errorf("Script() of no statements = %q, want empty", got)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/expr"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/internal/util"
 )
 
 // unwindBuilder implements the UnwindBuilder interface
@@ -22,18 +24,18 @@ func (u *unwindBuilder) Where(condition core.Expression) WhereBuilder {
 }
 
 // Match adds a MATCH clause
-func (u *unwindBuilder) Match(pattern core.Expression) MatchBuilder {
+func (u *unwindBuilder) Match(patterns ...core.Expression) MatchBuilder {
 	return &matchBuilder{
-		pattern:  pattern,
+		patterns: patterns,
 		optional: false,
 		prev:     u,
 	}
 }
 
 // OptionalMatch adds an OPTIONAL MATCH clause
-func (u *unwindBuilder) OptionalMatch(pattern core.Expression) MatchBuilder {
+func (u *unwindBuilder) OptionalMatch(patterns ...core.Expression) MatchBuilder {
 	return &matchBuilder{
-		pattern:  pattern,
+		patterns: patterns,
 		optional: true,
 		prev:     u,
 	}
@@ -57,20 +59,26 @@ func (u *unwindBuilder) Returning(expressions ...core.Expression) ReturnBuilder
 
 // Build builds this UNWIND into a complete statement
 func (u *unwindBuilder) Build() (core.Statement, error) {
-	// Simple implementation for now
 	var cypher string
-
 	// If this builder has a previous clause, we need to build that first
+	var prevStmt core.Statement
 	if u.prev != nil {
-		prevStmt, err := u.prev.Build()
+		var err error
+		prevStmt, err = u.prev.Build()
 		if err != nil {
 			return nil, err
 		}
 		cypher = prevStmt.Cypher() + " "
 	}
 
+	params := util.NewParameters(prevStmt)
+	if err := params.Add(u.expression); err != nil {
+		return nil, err
+	}
+
 	// Add UNWIND keyword, expression and alias
 	cypher += fmt.Sprintf("UNWIND %s AS %s", u.expression.String(), u.alias)
 
-	return core.NewStatement(cypher, nil), nil
+	return withClauses(core.NewStatement(params.Render(cypher), params.Values()), prevStmt,
+		clause("UNWIND", expr.As(u.expression, u.alias))), nil
 }
@@ -55,3 +55,64 @@ func TestUnwindWithReturn(t *testing.T) {
 	}
 }
 
+func TestUnwindNumbersParametersAcrossClauses(t *testing.T) {
+	node := ast.Node("Person").Named("p")
+	stmt, err := Unwind(core.NewParameter("", []string{"John", "Jane"}), "name").
+		Match(node).
+		Where(node.Property("age").Gt(core.NewParameter("", 30))).
+		Returning(expr.NewVariableExpression("p")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	want := "UNWIND $p0 AS name MATCH (p:Person) WHERE (p.age > $p1) RETURN p"
+	if stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+	params := stmt.Params()
+	if len(params) != 2 || params["p1"] != 30 {
+		t.Errorf("Params() = %v, want p0 and p1 = 30", params)
+	}
+}
+
+func TestUnwindParam(t *testing.T) {
+	rows := []map[string]any{{"id": 1}, {"id": 2}}
+	stmt, err := UnwindParam(rows, "row").
+		Returning(expr.NewProperty(expr.NewVariableExpression("row"), "id")).
+		Build()
+	if err != nil {
+		t.Fatalf("UnwindParam().Returning().Build() error = %v", err)
+	}
+
+	if want := "UNWIND $rows AS row RETURN row.id"; stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+	if got, ok := stmt.Params()["rows"].([]map[string]any); !ok || len(got) != 2 {
+		t.Errorf("Params() = %v, should bind the rows", stmt.Params())
+	}
+
+	stmt, err = UnwindParam([]string{"a"}, "status").Returning(expr.NewVariableExpression("status")).Build()
+	if err != nil {
+		t.Fatalf("UnwindParam().Build() error = %v", err)
+	}
+	if want := "UNWIND $statusList AS status RETURN status"; stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+}
+
+func TestUnwindWithDistinct(t *testing.T) {
+	x := expr.NewVariableExpression("x")
+	stmt, err := UnwindParam([]int{1, 1, 2}, "x").
+		With(x).
+		Distinct().
+		Returning(x).
+		Build()
+	if err != nil {
+		t.Fatalf("Unwind().With().Distinct().Build() error = %v", err)
+	}
+
+	if want := "UNWIND $xs AS x WITH DISTINCT x RETURN x"; stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+}
@@ -3,6 +3,7 @@ package builder
 import (
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/expr"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/internal/util"
 )
 
 // whereBuilder implements the WhereBuilder interface
@@ -13,6 +14,10 @@ type whereBuilder struct {
 
 // AndWhere adds another condition with AND
 func (w *whereBuilder) AndWhere(condition core.Expression) WhereBuilder {
+	if condition == nil || w.condition == nil {
+		// Keep the nil so that Build reports ErrNilCondition
+		return &whereBuilder{prev: w.prev}
+	}
 	return &whereBuilder{
 		condition: expr.And(w.condition, condition),
 		prev:      w.prev,
@@ -21,6 +26,10 @@ func (w *whereBuilder) AndWhere(condition core.Expression) WhereBuilder {
 
 // OrWhere adds another condition with OR
 func (w *whereBuilder) OrWhere(condition core.Expression) WhereBuilder {
+	if condition == nil || w.condition == nil {
+		// Keep the nil so that Build reports ErrNilCondition
+		return &whereBuilder{prev: w.prev}
+	}
 	return &whereBuilder{
 		condition: expr.Or(w.condition, condition),
 		prev:      w.prev,
@@ -45,20 +54,35 @@ func (w *whereBuilder) Returning(expressions ...core.Expression) ReturnBuilder {
 
 // Build builds this WHERE into a complete statement
 func (w *whereBuilder) Build() (core.Statement, error) {
-	// Simple implementation for now
 	var cypher string
 
 	// If this builder has a previous clause, we need to build that first
+	var prevStmt core.Statement
 	if w.prev != nil {
-		prevStmt, err := w.prev.Build()
+		var err error
+		prevStmt, err = w.prev.Build()
 		if err != nil {
 			return nil, err
 		}
 		cypher = prevStmt.Cypher() + " "
 	}
 
+	if w.condition == nil {
+		return nil, core.NewError(core.ErrNilCondition, "where condition cannot be nil")
+	}
+
+	if err := util.ValidateExpressions(w.condition); err != nil {
+		return nil, err
+	}
+
+	params := util.NewParameters(prevStmt)
+	if err := params.Add(w.condition); err != nil {
+		return nil, err
+	}
+
 	// Add WHERE keyword and condition
 	cypher += "WHERE " + w.condition.String()
 
-	return core.NewStatement(cypher, nil), nil
+	return withClauses(core.NewStatement(params.Render(cypher), params.Values()), prevStmt,
+		clause("WHERE", w.condition)), nil
 }
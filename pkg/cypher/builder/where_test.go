@@ -1,6 +1,8 @@
 package builder
 
 import (
+	"errors"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -71,3 +73,213 @@ func TestWhereWithReturn(t *testing.T) {
 	}
 }
 
+func TestDistanceInWhere(t *testing.T) {
+	place := ast.Node("Place").Named("n")
+	here := expr.Point(expr.Map(map[string]core.Expression{
+		"latitude":  core.NewParameter("lat", 52.5),
+		"longitude": core.NewParameter("lon", 13.4),
+	}))
+
+	stmt, err := Match(place).
+		Where(expr.LessThan(expr.Distance(place.Property("location"), here), core.NewParameter("radius", 1000))).
+		Returning(expr.NewVariableExpression("n")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	want := "MATCH (n:Place) WHERE (point.distance(n.location, point({latitude: $lat, longitude: $lon})) < $radius) RETURN n"
+	if stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+	if !reflect.DeepEqual(stmt.Params(), map[string]any{"lat": 52.5, "lon": 13.4, "radius": 1000}) {
+		t.Errorf("Params() = %v, want lat, lon and radius", stmt.Params())
+	}
+}
+
+func TestWhereNullSafeEq(t *testing.T) {
+	a := ast.Node("Person").Named("a")
+	b := ast.Node("Person").Named("b")
+	stmt, err := Match(a, b).
+		Where(expr.And(
+			expr.NullSafeEquals(a.Property("nickname"), b.Property("nickname")),
+			expr.NullSafeEquals(a.Property("team"), core.NewParameter("", "core")),
+		)).
+		Returning(expr.NewVariableExpression("a"), expr.NewVariableExpression("b")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	want := "MATCH (a:Person), (b:Person) WHERE (((a.nickname = b.nickname) OR ((a.nickname IS NULL) AND (b.nickname IS NULL))) " +
+		"AND ((a.team = $p0) OR ((a.team IS NULL) AND ($p0 IS NULL)))) RETURN a, b"
+	if stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+	if want := map[string]any{"p0": "core"}; !reflect.DeepEqual(stmt.Params(), want) {
+		t.Errorf("Params() = %v, want %v", stmt.Params(), want)
+	}
+}
+
+func TestWhereBarePredicates(t *testing.T) {
+	n := ast.Node("Person").Named("n")
+
+	tests := []struct {
+		name      string
+		condition core.Expression
+		want      string
+	}{
+		{"function", expr.Function("isEmpty", n.Property("tags")), "MATCH (n:Person) WHERE isEmpty(n.tags) RETURN n"},
+		{"property", n.Property("active"), "MATCH (n:Person) WHERE n.active RETURN n"},
+		{"combined", n.Property("active").And(n.Property("email").IsNotNull()),
+			"MATCH (n:Person) WHERE (n.active AND (n.email IS NOT NULL)) RETURN n"},
+		{"negated", n.Property("deleted").Not().And(n.Property("active")),
+			"MATCH (n:Person) WHERE (NOT n.deleted AND n.active) RETURN n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stmt, err := Match(n).Where(tt.condition).Returning(expr.NewVariableExpression("n")).Build()
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			if stmt.Cypher() != tt.want {
+				t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), tt.want)
+			}
+		})
+	}
+}
+
+func TestWhereIsType(t *testing.T) {
+	person := ast.Node("Person").Named("p")
+	stmt, err := Match(person).
+		Where(expr.IsType(person.Property("age"), "INTEGER NOT NULL").And(person.Property("name").IsType("STRING"))).
+		Returning(expr.NewVariableExpression("p")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	want := "MATCH (p:Person) WHERE ((p.age IS :: INTEGER NOT NULL) AND (p.name IS :: STRING)) RETURN p"
+	if stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+
+	_, err = Match(person).Where(expr.IsType(person.Property("age"), "NUMBER")).Returning(expr.NewVariableExpression("p")).Build()
+	if !errors.Is(err, core.ErrInvalidExpression) {
+		t.Errorf("Build() error = %v, want core.ErrInvalidExpression", err)
+	}
+}
+
+func TestWherePatternPredicate(t *testing.T) {
+	a := ast.Node("Person").Named("a").WithProps(map[string]any{"id": core.NewParameter("id", 1)})
+	b := ast.Node("Person").Named("b")
+
+	stmt, err := Match(a, b).
+		Where(ast.PatternPredicate(ast.Pattern(a, a.RelationshipTo(b, "BLOCKED"), b)).Not()).
+		Returning(expr.NewVariableExpression("b")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	want := "MATCH (a:Person {id: $id}), (b:Person) WHERE NOT (a:Person {id: $id})-[:`BLOCKED`]->(b:Person) RETURN b"
+	if stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+	if stmt.Params()["id"] != 1 {
+		t.Errorf("Params() = %v, should include the id parameter", stmt.Params())
+	}
+
+	_, err = Match(a).Where(ast.PatternPredicate(nil)).Returning(expr.NewVariableExpression("a")).Build()
+	if !errors.Is(err, core.ErrEmptyPattern) {
+		t.Errorf("Build() error = %v, want core.ErrEmptyPattern", err)
+	}
+
+	for _, pattern := range []core.Expression{
+		a.RelationshipTo(b, "BLOCKED"),
+		ast.Pattern(a, a.RelationshipTo(b, "BLOCKED")),
+	} {
+		_, err = Match(a, b).Where(ast.PatternPredicate(pattern)).Returning(expr.NewVariableExpression("b")).Build()
+		if !errors.Is(err, core.ErrInvalidPattern) {
+			t.Errorf("Build() with %s error = %v, want core.ErrInvalidPattern", pattern, err)
+		}
+	}
+}
+
+func TestWhereGroupedFragment(t *testing.T) {
+	person := ast.Node("Person").Named("p")
+	visible := expr.Fragment(expr.RawCypher("p.public OR p.owner = $user"), map[string]any{"user": "alice"})
+
+	stmt, err := Match(person).
+		Where(expr.Group(visible).And(person.Property("active").Eq(true))).
+		Returning(expr.NewVariableExpression("p")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	want := "MATCH (p:Person) WHERE ((p.public OR p.owner = $user) AND (p.active = true)) RETURN p"
+	if stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+	if stmt.Params()["user"] != "alice" {
+		t.Errorf("Params() = %v, should include the fragment's user parameter", stmt.Params())
+	}
+}
+
+func TestWhereHasLabel(t *testing.T) {
+	n := ast.Node().Named("n")
+	stmt, err := Match(n).
+		Where(expr.HasLabel(expr.NewVariableExpression("n"), "Person").And(expr.Not(expr.HasLabel(n, "Archived", "Needs Review")))).
+		Returning(expr.NewVariableExpression("n")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	want := "MATCH (n) WHERE (n:Person AND NOT n:Archived:`Needs Review`) RETURN n"
+	if stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+
+	_, err = Match(n).Where(expr.HasLabel(n)).Returning(expr.NewVariableExpression("n")).Build()
+	if !errors.Is(err, core.ErrNoLabels) {
+		t.Errorf("Build() error = %v, want core.ErrNoLabels", err)
+	}
+}
+
+func TestXorWithParameter(t *testing.T) {
+	person := ast.Node("Person").Named("p")
+	stmt, err := Match(person).
+		Where(expr.Xor(core.NewParameter("flag", true), person.Property("age").Gt(30))).
+		Returning(expr.NewVariableExpression("p")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	want := "MATCH (p:Person) WHERE ($flag XOR (p.age > 30)) RETURN p"
+	if stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+}
+
+func TestRawCypherWithParams(t *testing.T) {
+	person := ast.Node("Person").Named("p")
+	stmt, err := Match(person).
+		Where(expr.And(
+			person.Property("active").Eq(true),
+			expr.RawCypherWithParams("p.score >= $threshold", map[string]any{"threshold": 0.5}),
+		)).
+		Returning(person).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if !strings.Contains(stmt.Cypher(), "p.score >= $threshold") {
+		t.Errorf("Cypher() = %q, should contain the raw fragment", stmt.Cypher())
+	}
+	if stmt.Params()["threshold"] != 0.5 {
+		t.Errorf("Params() = %v, should bind threshold", stmt.Params())
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/expr"
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/internal/util"
 )
 
@@ -16,16 +17,36 @@ type withBuilder struct {
 	orderDir    string
 	skipValue   int
 	limitValue  int
-	prev        core.Buildable
+	distinct    bool
+	// hasWhere records that Where was called, so a nil condition fails the
+	// build instead of silently dropping the filter
+	hasWhere bool
+	prev     core.Buildable
+}
+
+// Distinct makes this a WITH DISTINCT clause
+func (w *withBuilder) Distinct() WithBuilder {
+	clone := *w
+	clone.distinct = true
+	return &clone
 }
 
 // Where adds a WHERE clause
 func (w *withBuilder) Where(condition core.Expression) WithBuilder {
 	clone := *w
 	clone.whereClause = condition
+	clone.hasWhere = true
 	return &clone
 }
 
+// WhereIf adds a WHERE clause only when cond is true
+func (w *withBuilder) WhereIf(cond bool, condition core.Expression) WithBuilder {
+	if !cond {
+		return w
+	}
+	return w.Where(condition)
+}
+
 // OrderBy adds an ORDER BY clause
 func (w *withBuilder) OrderBy(expressions ...core.Expression) WithOrderable {
 	clone := *w
@@ -33,6 +54,14 @@ func (w *withBuilder) OrderBy(expressions ...core.Expression) WithOrderable {
 	return &clone
 }
 
+// OrderByIf adds an ORDER BY clause only when cond is true
+func (w *withBuilder) OrderByIf(cond bool, expressions ...core.Expression) WithOrderable {
+	if !cond {
+		return w
+	}
+	return w.OrderBy(expressions...)
+}
+
 // Skip adds a SKIP clause
 func (w *withBuilder) Skip(count int) WithBuilder {
 	clone := *w
@@ -40,6 +69,14 @@ func (w *withBuilder) Skip(count int) WithBuilder {
 	return &clone
 }
 
+// SkipIf adds a SKIP clause only when cond is true
+func (w *withBuilder) SkipIf(cond bool, count int) WithBuilder {
+	if !cond {
+		return w
+	}
+	return w.Skip(count)
+}
+
 // Limit adds a LIMIT clause
 func (w *withBuilder) Limit(count int) WithBuilder {
 	clone := *w
@@ -47,6 +84,14 @@ func (w *withBuilder) Limit(count int) WithBuilder {
 	return &clone
 }
 
+// LimitIf adds a LIMIT clause only when cond is true
+func (w *withBuilder) LimitIf(cond bool, count int) WithBuilder {
+	if !cond {
+		return w
+	}
+	return w.Limit(count)
+}
+
 // Asc specifies ascending order
 func (w *withBuilder) Asc() WithBuilder {
 	clone := *w
@@ -62,18 +107,18 @@ func (w *withBuilder) Desc() WithBuilder {
 }
 
 // Match adds a MATCH clause
-func (w *withBuilder) Match(pattern core.Expression) MatchBuilder {
+func (w *withBuilder) Match(patterns ...core.Expression) MatchBuilder {
 	return &matchBuilder{
-		pattern:  pattern,
+		patterns: patterns,
 		optional: false,
 		prev:     w,
 	}
 }
 
 // OptionalMatch adds an OPTIONAL MATCH clause
-func (w *withBuilder) OptionalMatch(pattern core.Expression) MatchBuilder {
+func (w *withBuilder) OptionalMatch(patterns ...core.Expression) MatchBuilder {
 	return &matchBuilder{
-		pattern:  pattern,
+		patterns: patterns,
 		optional: true,
 		prev:     w,
 	}
@@ -167,44 +212,43 @@ func (w *withBuilder) Build() (core.Statement, error) {
 		}
 	}
 
-	// Collect parameters
-	paramsMap := make(map[string]any)
-
-	// Extract parameters from expressions
-	for _, expr := range w.expressions {
-		util.ExtractParameters(expr, paramsMap)
+	if w.hasWhere && w.whereClause == nil {
+		return nil, core.NewError(core.ErrNilCondition, "with where condition cannot be nil")
 	}
 
-	// Extract parameters from WHERE clause if present
-	if w.whereClause != nil {
-		util.ExtractParameters(w.whereClause, paramsMap)
+	if err := util.ValidateExpressions(append(append([]core.Expression{w.whereClause}, w.expressions...), w.orderBy...)...); err != nil {
+		return nil, err
 	}
 
-	// Extract parameters from ORDER BY expressions if present
-	for _, expr := range w.orderBy {
-		util.ExtractParameters(expr, paramsMap)
+	// Collect parameters from the items as they are rendered, the WHERE
+	// clause and the ORDER BY expressions
+	items := projectionItems(w.expressions)
+	params := util.NewParameters(prevStmt)
+	if err := params.Add(append(append(append([]core.Expression{}, items...), w.whereClause), w.orderBy...)...); err != nil {
+		return nil, err
 	}
 
-	// Build WITH clause
-	parts := []string{"WITH"}
+	// Build WITH clause: WITH [DISTINCT] items ORDER BY SKIP LIMIT WHERE.
+	// ORDER BY, SKIP and LIMIT belong to the projection, so the WHERE that
+	// follows them filters the rows that are left.
+	withKeyword := "WITH"
+	if w.distinct {
+		withKeyword = "WITH DISTINCT"
+	}
+	parts := []string{withKeyword}
 
 	// Add expressions
-	exprStrings := make([]string, len(w.expressions))
-	for i, expr := range w.expressions {
+	exprStrings := make([]string, len(items))
+	for i, expr := range items {
 		exprStrings[i] = expr.String()
 	}
 	parts = append(parts, strings.Join(exprStrings, ", "))
 
-	// Add WHERE clause if present
-	if w.whereClause != nil {
-		parts = append(parts, "WHERE", w.whereClause.String())
-	}
-
 	// Add ORDER BY if present
 	if len(w.orderBy) > 0 {
 		orderExprs := make([]string, len(w.orderBy))
-		for i, expr := range w.orderBy {
-			orderExprs[i] = expr.String()
+		for i, item := range w.orderBy {
+			orderExprs[i] = expr.SortKey(item).String()
 		}
 
 		parts = append(parts, "ORDER BY "+strings.Join(orderExprs, ", "))
@@ -224,22 +268,23 @@ func (w *withBuilder) Build() (core.Statement, error) {
 		parts = append(parts, fmt.Sprintf("LIMIT %d", w.limitValue))
 	}
 
+	// Add WHERE clause if present
+	if w.whereClause != nil {
+		parts = append(parts, "WHERE", w.whereClause.String())
+	}
+
 	// Create the query string
 	query := strings.Join(parts, " ")
 
-	// Merge with previous statement if any
+	// Prepend the previous clauses if any
 	if prevStmt != nil {
-		// Merge previous parameters
-		prevParams := prevStmt.Params()
-		if prevParams != nil {
-			for k, v := range prevParams {
-				paramsMap[k] = v
-			}
-		}
-
-		return core.NewStatement(prevStmt.Cypher()+" "+query, paramsMap), nil
+		query = prevStmt.Cypher() + " " + query
 	}
 
-	// Create a new statement
-	return core.NewStatement(query, paramsMap), nil
+	return withClauses(core.NewStatement(params.Render(query), params.Values()), prevStmt,
+		clause(withKeyword, items...),
+		orderClause(w.orderBy, w.orderDir, false),
+		countClause("SKIP", w.skipValue),
+		countClause("LIMIT", w.limitValue),
+		clauseIf(w.whereClause != nil, "WHERE", w.whereClause)), nil
 }
@@ -1,10 +1,14 @@
 package builder
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/ast"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/expr"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/validation"
 )
 
 func TestWith(t *testing.T) {
@@ -107,3 +111,130 @@ func TestWithWithMatch(t *testing.T) {
 	}
 }
 
+func TestWithDistinct(t *testing.T) {
+	a := expr.NewVariableExpression("a")
+	stmt, err := With(a).
+		Distinct().
+		Where(expr.GreaterThan(a, expr.Integer(1))).
+		OrderBy(a).
+		Limit(5).
+		Build()
+	if err != nil {
+		t.Fatalf("With().Distinct().Build() error = %v", err)
+	}
+
+	expected := "WITH DISTINCT a ORDER BY a LIMIT 5 WHERE (a > 1)"
+	if cypher := stmt.Cypher(); cypher != expected {
+		t.Errorf("Cypher() = %q, want %q", cypher, expected)
+	}
+}
+
+func TestWithOptionalMatch(t *testing.T) {
+	person := ast.Node("Person").Named("p")
+	movie := ast.Node("Movie").Named("m")
+	stmt, err := Match(person).
+		With(expr.NewVariableExpression("p")).
+		OptionalMatch(ast.Pattern(person, person.RelationshipTo(movie, "ACTED_IN"), movie)).
+		Where(movie.Property("released").Gt(2000)).
+		Returning(expr.NewVariableExpression("p"), expr.NewVariableExpression("m")).
+		Build()
+	if err != nil {
+		t.Fatalf("With().OptionalMatch().Build() error = %v", err)
+	}
+
+	expected := "MATCH (p:Person) WITH p OPTIONAL MATCH (p:Person)-[:`ACTED_IN`]->(m:Movie) WHERE (m.released > 2000) RETURN p, m"
+	if cypher := stmt.Cypher(); cypher != expected {
+		t.Errorf("Cypher() = %q, want %q", cypher, expected)
+	}
+}
+
+func TestWithWhereOnAggregateAlias(t *testing.T) {
+	person := ast.Node("Person").Named("p")
+	movie := ast.Node("Movie").Named("m")
+	stmt, err := Match(ast.Pattern(person, person.RelationshipTo(movie, "ACTED_IN"), movie)).
+		With(expr.NewVariableExpression("p"), expr.Count(expr.NewVariableExpression("m")).As("movies")).
+		Where(expr.GreaterThan(expr.NewVariableExpression("movies"), core.NewParameter("", 1))).
+		OrderBy(expr.NewVariableExpression("movies")).
+		Returning(expr.NewVariableExpression("p"), expr.NewVariableExpression("movies")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	want := "MATCH (p:Person)-[:`ACTED_IN`]->(m:Movie) WITH p, count(m) AS movies ORDER BY movies WHERE (movies > $p0) RETURN p, movies"
+	if stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+	if !reflect.DeepEqual(stmt.Params(), map[string]any{"p0": 1}) {
+		t.Errorf("Params() = %v, want p0 = 1", stmt.Params())
+	}
+	if err := validation.NewValidator(validation.ValidationLevelStrict).ValidateStatement(stmt); err != nil {
+		t.Errorf("ValidateStatement() error = %v", err)
+	}
+}
+
+func TestWithLimitThenDelete(t *testing.T) {
+	n := ast.Node("Log").Named("n")
+
+	stmt, err := Match(n).
+		Where(n.Property("expired").Eq(true)).
+		With(expr.NewVariableExpression("n")).
+		Limit(100).
+		DetachDelete(expr.NewVariableExpression("n")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	want := "MATCH (n:Log) WHERE (n.expired = true) WITH n LIMIT 100 DETACH DELETE n"
+	if stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+
+	stmt, err = Match(n).
+		With(expr.NewVariableExpression("n")).
+		OrderBy(n.Property("createdAt")).
+		Limit(100).
+		Delete(expr.NewVariableExpression("n")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	want = "MATCH (n:Log) WITH n ORDER BY n.createdAt LIMIT 100 DELETE n"
+	if stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+}
+
+func TestWithRenamesVariable(t *testing.T) {
+	n := ast.Node("Person").Named("n")
+	knows := n.RelationshipTo(ast.Node("Person").Named("m"), "KNOWS").Named("r")
+
+	stmt, err := Match(n).
+		With(expr.As(n, "person"), expr.As(expr.NewVariableExpression("n.name"), "name")).
+		Returning(expr.NewVariableExpression("person"), expr.NewVariableExpression("name")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	want := "MATCH (n:Person) WITH n AS person, n.name AS name RETURN person, name"
+	if stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+	if got := expr.As(knows, "link").String(); got != "r AS link" {
+		t.Errorf("expr.As(relationship) = %q, want %q", got, "r AS link")
+	}
+
+	// The pattern is not rendered again, so its parameters are not bound twice
+	filtered := n.WithPropsParam(core.NewParameter("props", map[string]any{"name": "Ann"}))
+	stmt, err = Match(filtered).With(expr.As(filtered, "person")).Returning(expr.NewVariableExpression("person")).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	want = "MATCH (n:Person $props) WITH n AS person RETURN person"
+	if stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+	if len(stmt.Params()) != 1 {
+		t.Errorf("Params() = %v, want only props", stmt.Params())
+	}
+}
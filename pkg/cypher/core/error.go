@@ -17,6 +17,16 @@ var (
 	ErrBuildFailed       = errors.New("build failed")
 )
 
+// Specific errors returned by the builders. Each wraps one of the general
+// errors above, so errors.Is matches both.
+var (
+	ErrNilCondition = fmt.Errorf("%w: condition cannot be nil", ErrInvalidExpression)
+	ErrEmptyPattern = fmt.Errorf("%w: pattern cannot be empty", ErrInvalidPattern)
+	ErrEmptyReturn  = fmt.Errorf("%w: RETURN requires at least one expression", ErrInvalidQuery)
+	ErrNoProperties = fmt.Errorf("%w: at least one property is required", ErrInvalidProperty)
+	ErrNoLabels     = fmt.Errorf("%w: at least one label is required", ErrInvalidExpression)
+)
+
 // CypherError represents an error that occurred during Cypher query construction
 type CypherError struct {
 	Err       error
@@ -34,33 +34,45 @@ type PropertyContainer interface {
 	Property(propertyName string) PropertyExpression
 }
 
-// PropertyExpression represents a property access expression
+// PropertyExpression represents a property access expression. It can be
+// combined with other conditions, so that a boolean property can be used as
+// a condition on its own, e.g. WHERE n.active AND n.verified.
 type PropertyExpression interface {
-	Expression
+	BooleanExpression
+	// Not creates a logical NOT of this property
+	Not() Expression
 	// Eq creates an equals comparison with the given value
-	Eq(value any) Expression
+	Eq(value any) BooleanExpression
 	// Gt creates a greater-than comparison with the given value
-	Gt(value any) Expression
+	Gt(value any) BooleanExpression
 	// Lt creates a less-than comparison with the given value
-	Lt(value any) Expression
+	Lt(value any) BooleanExpression
 	// Gte creates a greater-than-or-equal comparison with the given value
-	Gte(value any) Expression
+	Gte(value any) BooleanExpression
 	// Lte creates a less-than-or-equal comparison with the given value
-	Lte(value any) Expression
+	Lte(value any) BooleanExpression
 	// IsNull creates a null check
-	IsNull() Expression
+	IsNull() BooleanExpression
 	// IsNotNull creates a not-null check
-	IsNotNull() Expression
+	IsNotNull() BooleanExpression
+	// IsTrue uses a boolean property as a condition, rendered bare as p.active
+	IsTrue() BooleanExpression
+	// IsFalse negates a boolean property, rendered as NOT p.active
+	IsFalse() BooleanExpression
+	// IsType creates a type predicate, e.g. p.age IS :: INTEGER NOT NULL
+	IsType(typeName string) BooleanExpression
 	// In creates an IN comparison with the given values
-	In(values ...any) Expression
+	In(values ...any) BooleanExpression
 	// StartsWith creates a STARTS WITH comparison
-	StartsWith(value string) Expression
+	StartsWith(value string) BooleanExpression
 	// EndsWith creates an ENDS WITH comparison
-	EndsWith(value string) Expression
+	EndsWith(value string) BooleanExpression
 	// Contains creates a CONTAINS comparison
-	Contains(value string) Expression
+	Contains(value string) BooleanExpression
 	// RegularExpression creates a =~ comparison with a regular expression
-	RegularExpression(pattern string) Expression
+	RegularExpression(pattern string) BooleanExpression
+	// As creates an alias for this property, e.g. p.name AS name
+	As(alias string) Expression
 }
 
 // PatternElement represents an element in a Cypher pattern
@@ -74,6 +86,14 @@ type NamedExpression interface {
 	SymbolicName() string
 }
 
+// AliasableExpression is an expression that can be given an alias in a
+// projection, e.g. Gt(p.age, 30).As("older") renders (p.age > 30) AS older
+type AliasableExpression interface {
+	Expression
+	// As creates an alias for this expression
+	As(alias string) Expression
+}
+
 // BooleanExpression represents an expression that can be used in boolean contexts
 type BooleanExpression interface {
 	Expression
@@ -81,8 +101,8 @@ type BooleanExpression interface {
 	And(other Expression) Expression
 	// Or creates a logical OR with another expression
 	Or(other Expression) Expression
-	// Xor creates a logical XOR with another expression
-	Xor(other Expression) Expression
+	// Xor creates a logical XOR with another boolean expression
+	Xor(other BooleanExpression) BooleanExpression
 }
 
 // Statement represents a complete Cypher statement
@@ -91,6 +111,18 @@ type Statement interface {
 	Cypher() string
 	// Params returns the parameters for this statement
 	Params() map[string]any
+	// ParamsString renders the parameters sorted by name, e.g. {age: 30, name: "Ann"}
+	ParamsString() string
+	// Fingerprint returns a stable hash of the query shape, ignoring parameter values
+	Fingerprint() string
+	// WithParams returns a copy of the statement bound to new parameter values
+	WithParams(params map[string]any) (Statement, error)
+	// Clauses returns the clauses the statement was built from
+	Clauses() []*Clause
+	// Walk traverses the clauses and expressions of the statement in pre-order
+	Walk(visit func(node any) bool)
+	// Transform rebuilds the statement with a function applied to every expression
+	Transform(fn func(expression Expression) Expression) (Statement, error)
 	// Accept applies a visitor to this statement
 	Accept(visitor StatementVisitor) any
 }
@@ -2,6 +2,9 @@ package core
 
 import (
 	"fmt"
+	"math"
+	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -23,6 +26,11 @@ func (l *LiteralExpression) Value() any {
 	return l.value
 }
 
+// LiteralValue returns the value this literal inlines in the query
+func (l *LiteralExpression) LiteralValue() any {
+	return l.value
+}
+
 // String returns the string representation of this literal
 func (l *LiteralExpression) String() string {
 	if l.value == nil {
@@ -36,8 +44,10 @@ func (l *LiteralExpression) String() string {
 		return "'" + escaped + "'"
 	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
 		return fmt.Sprintf("%d", v)
-	case float32, float64:
-		return strconv.FormatFloat(v.(float64), 'f', -1, 64)
+	case float32:
+		return FormatFloat(float64(v))
+	case float64:
+		return FormatFloat(v)
 	case bool:
 		if v {
 			return "true"
@@ -52,19 +62,53 @@ func (l *LiteralExpression) String() string {
 		}
 		return "[" + strings.Join(items, ", ") + "]"
 	case map[string]any:
-		// Map literal
+		// Map literal, with keys sorted so the output is stable
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
 		var pairs []string
-		for key, value := range v {
-			valueLiteral := NewLiteral(value)
+		for _, key := range keys {
+			valueLiteral := NewLiteral(v[key])
 			pairs = append(pairs, key+": "+valueLiteral.String())
 		}
 		return "{" + strings.Join(pairs, ", ") + "}"
 	default:
+		// A nil pointer is NULL, like an untyped nil
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return "NULL"
+		}
 		// Convert to string as a fallback
 		return fmt.Sprintf("%v", v)
 	}
 }
 
+// FormatFloat renders a float as a Cypher number literal. Very large and very
+// small magnitudes use scientific notation (1.5e18, 1e-9) instead of long
+// decimal expansions, and negative zero renders as 0.
+func FormatFloat(value float64) string {
+	if value == 0 {
+		return "0"
+	}
+
+	abs := math.Abs(value)
+	if abs < 1e15 && abs >= 1e-6 || math.IsNaN(value) || math.IsInf(value, 0) {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+
+	// Cypher exponents have no '+' sign and no leading zeros: 1e-09 becomes 1e-9
+	formatted := strconv.FormatFloat(value, 'e', -1, 64)
+	mantissa, exponent, _ := strings.Cut(formatted, "e")
+	sign := ""
+	if strings.HasPrefix(exponent, "-") {
+		sign = "-"
+	}
+	exponent = strings.TrimLeft(exponent, "+-0")
+	return mantissa + "e" + sign + exponent
+}
+
 // Accept applies a visitor to this expression
 func (l *LiteralExpression) Accept(visitor ExpressionVisitor) any {
 	return visitor.Visit(l)
@@ -0,0 +1,142 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// NormalizeParams returns a copy of params whose values are converted to
+// types the Neo4j driver can encode: all integer kinds become int64, float32
+// becomes float64, integer slices such as []int become []int64, pointers are
+// dereferenced and nested slices and maps are normalized recursively.
+// time.Time values and the driver's own dbtype values (Date, Point2D, ...)
+// are kept as they are, since the driver encodes them as temporal and
+// spatial values. Values that cannot be sent to Neo4j, such as structs,
+// functions or channels, produce an error wrapping ErrInvalidParameter that
+// names the offending parameter.
+func NormalizeParams(params map[string]any) (map[string]any, error) {
+	normalized := make(map[string]any, len(params))
+	for name, value := range params {
+		v, err := normalizeValue(name, reflect.ValueOf(value))
+		if err != nil {
+			return nil, err
+		}
+		normalized[name] = v
+	}
+	return normalized, nil
+}
+
+// timeType is the reflect type of time.Time
+var timeType = reflect.TypeOf(time.Time{})
+
+// normalizeValue converts a single value; path names it in error messages
+func normalizeValue(path string, v reflect.Value) (any, error) {
+	if !v.IsValid() {
+		return nil, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool(), nil
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if v.Uint() > math.MaxInt64 {
+			return nil, NewError(ErrInvalidParameter, fmt.Sprintf("parameter %q: value %d overflows int64", path, v.Uint()))
+		}
+		return int64(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return normalizeValue(path, v.Elem())
+	case reflect.Slice, reflect.Array:
+		return normalizeList(path, v)
+	case reflect.Map:
+		return normalizeMap(path, v)
+	case reflect.Struct:
+		if v.Type() == timeType || isDriverType(v.Type()) {
+			return v.Interface(), nil
+		}
+	}
+
+	return nil, NewError(ErrInvalidParameter, fmt.Sprintf("parameter %q: unsupported type %s", path, v.Type()))
+}
+
+// normalizeList converts a slice or array into []int64, []float64, []string, []byte or []any
+func normalizeList(path string, v reflect.Value) (any, error) {
+	if v.Kind() == reflect.Slice && v.IsNil() {
+		return nil, nil
+	}
+
+	switch v.Type().Elem().Kind() {
+	case reflect.Uint8:
+		// Byte slices are sent as byte arrays
+		if v.Kind() == reflect.Slice {
+			return v.Bytes(), nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		ints := make([]int64, v.Len())
+		for i := range ints {
+			ints[i] = v.Index(i).Int()
+		}
+		return ints, nil
+	case reflect.Float32, reflect.Float64:
+		floats := make([]float64, v.Len())
+		for i := range floats {
+			floats[i] = v.Index(i).Float()
+		}
+		return floats, nil
+	case reflect.String:
+		strs := make([]string, v.Len())
+		for i := range strs {
+			strs[i] = v.Index(i).String()
+		}
+		return strs, nil
+	}
+
+	items := make([]any, v.Len())
+	for i := range items {
+		item, err := normalizeValue(fmt.Sprintf("%s[%d]", path, i), v.Index(i))
+		if err != nil {
+			return nil, err
+		}
+		items[i] = item
+	}
+	return items, nil
+}
+
+// normalizeMap converts a map with string keys into map[string]any
+func normalizeMap(path string, v reflect.Value) (any, error) {
+	if v.Type().Key().Kind() != reflect.String {
+		return nil, NewError(ErrInvalidParameter, fmt.Sprintf("parameter %q: map keys must be strings, got %s", path, v.Type().Key()))
+	}
+	if v.IsNil() {
+		return nil, nil
+	}
+
+	entries := make(map[string]any, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		key := iter.Key().String()
+		entry, err := normalizeValue(path+"."+key, iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		entries[key] = entry
+	}
+	return entries, nil
+}
+
+// isDriverType reports whether t is one of the Neo4j driver's temporal or
+// spatial types (package dbtype), which the driver encodes itself
+func isDriverType(t reflect.Type) bool {
+	return strings.Contains(t.PkgPath(), "neo4j-go-driver") && strings.HasSuffix(t.PkgPath(), "/dbtype")
+}
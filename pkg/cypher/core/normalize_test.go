@@ -0,0 +1,90 @@
+package core
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNormalizeParams(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	name := "John"
+	var missing *string
+
+	tests := []struct {
+		name  string
+		value any
+		want  any
+	}{
+		{"int", 42, int64(42)},
+		{"int32", int32(7), int64(7)},
+		{"uint16", uint16(9), int64(9)},
+		{"float32", float32(1.5), float64(1.5)},
+		{"string", "a", "a"},
+		{"bool", true, true},
+		{"nil", nil, nil},
+		{"pointer", &name, "John"},
+		{"nil pointer", missing, nil},
+		{"time", now, now},
+		{"int slice", []int{1, 2, 3}, []int64{1, 2, 3}},
+		{"float32 slice", []float32{0.5}, []float64{0.5}},
+		{"byte slice", []byte("ab"), []byte("ab")},
+		{"string slice", []string{"a", "b"}, []string{"a", "b"}},
+		{"mixed slice", []any{1, "a"}, []any{int64(1), "a"}},
+		{"nested map", map[string]any{"ids": []int{1}, "age": 30}, map[string]any{"ids": []int64{1}, "age": int64(30)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeParams(map[string]any{"p": tt.value})
+			if err != nil {
+				t.Fatalf("NormalizeParams() error = %v", err)
+			}
+			if !reflect.DeepEqual(got["p"], tt.want) {
+				t.Errorf("NormalizeParams() p = %#v, want %#v", got["p"], tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeParamsUnsupported(t *testing.T) {
+	tests := []struct {
+		name   string
+		params map[string]any
+		path   string
+	}{
+		{"struct", map[string]any{"person": struct{ Name string }{"John"}}, `"person"`},
+		{"channel", map[string]any{"c": make(chan int)}, `"c"`},
+		{"function", map[string]any{"f": func() {}}, `"f"`},
+		{"nested", map[string]any{"props": map[string]any{"items": []any{1, complex(1, 2)}}}, `"props.items[1]"`},
+		{"map key", map[string]any{"m": map[int]string{1: "a"}}, `"m"`},
+		{"overflow", map[string]any{"big": uint64(1 << 63)}, `"big"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NormalizeParams(tt.params)
+			if err == nil {
+				t.Fatal("NormalizeParams() should fail")
+			}
+			if !errors.Is(err, ErrInvalidParameter) {
+				t.Errorf("NormalizeParams() error should wrap ErrInvalidParameter, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.path) {
+				t.Errorf("NormalizeParams() error = %q, should name %s", err.Error(), tt.path)
+			}
+		})
+	}
+}
+
+func TestNormalizeParamsDoesNotModifyInput(t *testing.T) {
+	params := map[string]any{"n": 1}
+	if _, err := NormalizeParams(params); err != nil {
+		t.Fatalf("NormalizeParams() error = %v", err)
+	}
+	if _, ok := params["n"].(int); !ok {
+		t.Errorf("NormalizeParams() modified its input: %#v", params)
+	}
+}
@@ -3,20 +3,31 @@ package core
 import (
 	"fmt"
 	"strings"
+	"sync/atomic"
 )
 
+// unnamedParameters counts the unnamed parameters created so far, so that
+// each one renders with a placeholder of its own
+var unnamedParameters atomic.Uint64
+
 // ParameterExpression represents a parameter in a Cypher query
 type ParameterExpression struct {
 	name  string
 	value any
+	id    uint64
 }
 
-// NewParameter creates a new parameter expression
+// NewParameter creates a new parameter expression; with an empty name the
+// parameter is named when a statement is built from it, see ParameterSet
 func NewParameter(name string, value any) *ParameterExpression {
-	return &ParameterExpression{
+	p := &ParameterExpression{
 		name:  name,
 		value: value,
 	}
+	if name == "" {
+		p.id = unnamedParameters.Add(1)
+	}
+	return p
 }
 
 // Name returns the parameter name
@@ -29,11 +40,21 @@ func (p *ParameterExpression) Value() any {
 	return p.value
 }
 
-// String returns the string representation of this parameter
+// String returns the string representation of this parameter. An unnamed
+// parameter renders as a placeholder, which a statement built from it
+// replaces with the name the parameter was given in that statement.
 func (p *ParameterExpression) String() string {
+	if p.name == "" {
+		return p.placeholder()
+	}
 	return "$" + p.name
 }
 
+// placeholder returns the text an unnamed parameter renders as
+func (p *ParameterExpression) placeholder() string {
+	return fmt.Sprintf("$<unnamed %d>", p.id)
+}
+
 // Accept implements the Expression interface
 func (p *ParameterExpression) Accept(visitor ExpressionVisitor) any {
 	return visitor.Visit(p)
@@ -0,0 +1,120 @@
+package core
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ParameterSet collects the parameters of a statement while it is built and
+// names its unnamed parameters p0, p1, ... in the order they are found. A
+// name that is already bound, or taken by a named parameter added in the same
+// call, is skipped, and an unnamed parameter whose value equals that of an
+// earlier p<N> shares its name, so a repeated constant is sent only once.
+//
+// The names are kept in the set rather than in the parameters, so the same
+// parameter can be used in several statements, even ones built concurrently,
+// and is named independently in each.
+type ParameterSet struct {
+	values map[string]any
+	names  map[*ParameterExpression]string
+	// reserved holds names to avoid unless their value is equal, such as
+	// those of the statement a transformed statement is rebuilt from
+	reserved map[string]any
+}
+
+// NewParameterSet creates a set holding the given parameters, such as those
+// of the clauses a builder continues
+func NewParameterSet(params map[string]any) *ParameterSet {
+	s := &ParameterSet{
+		values: make(map[string]any, len(params)),
+		names:  make(map[*ParameterExpression]string),
+	}
+	for name, value := range params {
+		s.values[name] = value
+	}
+	return s
+}
+
+// Add collects the parameters found in the expressions and their
+// subexpressions. Named parameters are added first, so an unnamed parameter
+// never takes a name one of them uses. A name bound to two different values
+// is an ErrInvalidParameter error.
+func (s *ParameterSet) Add(expressions ...Expression) error {
+	var unnamed []*ParameterExpression
+	var err error
+	for _, expression := range expressions {
+		walkParameters(expression, func(p *ParameterExpression) {
+			if p.name == "" {
+				unnamed = append(unnamed, p)
+				return
+			}
+			if existing, ok := s.values[p.name]; ok && !reflect.DeepEqual(existing, p.value) && err == nil {
+				err = NewError(ErrInvalidParameter,
+					fmt.Sprintf("parameter $%s is bound to both %v and %v", p.name, existing, p.value))
+			}
+			s.values[p.name] = p.value
+		})
+	}
+
+	for _, p := range unnamed {
+		if _, ok := s.names[p]; ok {
+			continue
+		}
+		name := s.nameFor(p.value)
+		s.names[p] = name
+		s.values[name] = p.value
+	}
+	return err
+}
+
+// nameFor returns the first name of the form p<N> that is either bound to an
+// equal value or free
+func (s *ParameterSet) nameFor(value any) string {
+	for i := 0; ; i++ {
+		name := fmt.Sprintf("p%d", i)
+		if existing, ok := s.values[name]; ok {
+			if reflect.DeepEqual(existing, value) {
+				return name
+			}
+			continue
+		}
+		if reserved, ok := s.reserved[name]; ok && !reflect.DeepEqual(reserved, value) {
+			continue
+		}
+		return name
+	}
+}
+
+// Render replaces the placeholders of the unnamed parameters in a Cypher
+// string rendered from the added expressions with their names
+func (s *ParameterSet) Render(cypher string) string {
+	if len(s.names) == 0 {
+		return cypher
+	}
+	pairs := make([]string, 0, 2*len(s.names))
+	for p, name := range s.names {
+		pairs = append(pairs, p.placeholder(), "$"+name)
+	}
+	return strings.NewReplacer(pairs...).Replace(cypher)
+}
+
+// Values returns the parameters collected so far by name
+func (s *ParameterSet) Values() map[string]any {
+	return s.values
+}
+
+// walkParameters calls fn for every parameter in an expression, finding
+// nested expressions the same way Walk does
+func walkParameters(expression Expression, fn func(p *ParameterExpression)) {
+	if expression == nil {
+		return
+	}
+	if p, ok := expression.(*ParameterExpression); ok {
+		fn(p)
+		return
+	}
+	for _, child := range children(expression) {
+		walkParameters(child, fn)
+	}
+}
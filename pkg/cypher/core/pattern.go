@@ -22,6 +22,10 @@ type NodeExpression interface {
 	WithLabels(labels ...string) NodeExpression
 	// WithProperties adds properties to this node pattern
 	WithProperties(properties map[string]Expression) NodeExpression
+	// WithPropsParam uses a single map parameter for the properties, rendered as (n:Label $props)
+	WithPropsParam(param Expression) NodeExpression
+	// Where adds an inline predicate, rendered as (n:Label WHERE condition)
+	Where(condition Expression) NodeExpression
 	// Props is an alias for WithProps
 	Props(properties map[string]interface{}) Expression
 	// RelationshipTo creates a relationship from this node to another
@@ -32,6 +36,8 @@ type NodeExpression interface {
 	RelationshipBetween(other NodeExpression, types ...string) RelationshipPattern
 	// SymbolicName returns the alias of this node pattern
 	SymbolicName() string
+	// Prop is an alias for Property
+	Prop(propertyName string) PropertyExpression
 	// WithProps adds properties with automatic conversion to expressions
 	// and returns the node expression itself for chaining
 	WithProps(properties map[string]interface{}) NodeExpression
@@ -59,4 +65,35 @@ type RelationshipPattern interface {
 	Types() []string
 	// SymbolicName returns the alias of this relationship pattern
 	SymbolicName() string
+	// Prop is an alias for Property
+	Prop(propertyName string) PropertyExpression
+	// RelationshipTo continues the pattern with a relationship from the node
+	// this relationship leads to, to another node
+	RelationshipTo(other NodeExpression, types ...string) PathPattern
+	// RelationshipFrom continues the pattern with a relationship from another
+	// node to the node this relationship leads to
+	RelationshipFrom(other NodeExpression, types ...string) PathPattern
+	// RelationshipBetween continues the pattern with an undirected relationship
+	RelationshipBetween(other NodeExpression, types ...string) PathPattern
+}
+
+// PathPattern is a chain of relationships read from left to right, e.g.
+// (a)-[r:R]->(b)<-[s:S]-(c). Named and the property methods apply to the
+// last relationship, so each one can be configured before the next is added.
+type PathPattern interface {
+	PatternElement
+	// Named sets the alias of the last relationship
+	Named(alias string) PathPattern
+	// WithProperties adds properties to the last relationship
+	WithProperties(properties map[string]Expression) PathPattern
+	// WithProps adds properties to the last relationship with automatic conversion to expressions
+	WithProps(properties map[string]interface{}) PathPattern
+	// Props is an alias for WithProps
+	Props(properties map[string]interface{}) PathPattern
+	// RelationshipTo adds a relationship from the last node to another
+	RelationshipTo(other NodeExpression, types ...string) PathPattern
+	// RelationshipFrom adds a relationship from another node to the last one
+	RelationshipFrom(other NodeExpression, types ...string) PathPattern
+	// RelationshipBetween adds an undirected relationship between the last node and another
+	RelationshipBetween(other NodeExpression, types ...string) PathPattern
 }
@@ -1,10 +1,23 @@
 package core
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
 // StatementImpl implements the Statement interface
 type StatementImpl struct {
 	cypher     string
 	params     map[string]any
 	parameters *Parameters
+	clauses    []*Clause
 }
 
 // NewStatement creates a new statement with the given Cypher and params
@@ -43,6 +56,103 @@ func (s *StatementImpl) Params() map[string]any {
 	return s.params
 }
 
+// ParamsString renders the parameters sorted by name, e.g.
+// {age: 30, name: "Ann"}, so that they can be compared and printed in tests
+// and debug output. Nested maps are sorted as well.
+func (s *StatementImpl) ParamsString() string {
+	return formatParamValue(reflect.ValueOf(s.params))
+}
+
+// formatParamValue renders a parameter value for ParamsString: strings
+// quoted, lists in brackets and maps with string keys in braces
+func formatParamValue(value reflect.Value) string {
+	if !value.IsValid() {
+		return "null"
+	}
+	switch value.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if value.IsNil() {
+			return "null"
+		}
+		return formatParamValue(value.Elem())
+	case reflect.String:
+		return strconv.Quote(value.String())
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			return "null"
+		}
+		items := make([]string, value.Len())
+		for i := range items {
+			items[i] = formatParamValue(value.Index(i))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			break
+		}
+		keys := make([]string, 0, value.Len())
+		for _, key := range value.MapKeys() {
+			keys = append(keys, key.String())
+		}
+		sort.Strings(keys)
+		items := make([]string, len(keys))
+		for i, key := range keys {
+			items[i] = key + ": " + formatParamValue(value.MapIndex(reflect.ValueOf(key).Convert(value.Type().Key())))
+		}
+		return "{" + strings.Join(items, ", ") + "}"
+	}
+	return fmt.Sprint(value.Interface())
+}
+
+// Fingerprint returns a stable hash of the statement's query shape, which
+// makes it suitable as a key for query caches. For a built statement it
+// hashes the Cypher rendered with every literal and unnamed parameter
+// replaced by a placeholder numbered in query order, so statements that only
+// differ in their values share a fingerprint whether the values were inlined
+// or passed as parameters; named parameters keep their names. A statement
+// created from a Cypher string has no clauses to rewrite, so its query text
+// is hashed as it is.
+func (s *StatementImpl) Fingerprint() string {
+	cypher := s.cypher
+	if len(s.clauses) > 0 {
+		placeholders := 0
+		shape, err := s.Transform(func(expression Expression) Expression {
+			switch e := expression.(type) {
+			case interface{ LiteralValue() any }:
+			case *ParameterExpression:
+				if e.name != "" {
+					return expression
+				}
+			default:
+				return expression
+			}
+			placeholders++
+			// ? is not allowed in parameter names, so no named parameter is shadowed
+			return NewParameter(fmt.Sprintf("?%d", placeholders), nil)
+		})
+		if err == nil {
+			cypher = shape.Cypher()
+		}
+	}
+	sum := sha256.Sum256([]byte(cypher))
+	return hex.EncodeToString(sum[:])
+}
+
+// MarshalJSON serializes the statement as {"cypher": "...", "params": {...}}
+func (s *StatementImpl) MarshalJSON() ([]byte, error) {
+	params := s.params
+	if params == nil {
+		params = map[string]any{}
+	}
+	return json.Marshal(struct {
+		Cypher string         `json:"cypher"`
+		Params map[string]any `json:"params"`
+	}{
+		Cypher: s.cypher,
+		Params: params,
+	})
+}
+
 // Parameters returns the Parameters object for this statement
 func (s *StatementImpl) Parameters() *Parameters {
 	return s.parameters
@@ -59,16 +169,111 @@ func (s *StatementImpl) WithCypher(cypher string) *StatementImpl {
 		cypher:     cypher,
 		params:     s.params,
 		parameters: s.parameters,
+		clauses:    s.clauses,
 	}
 }
 
-// WithParams creates a new statement with the given parameters map
-func (s *StatementImpl) WithParams(params map[string]any) *StatementImpl {
-	return &StatementImpl{
-		cypher:     s.cypher,
-		params:     params,
-		parameters: s.parameters,
+// WithParams returns a copy of the statement that shares its Cypher but binds
+// the given values, so a built query can be reused with new bindings. The
+// keys must be exactly the $names the query references; a missing or unknown
+// key returns an error wrapping ErrInvalidParameter.
+func (s *StatementImpl) WithParams(params map[string]any) (Statement, error) {
+	referenced := ParameterNames(s.cypher)
+
+	var missing, unknown []string
+	for _, name := range referenced {
+		if _, ok := params[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	for name := range params {
+		if !containsName(referenced, name) {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+
+	var problems []string
+	if len(missing) > 0 {
+		problems = append(problems, "missing "+strings.Join(missing, ", "))
+	}
+	if len(unknown) > 0 {
+		problems = append(problems, "not referenced by the query: "+strings.Join(unknown, ", "))
+	}
+	if len(problems) > 0 {
+		return nil, NewError(ErrInvalidParameter, "parameters do not match the query: "+strings.Join(problems, "; "))
+	}
+
+	copied := make(map[string]any, len(params))
+	for name, value := range params {
+		copied[name] = value
+	}
+	return NewStatement(s.cypher, copied).WithClauses(s.clauses), nil
+}
+
+// ParameterNames returns the names of the $parameters a Cypher query
+// references, sorted and without duplicates. String literals, quoted
+// identifiers and comments are skipped.
+func ParameterNames(cypher string) []string {
+	var names []string
+	runes := []rune(cypher)
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; {
+		case r == '\'' || r == '"' || r == '`':
+			for i++; i < len(runes) && runes[i] != r; i++ {
+				if runes[i] == '\\' && r != '`' {
+					i++
+				}
+			}
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '/':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			for i += 2; i+1 < len(runes) && !(runes[i] == '*' && runes[i+1] == '/'); i++ {
+			}
+			i++
+		case r == '$':
+			start := i + 1
+			if start < len(runes) && runes[start] == '`' {
+				end := start + 1
+				for end < len(runes) && runes[end] != '`' {
+					end++
+				}
+				names = appendName(names, string(runes[start+1:min(end, len(runes))]))
+				i = end
+				continue
+			}
+			end := start
+			for end < len(runes) && (runes[end] == '_' || unicode.IsLetter(runes[end]) || unicode.IsDigit(runes[end])) {
+				end++
+			}
+			if end > start {
+				names = appendName(names, string(runes[start:end]))
+			}
+			i = end - 1
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// appendName appends name unless it is already in names
+func appendName(names []string, name string) []string {
+	if containsName(names, name) {
+		return names
+	}
+	return append(names, name)
+}
+
+// containsName reports whether names contains name
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
 	}
+	return false
 }
 
 // Merge combines this statement with another one
@@ -1,6 +1,9 @@
 package core
 
 import (
+	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 )
 
@@ -46,13 +49,53 @@ func TestStatementWithCypher(t *testing.T) {
 }
 
 func TestStatementWithParams(t *testing.T) {
-	stmt := NewStatement("MATCH (n) RETURN n", map[string]any{"old": "value"})
-	newParams := map[string]any{"new": "value"}
-	newStmt := stmt.WithParams(newParams)
+	stmt := NewStatement("MATCH (n) WHERE n.name = $name RETURN n", map[string]any{"name": "old"})
+	newParams := map[string]any{"name": "new"}
+	newStmt, err := stmt.WithParams(newParams)
+	if err != nil {
+		t.Fatalf("WithParams() error = %v", err)
+	}
 
-	if newStmt.Params()["new"] != "value" {
+	if newStmt.Params()["name"] != "new" {
 		t.Errorf("WithParams() did not set new params correctly")
 	}
+	if newStmt.Cypher() != stmt.Cypher() || stmt.Params()["name"] != "old" {
+		t.Errorf("WithParams() should share the Cypher and leave the original statement unchanged")
+	}
+	newParams["name"] = "changed"
+	if newStmt.Params()["name"] != "new" {
+		t.Errorf("WithParams() should copy the params map")
+	}
+}
+
+func TestStatementWithParamsValidatesNames(t *testing.T) {
+	stmt := NewStatement("MATCH (n) WHERE n.name = $name AND n.age > $age RETURN n", nil)
+
+	tests := map[string]map[string]any{
+		"missing": {"name": "Tom"},
+		"unknown": {"name": "Tom", "age": 30, "city": "Berlin"},
+		"nil":     nil,
+	}
+	for name, params := range tests {
+		if _, err := stmt.WithParams(params); !errors.Is(err, ErrInvalidParameter) {
+			t.Errorf("%s: WithParams() error = %v, want ErrInvalidParameter", name, err)
+		}
+	}
+
+	_, err := stmt.WithParams(map[string]any{"name": "Tom", "city": "Berlin"})
+	if err == nil || !contains(err.Error(), "missing age") || !contains(err.Error(), "city") {
+		t.Errorf("WithParams() error = %v, should name the missing and unknown keys", err)
+	}
+}
+
+func TestParameterNames(t *testing.T) {
+	cypher := "MATCH (n {id: $id}) // $commented\n" +
+		"WHERE n.name = '$literal' AND n.code = $`my param` AND n.id IN $ids OR n.id = $id /* $block */ RETURN n"
+	got := ParameterNames(cypher)
+	want := []string{"id", "ids", "my param"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("ParameterNames() = %v, want %v", got, want)
+	}
 }
 
 func TestStatementMerge(t *testing.T) {
@@ -81,6 +124,34 @@ func TestStatementMergeWithNil(t *testing.T) {
 	}
 }
 
+func TestStatementMarshalJSON(t *testing.T) {
+	stmt := NewStatement("MATCH (n) WHERE n.age > $age RETURN n", map[string]any{"age": 30, "name": "John"})
+
+	data, err := json.Marshal(stmt)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	want := `{"cypher":"MATCH (n) WHERE n.age \u003e $age RETURN n","params":{"age":30,"name":"John"}}`
+	if string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+}
+
+func TestStatementMarshalJSONNilParams(t *testing.T) {
+	stmt := &StatementImpl{cypher: "MATCH (n) RETURN n"}
+
+	data, err := json.Marshal(stmt)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	want := `{"cypher":"MATCH (n) RETURN n","params":{}}`
+	if string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+}
+
 // Helper function
 func contains(s, substr string) bool {
 	for i := 0; i <= len(s)-len(substr); i++ {
@@ -91,3 +162,39 @@ func contains(s, substr string) bool {
 	return false
 }
 
+func TestStatementFingerprint(t *testing.T) {
+	stmt1 := NewStatement("MATCH (n) WHERE n.name = $name RETURN n", map[string]any{"name": "John"})
+	stmt2 := NewStatement("MATCH (n) WHERE n.name = $name RETURN n", map[string]any{"name": "Jane"})
+	stmt3 := NewStatement("MATCH (n) WHERE n.age = $name RETURN n", map[string]any{"name": "John"})
+
+	if stmt1.Fingerprint() != stmt2.Fingerprint() {
+		t.Errorf("Fingerprint() should ignore parameter values, got %q and %q", stmt1.Fingerprint(), stmt2.Fingerprint())
+	}
+	if stmt1.Fingerprint() == stmt3.Fingerprint() {
+		t.Errorf("Fingerprint() should differ for different queries")
+	}
+	if stmt1.Fingerprint() != stmt1.Fingerprint() {
+		t.Errorf("Fingerprint() should be stable")
+	}
+}
+
+func TestStatementParamsString(t *testing.T) {
+	stmt := NewStatement("MATCH (n) RETURN n", map[string]any{
+		"name":    "Ann",
+		"age":     30,
+		"tags":    []string{"a", "b"},
+		"address": map[string]any{"zip": "1000", "city": "Oslo"},
+		"manager": nil,
+	})
+
+	want := `{address: {city: "Oslo", zip: "1000"}, age: 30, manager: null, name: "Ann", tags: ["a", "b"]}`
+	for i := 0; i < 5; i++ {
+		if got := stmt.ParamsString(); got != want {
+			t.Fatalf("ParamsString() = %q, want %q", got, want)
+		}
+	}
+
+	if got := NewStatement("RETURN 1", nil).ParamsString(); got != "{}" {
+		t.Errorf("ParamsString() without parameters = %q, want {}", got)
+	}
+}
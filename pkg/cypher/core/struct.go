@@ -0,0 +1,65 @@
+package core
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// StructProperties returns the exported fields of a struct (or a pointer to
+// one) as a property map. Fields are named after their `cypher:"name"` tag,
+// falling back to the field name; `cypher:"-"` skips a field and the
+// omitempty option skips it when it holds its zero value. The fields of
+// exported embedded structs are promoted unless the embedded struct is tagged.
+func StructProperties(v any) (map[string]any, error) {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil, NewError(ErrInvalidParameter, fmt.Sprintf("expected a struct, got nil %T", v))
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, NewError(ErrInvalidParameter, fmt.Sprintf("expected a struct, got %T", v))
+	}
+
+	properties := make(map[string]any)
+	collectStructProperties(value, properties)
+	return properties, nil
+}
+
+// collectStructProperties adds the exported fields of value to properties
+func collectStructProperties(value reflect.Value, properties map[string]any) {
+	structType := value.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		tag, tagged := field.Tag.Lookup("cypher")
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+		if field.Anonymous && !tagged {
+			embedded := fieldValue
+			if embedded.Kind() == reflect.Ptr {
+				if embedded.IsNil() {
+					continue
+				}
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				collectStructProperties(embedded, properties)
+				continue
+			}
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		if options == "omitempty" && fieldValue.IsZero() {
+			continue
+		}
+		properties[name] = fieldValue.Interface()
+	}
+}
@@ -0,0 +1,65 @@
+package core
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type Audit struct {
+	CreatedBy string `cypher:"createdBy"`
+}
+
+type structPerson struct {
+	Audit
+	Name     string `cypher:"name"`
+	Age      int    `cypher:"age,omitempty"`
+	Nickname string `cypher:",omitempty"`
+	Email    string
+	Password string `cypher:"-"`
+	internal string
+}
+
+func TestStructProperties(t *testing.T) {
+	person := structPerson{
+		Audit:    Audit{CreatedBy: "import"},
+		Name:     "Ann",
+		Email:    "ann@example.com",
+		Password: "secret",
+		internal: "hidden",
+	}
+
+	want := map[string]any{
+		"createdBy": "import",
+		"name":      "Ann",
+		"Email":     "ann@example.com",
+	}
+	for _, v := range []any{person, &person} {
+		got, err := StructProperties(v)
+		if err != nil {
+			t.Fatalf("StructProperties(%T) error = %v", v, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("StructProperties(%T) = %v, want %v", v, got, want)
+		}
+	}
+
+	person.Age = 30
+	person.Nickname = "Annie"
+	got, err := StructProperties(person)
+	if err != nil {
+		t.Fatalf("StructProperties() error = %v", err)
+	}
+	if got["age"] != 30 || got["Nickname"] != "Annie" {
+		t.Errorf("StructProperties() = %v, should keep non-zero omitempty fields", got)
+	}
+}
+
+func TestStructPropertiesRejectsNonStructs(t *testing.T) {
+	var missing *structPerson
+	for _, v := range []any{nil, missing, "Ann", map[string]any{"name": "Ann"}} {
+		if _, err := StructProperties(v); !errors.Is(err, ErrInvalidParameter) {
+			t.Errorf("StructProperties(%#v) error = %v, want ErrInvalidParameter", v, err)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Clause is a clause of a built statement together with the expressions it
+// contains, e.g. WHERE and its condition. SKIP and LIMIT hold their count as
+// an integer literal, and Cypher appended as raw text is a clause with an
+// empty keyword and no expressions.
+type Clause struct {
+	Keyword     string
+	Expressions []Expression
+}
+
+// Clauses returns the clauses the statement was built from in query order.
+// A statement created from a Cypher string has none.
+func (s *StatementImpl) Clauses() []*Clause {
+	return s.clauses
+}
+
+// WithClauses records the clauses the statement was built from and returns
+// the statement; the builders call it when they create a statement
+func (s *StatementImpl) WithClauses(clauses []*Clause) *StatementImpl {
+	s.clauses = clauses
+	return s
+}
+
+// Walk traverses the statement in pre-order, visiting each clause followed
+// by its expressions and their subexpressions. visit receives either a
+// *Clause or an Expression; returning false skips the children of that node.
+// The Cypher has already been rendered, so Walk is meant for analysis:
+// changing an expression does not change the statement.
+func (s *StatementImpl) Walk(visit func(node any) bool) {
+	for _, clause := range s.clauses {
+		if !visit(clause) {
+			continue
+		}
+		for _, expression := range clause.Expressions {
+			walkExpression(expression, visit)
+		}
+	}
+}
+
+// walkExpression visits an expression and then its children
+func walkExpression(expression Expression, visit func(node any) bool) {
+	if expression == nil || !visit(expression) {
+		return
+	}
+	for _, child := range children(expression) {
+		walkExpression(child, visit)
+	}
+}
+
+// children returns the expressions nested in an expression, found the same
+// way parameters are: through Expressions() or else Left() and Right()
+func children(expression Expression) []Expression {
+	switch e := expression.(type) {
+	case interface{ Expressions() []Expression }:
+		return e.Expressions()
+	case interface {
+		Left() Expression
+		Right() Expression
+	}:
+		return []Expression{e.Left(), e.Right()}
+	}
+	return nil
+}
+
+// Rebuilder is implemented by expressions that can be copied with other
+// children, given in the order Walk visits them. Transform uses it to
+// replace the expressions nested in another one.
+type Rebuilder interface {
+	WithChildren(children []Expression) Expression
+}
+
+// Transform returns a statement rebuilt from the clauses of this one with fn
+// applied to every expression, innermost first: fn receives an expression
+// whose children have already been transformed and returns the expression to
+// use in its place, or the expression itself to keep it. An expression that
+// does not implement Rebuilder is passed to fn as a whole, without its
+// children. Parameters are collected again, so fn may introduce new ones,
+// and the result is validated like a statement that was just built.
+func (s *StatementImpl) Transform(fn func(expression Expression) Expression) (Statement, error) {
+	if len(s.clauses) == 0 {
+		return nil, NewError(ErrInvalidQuery, "only statements built from clauses can be transformed")
+	}
+
+	clauses := make([]*Clause, len(s.clauses))
+	for i, clause := range s.clauses {
+		expressions := make([]Expression, len(clause.Expressions))
+		for j, expression := range clause.Expressions {
+			expressions[j] = transformExpression(expression, fn)
+			if expressions[j] == nil {
+				return nil, NewError(ErrInvalidExpression,
+					fmt.Sprintf("transforming the %s clause produced a nil expression", clause.Keyword))
+			}
+		}
+		clauses[i] = &Clause{Keyword: clause.Keyword, Expressions: expressions}
+	}
+	transformed := &StatementImpl{clauses: clauses}
+
+	// Validate the expressions and name their parameters, as the builders do.
+	// Names of the original statement are kept for parameters of equal value.
+	var err error
+	var expressions []Expression
+	transformed.Walk(func(node any) bool {
+		if validatable, ok := node.(interface{ Validate() error }); ok && err == nil {
+			err = validatable.Validate()
+		}
+		if clause, ok := node.(*Clause); ok {
+			expressions = append(expressions, clause.Expressions...)
+		}
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	set := NewParameterSet(nil)
+	set.reserved = s.params
+	if err := set.Add(expressions...); err != nil {
+		return nil, err
+	}
+	params := set.Values()
+
+	parts := make([]string, len(clauses))
+	for i, clause := range clauses {
+		items := make([]string, len(clause.Expressions))
+		for j, expression := range clause.Expressions {
+			items[j] = expression.String()
+		}
+		parts[i] = strings.TrimSpace(clause.Keyword + " " + strings.Join(items, ", "))
+	}
+	transformed.cypher = set.Render(strings.Join(parts, " "))
+
+	// Keep the values of parameters that no expression carries, such as those
+	// of appended raw Cypher, as long as the query still references them
+	transformed.params = make(map[string]any)
+	for _, name := range ParameterNames(transformed.cypher) {
+		if value, ok := params[name]; ok {
+			transformed.params[name] = value
+		} else if value, ok := s.params[name]; ok {
+			transformed.params[name] = value
+		}
+	}
+	return transformed, nil
+}
+
+// transformExpression transforms the children of an expression that can be
+// rebuilt with them and then applies fn to the expression itself
+func transformExpression(expression Expression, fn func(Expression) Expression) Expression {
+	if expression == nil {
+		return nil
+	}
+	if rebuilder, ok := expression.(Rebuilder); ok {
+		nested := children(expression)
+		if len(nested) > 0 {
+			transformed := make([]Expression, len(nested))
+			for i, child := range nested {
+				transformed[i] = transformExpression(child, fn)
+			}
+			expression = rebuilder.WithChildren(transformed)
+		}
+	}
+	return fn(expression)
+}
@@ -1,12 +1,23 @@
-// Package cypher provides a fluent API for building Cypher queries
+// Package cypher provides a fluent API for building Cypher queries.
+//
+// This package is the canonical entry point of the DSL: node patterns,
+// expressions and clause builders should be obtained through the functions
+// declared here. The core, expr, ast, builder and renderer subpackages are
+// the building blocks these functions delegate to; they are public so that
+// their types can be named, but they are not a separate API.
 package cypher
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/ast"
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/builder"
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/expr"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/internal/util"
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/renderer"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/validation"
 )
 
 // Property creates a property expression for a node or relationship
@@ -14,6 +25,25 @@ func Property(entity, property string) core.Expression {
 	return expr.Property(entity, property)
 }
 
+// PropertyOf creates a property expression over any expression, such as an
+// UNWIND variable or a map: PropertyOf(Var("row"), "id") renders row.id
+func PropertyOf(subject core.Expression, name string) core.PropertyExpression {
+	return expr.NewProperty(subject, name)
+}
+
+// Fragment is a reusable condition or pattern together with its parameters
+type Fragment = expr.FragmentExpression
+
+// NewFragment wraps an expression and the parameters it references, such as
+// a WHERE condition shared by several queries:
+//
+//	active := NewFragment(RawCypher("p.active = $active"), map[string]any{"active": true})
+//	Match(person).Where(active)
+//	Match(person).Where(active.And(Gt(person.Property("age"), NamedParam("age", 30))))
+func NewFragment(expression core.Expression, params map[string]any) *Fragment {
+	return expr.Fragment(expression, params)
+}
+
 // Parameters creates a new parameter container
 func Parameters() *core.Parameters {
 	return core.NewParameters()
@@ -36,7 +66,7 @@ func ParamWithValue(name string, value any) core.Expression {
 
 // Literal creates a new literal expression
 func Literal(value any) core.Expression {
-	return core.NewLiteral(value)
+	return expr.LiteralFromValue(value)
 }
 
 // Node creates a new node pattern
@@ -62,15 +92,28 @@ func NamedNodeWithProperties(alias string, properties map[string]any, labels ...
 }
 
 // Pattern creates a pattern expression
-func Pattern(elements ...core.PatternElement) core.Expression {
+func Pattern(elements ...core.PatternElement) *ast.PatternExpression {
 	return ast.Pattern(elements...)
 }
 
-// Path creates a path pattern
-func Path(elements ...core.PatternElement) core.Expression {
+// Path creates a path pattern; name it with Named to refer to the path,
+// e.g. MATCH p = (a)-[:KNOWS]->(b) RETURN p
+func Path(elements ...core.PatternElement) *ast.PatternExpression {
 	return ast.Path(elements...)
 }
 
+// PatternUnion creates a parenthesized alternation of whole graph patterns,
+// e.g. ((a)-[:R]->(b) | (a)-[:S]->(b)), for use in MATCH
+func PatternUnion(patterns ...core.Expression) core.Expression {
+	return ast.PatternUnion(patterns...)
+}
+
+// PatternPredicate turns a pattern into a WHERE condition; negate it with
+// Not() to render NOT (a)-[:BLOCKED]->(b)
+func PatternPredicate(pattern core.Expression) *ast.PatternPredicateExpression {
+	return ast.PatternPredicate(pattern)
+}
+
 // Chain creates a relationship chain
 func Chain(startNode core.NodeExpression, relationships ...core.RelationshipPattern) core.Expression {
 	return ast.Chain(startNode, relationships...)
@@ -82,6 +125,12 @@ func RelateNodes(fromNode core.NodeExpression, toNode core.NodeExpression, relTy
 	return rel
 }
 
+// RelateNodesFrom creates an incoming relationship, (fromNode)<-[:R]-(toNode)
+func RelateNodesFrom(fromNode core.NodeExpression, toNode core.NodeExpression, relType string) core.PatternElement {
+	rel := fromNode.RelationshipFrom(toNode, relType)
+	return rel
+}
+
 // RelateBidirectionally creates a bidirectional relationship between two nodes
 func RelateBidirectionally(fromNode core.NodeExpression, toNode core.NodeExpression, relType string) core.PatternElement {
 	rel := fromNode.RelationshipBetween(toNode, relType)
@@ -92,48 +141,62 @@ func RelateBidirectionally(fromNode core.NodeExpression, toNode core.NodeExpress
 // This is useful for creating more complex patterns without nesting multiple calls
 // Example: ComplexPath(user, "WORKS_FOR", company, "LOCATED_IN", city)
 // Creates pattern: (user)-[:WORKS_FOR]->(company)-[:LOCATED_IN]->(city)
+//
+// ComplexPath panics on invalid arguments and is meant for quick scripts and
+// tests; use ComplexPathE when the elements come from input.
 func ComplexPath(startNode core.NodeExpression, elementsInPath ...interface{}) core.Expression {
+	path, err := ComplexPathE(startNode, elementsInPath...)
+	if err != nil {
+		panic(err)
+	}
+	return path
+}
+
+// ComplexPathE is like ComplexPath but returns an error wrapping
+// core.ErrInvalidPattern instead of panicking when the elements do not
+// alternate between relationship types (string) and nodes
+func ComplexPathE(startNode core.NodeExpression, elementsInPath ...interface{}) (core.Expression, error) {
+	if startNode == nil {
+		return nil, core.NewError(core.ErrNodeRequired, "ComplexPath requires a start node")
+	}
 	if len(elementsInPath) < 2 || len(elementsInPath)%2 != 0 {
-		panic("ComplexPath requires at least one relationship type and node pair, and must have an even number of elements")
+		return nil, core.NewError(core.ErrInvalidPattern,
+			"ComplexPath requires at least one relationship type and node pair, and must have an even number of elements")
 	}
 
-	var elements []core.PatternElement
+	elements := []core.PatternElement{startNode}
 	currentNode := startNode
 
-	// Add the start node
-	elements = append(elements, currentNode)
-
 	// Process the path pairs: [relType, node, relType, node, ...]
 	for i := 0; i < len(elementsInPath); i += 2 {
 		relType, ok := elementsInPath[i].(string)
 		if !ok {
-			panic("Relationship type must be a string")
+			return nil, core.NewError(core.ErrInvalidPattern,
+				fmt.Sprintf("element %d: relationship type must be a string, got %T", i, elementsInPath[i]))
 		}
 
 		nextNode, ok := elementsInPath[i+1].(core.NodeExpression)
-		if !ok {
-			panic("Path elements must alternate between relationship types (string) and nodes")
+		if !ok || nextNode == nil {
+			return nil, core.NewError(core.ErrInvalidPattern,
+				fmt.Sprintf("element %d: path elements must alternate between relationship types (string) and nodes, got %T", i+1, elementsInPath[i+1]))
 		}
 
-		// Create the relationship and add it to elements
-		rel := currentNode.RelationshipTo(nextNode, relType)
-		elements = append(elements, rel)
-
-		// Update current node for next iteration
+		// Add the relationship followed by the node it leads to
+		elements = append(elements, currentNode.RelationshipTo(nextNode, relType), nextNode)
 		currentNode = nextNode
 	}
 
-	return ast.Path(elements...)
+	return ast.Path(elements...), nil
 }
 
-// Match creates a MATCH clause
-func Match(pattern core.Expression) builder.MatchBuilder {
-	return builder.Match(pattern)
+// Match creates a MATCH clause; multiple patterns render as MATCH (a), (b)
+func Match(patterns ...core.Expression) builder.MatchBuilder {
+	return builder.Match(patterns...)
 }
 
 // OptionalMatch creates an OPTIONAL MATCH clause
-func OptionalMatch(pattern core.Expression) builder.MatchBuilder {
-	return builder.OptionalMatch(pattern)
+func OptionalMatch(patterns ...core.Expression) builder.MatchBuilder {
+	return builder.OptionalMatch(patterns...)
 }
 
 // Create creates a CREATE clause
@@ -141,6 +204,34 @@ func Create(pattern core.Expression) builder.CreateBuilder {
 	return builder.Create(pattern)
 }
 
+// CreateFromStruct creates a CREATE clause for a node with the given label
+// whose properties are the fields of v, each bound to a parameter:
+// CREATE (:Person {age: $p0, name: $p1}). See PropsFromStruct for how
+// fields are named; use it directly to create a named node.
+func CreateFromStruct(label string, v any) (builder.CreateBuilder, error) {
+	props, err := PropsFromStruct(v)
+	if err != nil {
+		return nil, err
+	}
+	return builder.Create(ast.Node(label).WithProperties(props)), nil
+}
+
+// PropsFromStruct returns the exported fields of a struct as properties, each
+// bound to an unnamed parameter, so the properties of two structs in one
+// statement don't share parameters. A `cypher:"name"` tag renames a field,
+// `cypher:"-"` skips it and `cypher:"name,omitempty"` skips its zero value.
+func PropsFromStruct(v any) (map[string]core.Expression, error) {
+	values, err := core.StructProperties(v)
+	if err != nil {
+		return nil, err
+	}
+	props := make(map[string]core.Expression, len(values))
+	for name, value := range values {
+		props[name] = Param(value)
+	}
+	return props, nil
+}
+
 // Merge creates a MERGE clause
 func Merge(pattern core.Expression) builder.MergeBuilder {
 	return builder.Merge(pattern)
@@ -191,6 +282,24 @@ func Set(expression core.Expression) builder.SetBuilder {
 	return builder.Set(expression)
 }
 
+// SetProperty creates a SET item that assigns a value to a property, e.g.
+// CREATE (n:Person) SET n.name = $p0
+func SetProperty(property core.Expression, value any) core.Expression {
+	return expr.SetProperty(property, value)
+}
+
+// SetMutate creates a SET item that merges a map into a node's or
+// relationship's properties, e.g. MERGE ... ON CREATE SET n += $props
+func SetMutate(target core.Expression, value core.Expression) core.Expression {
+	return expr.SetMutate(target, value)
+}
+
+// SetLabels creates a SET item that adds labels to a node, e.g.
+// MERGE ... ON MATCH SET n:Seen
+func SetLabels(target core.Expression, labels ...string) core.Expression {
+	return expr.SetLabels(target, labels...)
+}
+
 // Remove creates a REMOVE clause
 func Remove(expression core.Expression) builder.RemoveBuilder {
 	return builder.Remove(expression)
@@ -201,33 +310,51 @@ func Unwind(expression core.Expression, alias string) builder.UnwindBuilder {
 	return builder.Unwind(expression, alias)
 }
 
+// Statements collects built statements, e.g. to render them as one
+// cypher-shell script with Script or to run them with ExecuteBatchWrite
+func Statements(statements ...core.Statement) *builder.StatementsBuilder {
+	return builder.Statements(statements...)
+}
+
+// UnwindParam creates an UNWIND clause over a list bound as a parameter named
+// after the alias: UnwindParam(rows, "row") renders UNWIND $rows AS row
+func UnwindParam(value any, alias string) builder.UnwindBuilder {
+	return builder.UnwindParam(value, alias)
+}
+
 // Eq creates an equality expression
-func Eq(left, right core.Expression) core.Expression {
+func Eq(left, right core.Expression) core.AliasableExpression {
 	return expr.Equals(left, right)
 }
 
+// NullSafeEq creates an equality under which null equals null, unlike Eq
+// where comparing with null yields null
+func NullSafeEq(left, right core.Expression) core.AliasableExpression {
+	return expr.NullSafeEquals(left, right)
+}
+
 // Ne creates a not-equals expression
-func Ne(left, right core.Expression) core.Expression {
+func Ne(left, right core.Expression) core.AliasableExpression {
 	return expr.NotEquals(left, right)
 }
 
 // Gt creates a greater-than expression
-func Gt(left, right core.Expression) core.Expression {
+func Gt(left, right core.Expression) core.AliasableExpression {
 	return expr.GreaterThan(left, right)
 }
 
 // Gte creates a greater-than-or-equal expression
-func Gte(left, right core.Expression) core.Expression {
+func Gte(left, right core.Expression) core.AliasableExpression {
 	return expr.GreaterThanEqual(left, right)
 }
 
 // Lt creates a less-than expression
-func Lt(left, right core.Expression) core.Expression {
+func Lt(left, right core.Expression) core.AliasableExpression {
 	return expr.LessThan(left, right)
 }
 
 // Lte creates a less-than-or-equal expression
-func Lte(left, right core.Expression) core.Expression {
+func Lte(left, right core.Expression) core.AliasableExpression {
 	return expr.LessThanEqual(left, right)
 }
 
@@ -241,11 +368,35 @@ func Or(left, right core.Expression) core.Expression {
 	return expr.Or(left, right)
 }
 
+// AndAll combines conditions with AND, e.g. to build a WHERE clause from
+// optional filters; nil conditions are skipped and no conditions yield true
+func AndAll(conditions ...core.Expression) core.Expression {
+	return expr.AndAll(conditions...)
+}
+
+// OrAll combines conditions with OR; nil conditions are skipped and no
+// conditions yield true
+func OrAll(conditions ...core.Expression) core.Expression {
+	return expr.OrAll(conditions...)
+}
+
 // Xor creates a logical XOR expression
 func Xor(left, right core.Expression) core.Expression {
 	return expr.Xor(left, right)
 }
 
+// HasLabel creates a label predicate such as n:Person, or n:Person:Actor when
+// several labels are given; negate it with Not for WHERE NOT n:Archived
+func HasLabel(node core.Expression, labels ...string) core.Expression {
+	return expr.HasLabel(node, labels...)
+}
+
+// Group encloses an expression in parentheses so that it is evaluated as a
+// unit, e.g. Group(RawCypher("a OR b")).And(c) renders ((a OR b) AND c)
+func Group(expression core.Expression) core.Expression {
+	return expr.Group(expression)
+}
+
 // Not creates a logical NOT expression
 func Not(expression core.Expression) core.Expression {
 	return expr.Not(expression)
@@ -350,18 +501,211 @@ func Render(statement core.Statement) string {
 	return r.Render(statement)
 }
 
-// RenderWithParams renders a statement and returns the Cypher and parameters
+// RenderWithParams renders a statement and returns the Cypher and parameters,
+// normalized for the driver unless a value cannot be normalized; see
+// RenderWithParamsE
 func RenderWithParams(statement core.Statement) (string, map[string]any) {
 	r := renderer.NewCypherRenderer()
 	return r.RenderWithParams(statement)
 }
 
+// RenderWithParamsE renders a statement and returns the Cypher and the
+// normalized parameters, or an error wrapping core.ErrInvalidParameter when a
+// parameter value cannot be sent to Neo4j
+func RenderWithParamsE(statement core.Statement) (string, map[string]any, error) {
+	r := renderer.NewCypherRenderer()
+	return r.RenderWithParamsE(statement)
+}
+
 // PrettyPrint renders a statement with pretty printing
 func PrettyPrint(statement core.Statement) string {
 	r := renderer.NewCypherRenderer().WithPrettyPrint(true)
 	return r.Render(statement)
 }
 
+// PrettyPrintWith formats a statement with the given options, e.g. to choose
+// lowercase keywords or another indentation; start from
+// renderer.DefaultFormattingOptions to change only some of them
+func PrettyPrintWith(statement core.Statement, options renderer.FormattingOptions) string {
+	if statement == nil {
+		return ""
+	}
+	return renderer.NewCypherFormatter(options).Format(statement.Cypher())
+}
+
+// BuildWithValidation builds a statement and checks the rendered Cypher
+// against the rules of the given validation level. All rule violations are
+// returned together as a single error.
+func BuildWithValidation(builder core.Buildable, level validation.ValidationLevel) (core.Statement, error) {
+	statement, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+	if err := validation.NewValidator(level).ValidateStatement(statement); err != nil {
+		return nil, err
+	}
+	return statement, nil
+}
+
+// BuildWithWarnings is like BuildWithValidation but also returns the warnings
+// of the validation level, such as MATCH patterns that build a cartesian
+// product. Warnings never make the build fail.
+func BuildWithWarnings(builder core.Buildable, level validation.ValidationLevel) (core.Statement, []*validation.Warning, error) {
+	statement, err := BuildWithValidation(builder, level)
+	if err != nil {
+		return nil, nil, err
+	}
+	return statement, validation.NewValidator(level).StatementWarnings(statement), nil
+}
+
+// WithComment returns a copy of the statement whose Cypher starts with a
+// /* text */ comment, e.g. to record which service built the query.
+// The parameters are unchanged. Any "*/" in text is broken up so that the
+// comment cannot be terminated early. The comment is kept as a leading raw
+// clause, so it survives Transform and WithTenant and is part of the
+// statement's Fingerprint.
+func WithComment(statement core.Statement, text string) core.Statement {
+	if statement == nil {
+		return nil
+	}
+	comment := "/* " + strings.ReplaceAll(text, "*/", "* /") + " */"
+	var clauses []*core.Clause
+	if len(statement.Clauses()) > 0 {
+		clauses = append([]*core.Clause{{Expressions: []core.Expression{expr.RawCypher(comment)}}}, statement.Clauses()...)
+	}
+	return core.NewStatement(comment+" "+statement.Cypher(), statement.Params()).WithClauses(clauses)
+}
+
+// InTransactions wraps a statement in a CALL subquery that the server commits
+// in batches, CALL { inner } IN TRANSACTIONS OF batchSize ROWS; a batchSize
+// of zero or less leaves the batch size to the server. The parameters of the
+// inner statement are kept. The inner statement imports the rows of the
+// outer query with WITH, so that after LOAD CSV it reads:
+//
+//	load := core.NewStatement("LOAD CSV WITH HEADERS FROM $url AS row", params)
+//	inner, _ := With(Var("row")).Create(node).Build()
+//	stmt := JoinStatements(load, InTransactions(inner, 1000))
+func InTransactions(inner core.Statement, batchSize int) core.Statement {
+	if inner == nil {
+		return nil
+	}
+	body := "{ " + inner.Cypher() + " } IN TRANSACTIONS"
+	if batchSize > 0 {
+		body += fmt.Sprintf(" OF %d ROWS", batchSize)
+	}
+	clause := &core.Clause{Keyword: "CALL", Expressions: []core.Expression{expr.RawCypherWithParams(body, inner.Params())}}
+	return core.NewStatement("CALL "+body, inner.Params()).WithClauses([]*core.Clause{clause})
+}
+
+// JoinStatements joins statements into one, e.g. to run a subquery after a
+// clause the builders cannot express. Parameters are merged, the value of a
+// later statement winning. The result can be walked and transformed only if
+// every statement could.
+func JoinStatements(statements ...core.Statement) core.Statement {
+	var queries []string
+	var clauses []*core.Clause
+	params := make(map[string]any)
+	keepClauses := true
+	for _, statement := range statements {
+		if statement == nil {
+			continue
+		}
+		queries = append(queries, statement.Cypher())
+		for name, value := range statement.Params() {
+			params[name] = value
+		}
+		keepClauses = keepClauses && len(statement.Clauses()) > 0
+		clauses = append(clauses, statement.Clauses()...)
+	}
+	if !keepClauses {
+		clauses = nil
+	}
+	return core.NewStatement(strings.Join(queries, " "), params).WithClauses(clauses)
+}
+
+// VectorSimilarity queries a vector index for the k nodes whose embeddings are
+// most similar to the given one, yielding each node and its score:
+// CALL db.index.vector.queryNodes('index', k, $embedding) YIELD node, score.
+// An embedding that is not an expression is bound to the parameter
+// $embedding. Follow it with further clauses using JoinStatements.
+func VectorSimilarity(indexName string, k int, embedding any) core.Statement {
+	embeddingExpression, ok := embedding.(core.Expression)
+	if !ok {
+		embeddingExpression = NamedParam("embedding", embedding)
+	}
+	// VectorSimilarity cannot fail, so a name bound to two values in the
+	// embedding keeps the later one rather than being reported
+	params := util.NewParameters(nil)
+	_ = params.Add(embeddingExpression)
+
+	call := expr.Function("db.index.vector.queryNodes", expr.String(indexName), expr.Integer(int64(k)), embeddingExpression)
+	clauses := []*core.Clause{
+		{Keyword: "CALL", Expressions: []core.Expression{call}},
+		{Keyword: "YIELD", Expressions: []core.Expression{Var("node"), Var("score")}},
+	}
+	return core.NewStatement(params.Render("CALL "+call.String()+" YIELD node, score"), params.Values()).WithClauses(clauses)
+}
+
+// WithTenant returns a copy of the statement in which every node pattern
+// that introduces a node carries the tenant property, e.g.
+// (p:Person {tenantId: $p0}), so that each MATCH only sees the nodes of one
+// tenant and each CREATE or MERGE writes to it. Later references to a bound
+// variable, as in MATCH (p:Person) CREATE (p)-[:KNOWS]->(q:Person), are left
+// as they are; only q gets the property there. The value is passed as a
+// single added parameter.
+//
+// WithTenant panics when the statement cannot be rewritten; use WithTenantE
+// to get the error instead.
+func WithTenant(statement core.Statement, property string, value any) core.Statement {
+	tenantStatement, err := WithTenantE(statement, property, value)
+	if err != nil {
+		panic(err)
+	}
+	return tenantStatement
+}
+
+// WithTenantE is like WithTenant but returns an error when the statement was
+// not built from clauses or a node pattern takes its properties from a map
+// parameter, which cannot be combined with the tenant property
+func WithTenantE(statement core.Statement, property string, value any) (core.Statement, error) {
+	if statement == nil {
+		return nil, core.NewError(core.ErrInvalidQuery, "WithTenant requires a statement")
+	}
+	if property == "" {
+		return nil, core.NewError(core.ErrInvalidProperty, "WithTenant requires a property name")
+	}
+
+	tenant := Param(value)
+	bound := make(map[string]bool)
+	var err error
+	tenantStatement, transformErr := statement.Transform(func(e core.Expression) core.Expression {
+		node, ok := e.(core.NodeExpression)
+		if !ok {
+			return e
+		}
+		// Expressions are transformed in clause order, so the first pattern
+		// naming a variable is the one that introduces it
+		if alias := node.SymbolicName(); alias != "" {
+			if bound[alias] {
+				return node
+			}
+			bound[alias] = true
+		}
+		if withParam, ok := node.(interface{ PropsParam() core.Expression }); ok && withParam.PropsParam() != nil && err == nil {
+			err = core.NewError(core.ErrInvalidPattern,
+				fmt.Sprintf("cannot add the tenant property to %s, whose properties are a map parameter", node))
+		}
+		return node.WithProperties(map[string]core.Expression{property: tenant})
+	})
+	if transformErr != nil {
+		return nil, transformErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return tenantStatement, nil
+}
+
 // Literal utility functions
 
 // String creates a string literal
@@ -400,57 +744,81 @@ func Map(entries map[string]core.Expression) core.Expression {
 }
 
 // Equals creates an equality comparison expression
-func Equals(left, right core.Expression) core.Expression {
+func Equals(left, right core.Expression) core.AliasableExpression {
 	return expr.Equals(left, right)
 }
 
 // NotEquals creates a not-equals comparison expression
-func NotEquals(left, right core.Expression) core.Expression {
+func NotEquals(left, right core.Expression) core.AliasableExpression {
 	return expr.NotEquals(left, right)
 }
 
 // GreaterThan creates a greater-than comparison expression
-func GreaterThan(left, right core.Expression) core.Expression {
+func GreaterThan(left, right core.Expression) core.AliasableExpression {
 	return expr.GreaterThan(left, right)
 }
 
 // LessThan creates a less-than comparison expression
-func LessThan(left, right core.Expression) core.Expression {
+func LessThan(left, right core.Expression) core.AliasableExpression {
 	return expr.LessThan(left, right)
 }
 
 // GreaterThanEqual creates a greater-than-or-equal comparison expression
-func GreaterThanEqual(left, right core.Expression) core.Expression {
+func GreaterThanEqual(left, right core.Expression) core.AliasableExpression {
 	return expr.GreaterThanEqual(left, right)
 }
 
 // LessThanEqual creates a less-than-or-equal comparison expression
-func LessThanEqual(left, right core.Expression) core.Expression {
+func LessThanEqual(left, right core.Expression) core.AliasableExpression {
 	return expr.LessThanEqual(left, right)
 }
 
-// In creates an IN comparison with a list of values
-func In(left core.Expression, values ...any) core.Expression {
+// IsType creates a Neo4j 5 type predicate such as n.age IS :: INTEGER NOT NULL.
+// The type is rendered verbatim; an unknown type keyword fails the build.
+func IsType(expression core.Expression, typeName string) core.Expression {
+	return expr.IsType(expression, typeName)
+}
+
+// In creates an IN comparison with a list of values inlined as literals.
+// Each distinct list yields a different query text; prefer InParam when the
+// values change between executions so Neo4j can reuse the cached plan.
+func In(left core.Expression, values ...any) core.AliasableExpression {
 	return expr.In(left, values...)
 }
 
+// InParam creates an IN comparison that binds the whole list as one parameter,
+// rendering left IN $p0 with the parameter numbered when the statement is built
+func InParam(left core.Expression, value any) core.AliasableExpression {
+	return expr.InParam(left, value)
+}
+
+// Same creates an identity comparison between two named nodes or relationships (a = b)
+func Same(a, b core.Expression) core.AliasableExpression {
+	return expr.Same(a, b)
+}
+
+// NotSame creates a negated identity comparison between two named nodes or relationships (a <> b)
+func NotSame(a, b core.Expression) core.AliasableExpression {
+	return expr.NotSame(a, b)
+}
+
 // StartsWith creates a STARTS WITH comparison
-func StartsWith(left core.Expression, value string) core.Expression {
+func StartsWith(left core.Expression, value string) core.AliasableExpression {
 	return expr.StartsWith(left, value)
 }
 
 // EndsWith creates an ENDS WITH comparison
-func EndsWith(left core.Expression, value string) core.Expression {
+func EndsWith(left core.Expression, value string) core.AliasableExpression {
 	return expr.EndsWith(left, value)
 }
 
 // Contains creates a CONTAINS comparison
-func Contains(left core.Expression, value string) core.Expression {
+func Contains(left core.Expression, value string) core.AliasableExpression {
 	return expr.Contains(left, value)
 }
 
 // RegularExpression creates a regular expression comparison
-func RegularExpression(left core.Expression, pattern string) core.Expression {
+func RegularExpression(left core.Expression, pattern string) core.AliasableExpression {
 	return expr.RegularExpression(left, pattern)
 }
 
@@ -469,44 +837,81 @@ func Asc(expression core.Expression) core.Expression {
 	return expr.Asc(expression)
 }
 
+// GroupBy starts a RETURN or WITH projection with explicit grouping keys:
+// GroupBy(a.country).Aggregate(CountStar().As("people")) renders
+// a.country, count(*) AS people. Cypher groups aggregates by every
+// non-aggregated item implicitly; GroupBy makes that visible and fails the
+// build when a key is an aggregate or an aggregate is not.
+func GroupBy(keys ...core.Expression) *expr.Grouping {
+	return expr.GroupBy(keys...)
+}
+
 // Function creates a function call expression
-func Function(name string, args ...core.Expression) core.Expression {
+func Function(name string, args ...core.Expression) core.AliasableExpression {
 	return expr.Function(name, args...)
 }
 
 // Count creates a COUNT function expression
-func Count(expression core.Expression) core.Expression {
-	return expr.Count(expression)
+func Count(expression core.Expression) *expr.FunctionExpression {
+	return expr.Count(expression).(*expr.FunctionExpression)
 }
 
 // CountStar creates a COUNT(*) function expression
-func CountStar() core.Expression {
-	return expr.CountStar()
+func CountStar() *expr.FunctionExpression {
+	return expr.CountStar().(*expr.FunctionExpression)
 }
 
 // Sum creates a SUM function expression
-func Sum(expression core.Expression) core.Expression {
-	return expr.Sum(expression)
+func Sum(expression core.Expression) *expr.FunctionExpression {
+	return expr.Sum(expression).(*expr.FunctionExpression)
 }
 
 // Avg creates an AVG function expression
-func Avg(expression core.Expression) core.Expression {
-	return expr.Avg(expression)
+func Avg(expression core.Expression) *expr.FunctionExpression {
+	return expr.Avg(expression).(*expr.FunctionExpression)
 }
 
 // Min creates a MIN function expression
-func Min(expression core.Expression) core.Expression {
-	return expr.Min(expression)
+func Min(expression core.Expression) *expr.FunctionExpression {
+	return expr.Min(expression).(*expr.FunctionExpression)
 }
 
 // Max creates a MAX function expression
-func Max(expression core.Expression) core.Expression {
-	return expr.Max(expression)
+func Max(expression core.Expression) *expr.FunctionExpression {
+	return expr.Max(expression).(*expr.FunctionExpression)
 }
 
 // Collect creates a COLLECT function expression
-func Collect(expression core.Expression) core.Expression {
-	return expr.Collect(expression)
+func Collect(expression core.Expression) *expr.FunctionExpression {
+	return expr.Collect(expression).(*expr.FunctionExpression)
+}
+
+// StDev creates a stDev function expression (standard deviation of a sample)
+func StDev(expression core.Expression) *expr.FunctionExpression {
+	return expr.StDev(expression).(*expr.FunctionExpression)
+}
+
+// StDevP creates a stDevP function expression (standard deviation of a population)
+func StDevP(expression core.Expression) *expr.FunctionExpression {
+	return expr.StDevP(expression).(*expr.FunctionExpression)
+}
+
+// PercentileCont creates a percentileCont function expression; the percentile
+// must be a number between 0 and 1, e.g. Float(0.95), or a parameter
+func PercentileCont(expression, percentile core.Expression) *expr.FunctionExpression {
+	return expr.PercentileCont(expression, percentile).(*expr.FunctionExpression)
+}
+
+// PercentileDisc creates a percentileDisc function expression; the percentile
+// must be a number between 0 and 1 or a parameter
+func PercentileDisc(expression, percentile core.Expression) *expr.FunctionExpression {
+	return expr.PercentileDisc(expression, percentile).(*expr.FunctionExpression)
+}
+
+// Length creates a length function expression for a path; a named path is
+// referred to by its name, e.g. RETURN p, length(p)
+func Length(path core.Expression) *expr.FunctionExpression {
+	return expr.Length(path).(*expr.FunctionExpression)
 }
 
 // Distinct wraps an expression with DISTINCT keyword
@@ -524,45 +929,69 @@ func Concat(expressions ...core.Expression) core.Expression {
 
 // Substring creates a SUBSTRING function expression
 // substring(expression, start [, length])
-func Substring(expression core.Expression, start core.Expression, length ...core.Expression) core.Expression {
+func Substring(expression core.Expression, start core.Expression, length ...core.Expression) core.AliasableExpression {
 	return expr.Substring(expression, start, length...)
 }
 
 // Replace creates a REPLACE function expression
-func Replace(expression, search, replace core.Expression) core.Expression {
+func Replace(expression, search, replace core.Expression) core.AliasableExpression {
 	return expr.Replace(expression, search, replace)
 }
 
 // Split creates a SPLIT function expression
-func Split(expression, delimiter core.Expression) core.Expression {
+func Split(expression, delimiter core.Expression) core.AliasableExpression {
 	return expr.Split(expression, delimiter)
 }
 
 // ToLower creates a toLower function expression
-func ToLower(expression core.Expression) core.Expression {
+func ToLower(expression core.Expression) core.AliasableExpression {
 	return expr.ToLower(expression)
 }
 
 // ToUpper creates a toUpper function expression
-func ToUpper(expression core.Expression) core.Expression {
+func ToUpper(expression core.Expression) core.AliasableExpression {
 	return expr.ToUpper(expression)
 }
 
 // Trim creates a TRIM function expression
-func Trim(expression core.Expression) core.Expression {
+func Trim(expression core.Expression) core.AliasableExpression {
 	return expr.Trim(expression)
 }
 
 // LTrim creates a lTrim function expression
-func LTrim(expression core.Expression) core.Expression {
+func LTrim(expression core.Expression) core.AliasableExpression {
 	return expr.LTrim(expression)
 }
 
 // RTrim creates a rTrim function expression
-func RTrim(expression core.Expression) core.Expression {
+func RTrim(expression core.Expression) core.AliasableExpression {
 	return expr.RTrim(expression)
 }
 
+// Point creates a point() function expression from a map of coordinates,
+// e.g. Point(Map(map[string]core.Expression{"latitude": ..., "longitude": ...}))
+func Point(coordinates core.Expression) core.AliasableExpression {
+	return expr.Point(coordinates)
+}
+
+// Distance creates a point.distance() function expression, e.g. for
+// WHERE point.distance(n.location, $here) < $radius
+func Distance(a, b core.Expression) core.AliasableExpression {
+	return expr.Distance(a, b)
+}
+
+// Timestamp creates a timestamp() function expression, e.g. for
+// ON CREATE SET n.created = timestamp()
+func Timestamp() core.AliasableExpression {
+	return expr.Timestamp()
+}
+
+// DurationBetween creates a duration.between() function expression, e.g.
+// duration.between(p.born, date()) to compute an age
+func DurationBetween(a, b core.Expression) core.AliasableExpression {
+	return expr.DurationBetween(a, b)
+}
+
 // RawCypher creates a raw Cypher expression that will be inserted as-is into the query
 // WARNING: Use with caution to avoid Cypher injection vulnerabilities.
 // Only use this when the DSL doesn't support a specific Cypher feature.
@@ -570,6 +999,12 @@ func RawCypher(cypher string) core.Expression {
 	return expr.RawCypher(cypher)
 }
 
+// RawCypherWithParams creates a raw Cypher expression that binds the values it
+// references as parameters, so that only the fragment, not the data, is inlined
+func RawCypherWithParams(fragment string, params map[string]any) core.Expression {
+	return expr.RawCypherWithParams(fragment, params)
+}
+
 // Utility functions
 
 // convertProperties converts a map of Go values to a map of Expression values
@@ -584,8 +1019,9 @@ func convertProperties(properties map[string]any) map[string]core.Expression {
 // Variables
 // ================================================================
 
-// Var creates a variable reference
-func Var(name string) core.Expression {
+// Var creates a variable reference, e.g. for an UNWIND or WITH alias;
+// its properties are accessed with Var("row").Property("name")
+func Var(name string) *expr.VariableExpression {
 	return expr.NewVariableExpression(name)
 }
 
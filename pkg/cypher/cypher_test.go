@@ -1,8 +1,15 @@
 package cypher
 
 import (
+	"errors"
+	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/expr"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/renderer"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/validation"
 )
 
 func TestComplexPath(t *testing.T) {
@@ -120,3 +127,650 @@ func TestPrettyPrint(t *testing.T) {
 	}
 }
 
+func TestPrettyPrintWith(t *testing.T) {
+	person := Node("Person").Named("p")
+	stmt, err := Match(person).
+		Where(person.Property("age").Gt(30)).
+		Returning(Var("p")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	options := renderer.DefaultFormattingOptions()
+	options.KeywordCase = renderer.KeywordCaseLower
+	options.IndentString = "    "
+
+	formatted := PrettyPrintWith(stmt, options)
+	want := "match (p:Person)\n    where (p.age > 30)\n    return p"
+	if formatted != want {
+		t.Errorf("PrettyPrintWith() = %q, want %q", formatted, want)
+	}
+	if PrettyPrintWith(nil, options) != "" {
+		t.Errorf("PrettyPrintWith(nil) should be empty")
+	}
+
+	// Keyword-like map keys and comments keep their text
+	item := Node("Item").Named("n").WithProps(map[string]any{"desc": "x", "end": 2, "order": 1})
+	stmt, err = Create(item).Returning(Var("n")).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	formatted = PrettyPrintWith(WithComment(stmt, "import where end and order"), renderer.DefaultFormattingOptions())
+	want = "/* import where end and order */ CREATE (n:Item {desc: 'x', end: 2, order: 1})\n  RETURN n"
+	if formatted != want {
+		t.Errorf("PrettyPrintWith() = %q, want %q", formatted, want)
+	}
+}
+
+func TestLiteralSupportsLogicalOperators(t *testing.T) {
+	lit := Literal(true)
+	if lit.And(Literal(false)) == nil {
+		t.Error("Literal().And() returned nil")
+	}
+	if lit.Not() == nil {
+		t.Error("Literal().Not() returned nil")
+	}
+}
+
+func TestNodePropertyWithoutAssertion(t *testing.T) {
+	person := Node("Person").Named("p")
+	movie := Node("Movie").Named("m")
+	rel := person.RelationshipTo(movie, "ACTED_IN").Named("r")
+
+	tests := []struct {
+		name string
+		expr core.Expression
+		want string
+	}{
+		{"node Property", Node("Person").Named("p").Property("name"), "p.name"},
+		{"node Prop", person.Prop("name"), "p.name"},
+		{"relationship Prop", rel.Prop("roles"), "r.roles"},
+		{"named after labels", Node("Person").WithLabels("Actor").Named("a").Property("born").Gt(1960), "(a.born > 1960)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.expr.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildWithValidation(t *testing.T) {
+	movie := Node("Movie").Named("movie")
+
+	stmt, err := BuildWithValidation(Match(movie).Returning(movie.Property("title")), validation.ValidationLevelStrict)
+	if err != nil {
+		t.Fatalf("BuildWithValidation() error = %v", err)
+	}
+	if stmt.Cypher() != "MATCH (movie:Movie) RETURN movie.title" {
+		t.Errorf("Cypher() = %q", stmt.Cypher())
+	}
+
+	typo := Match(movie).Returning(Node("Movie").Named("m").Property("title"))
+	if _, err := BuildWithValidation(typo, validation.ValidationLevelStrict); !errors.Is(err, core.ErrInvalidQuery) {
+		t.Errorf("BuildWithValidation() error = %v, want an invalid query error", err)
+	}
+	if _, err := BuildWithValidation(typo, validation.ValidationLevelBasic); err != nil {
+		t.Errorf("BuildWithValidation() at basic level error = %v", err)
+	}
+}
+
+func TestBuildWithWarnings(t *testing.T) {
+	person := Node("Person").Named("p")
+	movie := Node("Movie").Named("m")
+
+	stmt, warnings, err := BuildWithWarnings(
+		Match(person).Match(movie).Returning(Var("p"), Var("m")),
+		validation.ValidationLevelStrict,
+	)
+	if err != nil {
+		t.Fatalf("BuildWithWarnings() error = %v", err)
+	}
+	if stmt.Cypher() != "MATCH (p:Person) MATCH (m:Movie) RETURN p, m" {
+		t.Errorf("Cypher() = %q", stmt.Cypher())
+	}
+	if len(warnings) != 1 || warnings[0].Rule != "cartesian-product" {
+		t.Errorf("BuildWithWarnings() warnings = %v, want a cartesian-product warning", warnings)
+	}
+
+	acted := person.RelationshipTo(movie, "ACTED_IN")
+	_, warnings, err = BuildWithWarnings(
+		Match(Pattern(person, acted, movie)).Returning(Var("p"), Var("m")),
+		validation.ValidationLevelStrict,
+	)
+	if err != nil || len(warnings) != 0 {
+		t.Errorf("BuildWithWarnings() = %v, %v, want no warnings", warnings, err)
+	}
+}
+
+func TestStatementWithParamsReusesBuiltQuery(t *testing.T) {
+	person := Node("Person").Named("p")
+	stmt, err := Match(person).
+		Where(person.Property("name").Eq(NamedParam("name", "Tom"))).
+		Returning(Var("p")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	rebound, err := stmt.WithParams(map[string]any{"name": "Meg"})
+	if err != nil {
+		t.Fatalf("WithParams() error = %v", err)
+	}
+	if rebound.Cypher() != stmt.Cypher() || rebound.Params()["name"] != "Meg" {
+		t.Errorf("WithParams() = %q %v, want the same query bound to Meg", rebound.Cypher(), rebound.Params())
+	}
+	if _, err := stmt.WithParams(map[string]any{"nme": "Meg"}); !errors.Is(err, core.ErrInvalidParameter) {
+		t.Errorf("WithParams() error = %v, want core.ErrInvalidParameter", err)
+	}
+}
+
+func TestWithComment(t *testing.T) {
+	person := Node("Person").Named("p")
+	stmt, err := Match(person).Where(person.Property("name").Eq(Param("John"))).Returning(person).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	commented := WithComment(stmt, "built by service X")
+	if !strings.HasPrefix(commented.Cypher(), "/* built by service X */ MATCH") {
+		t.Errorf("Cypher() = %q, should start with the comment", commented.Cypher())
+	}
+	if len(commented.Params()) != len(stmt.Params()) {
+		t.Errorf("Params() = %v, want %v", commented.Params(), stmt.Params())
+	}
+
+	injected := WithComment(stmt, "x */ MATCH (n) DETACH DELETE n //")
+	if strings.Count(injected.Cypher(), "*/") != 1 {
+		t.Errorf("Cypher() = %q, comment text should not close the comment", injected.Cypher())
+	}
+
+	if WithComment(nil, "x") != nil {
+		t.Errorf("WithComment(nil) should return nil")
+	}
+
+	if commented.Fingerprint() == stmt.Fingerprint() {
+		t.Error("Fingerprint() should differ between a commented and an uncommented statement")
+	}
+	if WithComment(stmt, "other").Fingerprint() == commented.Fingerprint() {
+		t.Error("Fingerprint() should differ between statements with different comments")
+	}
+
+	tenant := WithTenant(WithComment(stmt, "x"), "tenantId", "acme")
+	want := "/* x */ MATCH (p:Person {tenantId: $p1}) WHERE (p.name = $p0) RETURN p"
+	if tenant.Cypher() != want {
+		t.Errorf("WithTenant(WithComment()) = %q, want %q", tenant.Cypher(), want)
+	}
+}
+
+func TestPropertyOf(t *testing.T) {
+	row := Var("row")
+	person := Node("Person").Named("p")
+
+	stmt, err := Unwind(NamedParam("rows", []any{map[string]any{"id": 1, "name": "Tom"}}), "row").
+		Match(person).
+		Where(person.Property("id").Eq(PropertyOf(row, "id"))).
+		Returning(PropertyOf(row, "name").As("name")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	cypher := stmt.Cypher()
+	if !strings.Contains(cypher, "(p.id = row.id)") || !strings.Contains(cypher, "RETURN row.name AS name") {
+		t.Errorf("Cypher() = %q, should reference the UNWIND variable's properties", cypher)
+	}
+
+	nested := PropertyOf(PropertyOf(row, "address"), "city")
+	if got := nested.Eq("Berlin").String(); got != "(row.address.city = 'Berlin')" {
+		t.Errorf("nested PropertyOf = %q, want '(row.address.city = 'Berlin')'", got)
+	}
+}
+
+func TestVarProperty(t *testing.T) {
+	row := Var("row")
+	stmt, err := Unwind(NamedParam("rows", []any{}), "row").
+		Returning(row.Property("name"), row.Prop("age").As("age")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if !strings.Contains(stmt.Cypher(), "RETURN row.name, row.age AS age") {
+		t.Errorf("Cypher() = %q, should contain 'RETURN row.name, row.age AS age'", stmt.Cypher())
+	}
+}
+
+func TestNullAndBooleanLiteralsAreConsistent(t *testing.T) {
+	var nilPointer *string
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"Null()", Null().String(), "NULL"},
+		{"Literal(nil)", Literal(nil).String(), "NULL"},
+		{"core literal nil", core.NewLiteral(nil).String(), "NULL"},
+		{"core literal nil pointer", core.NewLiteral(nilPointer).String(), "NULL"},
+		{"expr literal nil", (&expr.Literal{Value: nil}).String(), "NULL"},
+		{"renderer nil", renderer.NewRenderVisitor().Visit(nil).(string), "NULL"},
+		{"list with nil", Literal([]any{nil, true}).String(), "[NULL, true]"},
+		{"Boolean(true)", Boolean(true).String(), "true"},
+		{"Literal(false)", Literal(false).String(), "false"},
+		{"core literal true", core.NewLiteral(true).String(), "true"},
+		{"expr literal false", (&expr.Literal{Value: false}).String(), "false"},
+		{"expr literal float", (&expr.Literal{Value: 1.5e18}).String(), Float(1.5e18).String()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("String() = %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComplexPathE(t *testing.T) {
+	user := Node("User").Named("u")
+	company := Node("Company").Named("c")
+	city := Node("City").Named("city")
+
+	path, err := ComplexPathE(user, "WORKS_AT", company, "LOCATED_IN", city)
+	if err != nil {
+		t.Fatalf("ComplexPathE() error = %v", err)
+	}
+	want := "(u:User)-[:`WORKS_AT`]->(c:Company)-[:`LOCATED_IN`]->(city:City)"
+	if path.String() != want {
+		t.Errorf("ComplexPathE() = %q, want %q", path.String(), want)
+	}
+
+	invalid := map[string][]any{
+		"odd count":       {"WORKS_AT"},
+		"no elements":     nil,
+		"type not string": {42, company},
+		"node not node":   {"WORKS_AT", "Company"},
+	}
+	for name, elements := range invalid {
+		if _, err := ComplexPathE(user, elements...); !errors.Is(err, core.ErrInvalidPattern) {
+			t.Errorf("%s: ComplexPathE() error = %v, want core.ErrInvalidPattern", name, err)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("ComplexPath() should panic on invalid elements")
+		}
+	}()
+	ComplexPath(user, "WORKS_AT")
+}
+
+func TestCreateFromStruct(t *testing.T) {
+	type movie struct {
+		Title    string   `cypher:"title"`
+		Released int      `cypher:"released,omitempty"`
+		Tags     []string `cypher:"tags,omitempty"`
+		Draft    bool     `cypher:"-"`
+	}
+
+	create, err := CreateFromStruct("Movie", movie{Title: "Heat", Tags: []string{"crime"}, Draft: true})
+	if err != nil {
+		t.Fatalf("CreateFromStruct() error = %v", err)
+	}
+	stmt, err := create.Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	want := "CREATE (:Movie {tags: $p0, title: $p1})"
+	if stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+	wantParams := map[string]any{"p0": []string{"crime"}, "p1": "Heat"}
+	if !reflect.DeepEqual(stmt.Params(), wantParams) {
+		t.Errorf("Params() = %v, want %v", stmt.Params(), wantParams)
+	}
+
+	props, err := PropsFromStruct(&movie{Title: "Heat", Released: 1995})
+	if err != nil {
+		t.Fatalf("PropsFromStruct() error = %v", err)
+	}
+	m := Node("Movie").Named("m").WithProperties(props)
+	stmt, err = Create(m).Returning(Var("m")).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	want = "CREATE (m:Movie {released: $p0, title: $p1}) RETURN m"
+	if stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+
+	type person struct {
+		FirstName string `cypher:"first-name"`
+	}
+	alice, err := PropsFromStruct(person{FirstName: "Alice"})
+	if err != nil {
+		t.Fatalf("PropsFromStruct() error = %v", err)
+	}
+	bob, err := PropsFromStruct(person{FirstName: "Bob"})
+	if err != nil {
+		t.Fatalf("PropsFromStruct() error = %v", err)
+	}
+	stmt, err = Create(Node("Person").Named("a").WithProperties(alice)).
+		Create(Node("Person").Named("b").WithProperties(bob)).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	want = "CREATE (a:Person {`first-name`: $p0}) CREATE (b:Person {`first-name`: $p1})"
+	if stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+	if wantParams := map[string]any{"p0": "Alice", "p1": "Bob"}; !reflect.DeepEqual(stmt.Params(), wantParams) {
+		t.Errorf("Params() = %v, want %v", stmt.Params(), wantParams)
+	}
+
+	if _, err := CreateFromStruct("Movie", "Heat"); !errors.Is(err, core.ErrInvalidParameter) {
+		t.Errorf("CreateFromStruct(string) error = %v, want core.ErrInvalidParameter", err)
+	}
+}
+
+func TestStatementWalk(t *testing.T) {
+	p := Node("Person").Named("p")
+	stmt, err := Match(p).
+		Where(p.Property("age").Gt(NamedParam("age", 30)).And(p.Property("name").StartsWith("A"))).
+		Returning(p.Property("name")).
+		OrderBy(p.Property("name")).
+		Limit(10).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	var keywords, properties []string
+	var params []string
+	stmt.Walk(func(node any) bool {
+		switch n := node.(type) {
+		case *core.Clause:
+			keywords = append(keywords, n.Keyword)
+		case *expr.PropertyExpression:
+			properties = append(properties, n.String())
+			return false
+		case core.Expression:
+			if strings.HasPrefix(n.String(), "$") {
+				params = append(params, n.String())
+			}
+		}
+		return true
+	})
+
+	if want := []string{"MATCH", "WHERE", "RETURN", "ORDER BY", "LIMIT"}; !reflect.DeepEqual(keywords, want) {
+		t.Errorf("clauses = %v, want %v", keywords, want)
+	}
+	if want := []string{"p.age", "p.name", "p.name", "p.name"}; !reflect.DeepEqual(properties, want) {
+		t.Errorf("properties = %v, want %v", properties, want)
+	}
+	if want := []string{"$age"}; !reflect.DeepEqual(params, want) {
+		t.Errorf("parameters = %v, want %v", params, want)
+	}
+
+	// Returning false for a clause skips its expressions
+	visited := 0
+	stmt.Walk(func(node any) bool {
+		visited++
+		_, isClause := node.(*core.Clause)
+		return !isClause
+	})
+	if visited != 5 {
+		t.Errorf("Walk() visited %d nodes, want only the 5 clauses", visited)
+	}
+
+	raw, err := Create(p).AppendRaw("RETURN p", nil).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	clauses := raw.Clauses()
+	if len(clauses) != 2 || clauses[0].Keyword != "CREATE" || clauses[1].Keyword != "" {
+		t.Errorf("Clauses() = %v, want CREATE followed by the raw Cypher", clauses)
+	}
+}
+
+func TestStatementTransformKeepsQuery(t *testing.T) {
+	p := Node("Person").Named("p")
+	c := CountStar().As("c")
+
+	builders := []core.Buildable{
+		Match(p).
+			Where(p.Property("age").Gt(NamedParam("age", 30)).And(Not(p.Property("name").IsNull()))).
+			Returning(p.Property("name"), c).Distinct().
+			OrderBy(Desc(c)).
+			Skip(5).
+			Limit(10),
+		Match(p).With(Var("p"), c).Where(Gt(Var("c"), Literal(1))).OrderBy(p.Property("name")).Desc().Returning(Var("p")),
+		Merge(Node("Person").Named("q").WithProps(map[string]any{"id": Param(7)})).
+			OnCreate(SetProperty(Var("q.created"), Function("timestamp"))),
+		Unwind(NamedParam("rows", []any{1, 2}), "row").Returning(Var("row")).AppendRaw("LIMIT $n", map[string]any{"n": 3}),
+	}
+	for _, b := range builders {
+		stmt, err := b.Build()
+		if err != nil {
+			t.Fatalf("Build() error = %v", err)
+		}
+		transformed, err := stmt.Transform(func(e core.Expression) core.Expression { return e })
+		if err != nil {
+			t.Fatalf("Transform() error = %v", err)
+		}
+		if transformed.Cypher() != stmt.Cypher() {
+			t.Errorf("Transform() = %q, want %q", transformed.Cypher(), stmt.Cypher())
+		}
+		if !reflect.DeepEqual(transformed.Params(), stmt.Params()) {
+			t.Errorf("Transform() params = %v, want %v", transformed.Params(), stmt.Params())
+		}
+	}
+}
+
+func TestStatementTransformRewritesExpressions(t *testing.T) {
+	p := Node("Person").Named("p")
+	stmt, err := Match(ComplexPath(p, "WORKS_AT", Node("Company").Named("c"))).
+		Where(p.Property("age").Gt(Literal(30)).And(p.Property("name").Eq(Literal("Ann")))).
+		Returning(Var("p")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	// Swap inlined literals for parameters and scope every node to a tenant
+	transformed, err := stmt.Transform(func(e core.Expression) core.Expression {
+		switch n := e.(type) {
+		case *expr.IntegerLiteral:
+			return Param(n.Value)
+		case *expr.StringLiteral:
+			return Param(n.Value)
+		case core.NodeExpression:
+			return n.WithLabels("Tenant42")
+		}
+		return e
+	})
+	if err != nil {
+		t.Fatalf("Transform() error = %v", err)
+	}
+
+	want := "MATCH (p:Person:Tenant42)-[:`WORKS_AT`]->(c:Company:Tenant42) WHERE ((p.age > $p0) AND (p.name = $p1)) RETURN p"
+	if transformed.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", transformed.Cypher(), want)
+	}
+	if want := map[string]any{"p0": int64(30), "p1": "Ann"}; !reflect.DeepEqual(transformed.Params(), want) {
+		t.Errorf("Params() = %v, want %v", transformed.Params(), want)
+	}
+	if !strings.Contains(stmt.Cypher(), "(p.age > 30)") {
+		t.Errorf("Transform() should leave the original statement unchanged, got %q", stmt.Cypher())
+	}
+
+	if _, err := core.NewStatement("MATCH (n) RETURN n", nil).Transform(func(e core.Expression) core.Expression { return e }); !errors.Is(err, core.ErrInvalidQuery) {
+		t.Errorf("Transform() of a statement without clauses error = %v, want core.ErrInvalidQuery", err)
+	}
+	if _, err := stmt.Transform(func(e core.Expression) core.Expression { return nil }); !errors.Is(err, core.ErrInvalidExpression) {
+		t.Errorf("Transform() returning nil error = %v, want core.ErrInvalidExpression", err)
+	}
+}
+
+func TestWithTenant(t *testing.T) {
+	p := Node("Person").Named("p")
+	c := Node("Company").Named("c")
+	stmt, err := Match(ComplexPath(p, "WORKS_AT", c)).
+		Where(p.Property("name").Eq(NamedParam("name", "Ann"))).
+		Merge(Node("Visit").Named("v").WithProps(map[string]any{"day": NamedParam("day", "mon")})).
+		Returning(Var("p"), Var("v")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	tenantStatement := WithTenant(stmt, "tenantId", "acme")
+	want := "MATCH (p:Person {tenantId: $p0})-[:`WORKS_AT`]->(c:Company {tenantId: $p0}) WHERE (p.name = $name) " +
+		"MERGE (v:Visit {day: $day, tenantId: $p0}) RETURN p, v"
+	if tenantStatement.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", tenantStatement.Cypher(), want)
+	}
+	wantParams := map[string]any{"name": "Ann", "day": "mon", "p0": "acme"}
+	if !reflect.DeepEqual(tenantStatement.Params(), wantParams) {
+		t.Errorf("Params() = %v, want %v", tenantStatement.Params(), wantParams)
+	}
+
+	// A bound variable is only constrained where it is introduced
+	reused := map[string]core.Buildable{
+		"MATCH (p:Person {tenantId: $p0}) CREATE (p:Person)-[:`KNOWS`]->(q:Person {tenantId: $p0})": Match(p).Create(ComplexPath(p, "KNOWS", Node("Person").Named("q"))),
+		"MATCH (p:Person {tenantId: $p0}) MERGE (p:Person)-[:`LIKES`]->(c:Company {tenantId: $p0})": Match(p).Merge(ComplexPath(p, "LIKES", c)),
+	}
+	for want, b := range reused {
+		stmt, err := b.Build()
+		if err != nil {
+			t.Fatalf("Build() error = %v", err)
+		}
+		if got := WithTenant(stmt, "tenantId", "acme").Cypher(); got != want {
+			t.Errorf("Cypher() = %q, want %q", got, want)
+		}
+	}
+
+	withPropsParam, err := Match(Node("Person").Named("n").WithPropsParam(NamedParam("props", map[string]any{}))).Returning(Var("n")).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if _, err := WithTenantE(withPropsParam, "tenantId", "acme"); !errors.Is(err, core.ErrInvalidPattern) {
+		t.Errorf("WithTenantE() error = %v, want core.ErrInvalidPattern", err)
+	}
+	if _, err := WithTenantE(core.NewStatement("MATCH (n) RETURN n", nil), "tenantId", "acme"); !errors.Is(err, core.ErrInvalidQuery) {
+		t.Errorf("WithTenantE() error = %v, want core.ErrInvalidQuery", err)
+	}
+	if _, err := WithTenantE(stmt, "", "acme"); !errors.Is(err, core.ErrInvalidProperty) {
+		t.Errorf("WithTenantE() error = %v, want core.ErrInvalidProperty", err)
+	}
+}
+
+func TestInTransactionsAfterLoadCSV(t *testing.T) {
+	load := core.NewStatement("LOAD CSV WITH HEADERS FROM $url AS row", map[string]any{"url": "file:///people.csv"})
+	inner, err := With(Var("row")).
+		Create(Node("Person").Named("p").WithProperties(map[string]core.Expression{"name": RawCypher("row.name"), "source": NamedParam("source", "csv")})).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	stmt := JoinStatements(load, InTransactions(inner, 1000))
+	want := "LOAD CSV WITH HEADERS FROM $url AS row CALL { WITH row CREATE (p:Person {name: row.name, source: $source}) } IN TRANSACTIONS OF 1000 ROWS"
+	if stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+	wantParams := map[string]any{"url": "file:///people.csv", "source": "csv"}
+	if !reflect.DeepEqual(stmt.Params(), wantParams) {
+		t.Errorf("Params() = %v, want %v", stmt.Params(), wantParams)
+	}
+
+	if got := InTransactions(inner, 0).Cypher(); !strings.HasSuffix(got, "} IN TRANSACTIONS") {
+		t.Errorf("InTransactions() without a batch size = %q, want no OF ... ROWS", got)
+	}
+}
+
+func TestVectorSimilarity(t *testing.T) {
+	ranked, err := Return(Var("node").Property("title"), Var("score")).Limit(3).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	stmt := JoinStatements(VectorSimilarity("doc_embeddings", 10, []float64{0.1, 0.2}), ranked)
+	want := "CALL db.index.vector.queryNodes('doc_embeddings', 10, $embedding) YIELD node, score RETURN node.title, score LIMIT 3"
+	if stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+	if want := map[string]any{"embedding": []float64{0.1, 0.2}}; !reflect.DeepEqual(stmt.Params(), want) {
+		t.Errorf("Params() = %v, want %v", stmt.Params(), want)
+	}
+
+	transformed, err := stmt.Transform(func(e core.Expression) core.Expression { return e })
+	if err != nil || transformed.Cypher() != want {
+		t.Errorf("Transform() = %v, %v, want %q", transformed, err, want)
+	}
+
+	named := VectorSimilarity("doc_embeddings", 5, NamedParam("query", []float64{1}))
+	if !strings.Contains(named.Cypher(), "queryNodes('doc_embeddings', 5, $query)") {
+		t.Errorf("Cypher() = %q, want the named embedding parameter", named.Cypher())
+	}
+}
+
+func TestRelateNodesDirections(t *testing.T) {
+	person := Node("Person").Named("p")
+	movie := Node("Movie").Named("m")
+
+	tests := map[string]struct {
+		rel  core.PatternElement
+		want string
+	}{
+		"outgoing":      {RelateNodes(person, movie, "ACTED_IN"), "MATCH (p:Person)-[:`ACTED_IN`]->(m:Movie) RETURN p"},
+		"incoming":      {RelateNodesFrom(person, movie, "ACTED_IN"), "MATCH (p:Person)<-[:`ACTED_IN`]-(m:Movie) RETURN p"},
+		"bidirectional": {RelateBidirectionally(person, movie, "ACTED_IN"), "MATCH (p:Person)-[:`ACTED_IN`]-(m:Movie) RETURN p"},
+	}
+	for name, tt := range tests {
+		stmt, err := Match(Pattern(person, tt.rel, movie)).Returning(Var("p")).Build()
+		if err != nil {
+			t.Fatalf("%s: Build() error = %v", name, err)
+		}
+		if stmt.Cypher() != tt.want {
+			t.Errorf("%s: Cypher() = %q, want %q", name, stmt.Cypher(), tt.want)
+		}
+	}
+}
+
+func TestFingerprintIgnoresValues(t *testing.T) {
+	person := Node("Person").Named("p")
+	build := func(where core.Expression, limit int) core.Statement {
+		stmt, err := Match(person).Where(where).Returning(Var("p")).Limit(limit).Build()
+		if err != nil {
+			t.Fatalf("Build() error = %v", err)
+		}
+		return stmt
+	}
+
+	john := build(person.Property("name").Eq("John"), 10)
+	jane := build(person.Property("name").Eq("Jane"), 20)
+	if john.Fingerprint() != jane.Fingerprint() {
+		t.Errorf("Fingerprint() differs for inlined values: %q and %q", john.Cypher(), jane.Cypher())
+	}
+
+	same := build(And(person.Property("a").Eq(Param("A")), person.Property("b").Eq(Param("A"))), 10)
+	different := build(And(person.Property("a").Eq(Param("A")), person.Property("b").Eq(Param("B"))), 10)
+	if same.Fingerprint() != different.Fingerprint() {
+		t.Errorf("Fingerprint() differs for parameter values: %q and %q", same.Cypher(), different.Cypher())
+	}
+
+	named := build(person.Property("name").Eq(NamedParam("name", "John")), 10)
+	if named.Fingerprint() == john.Fingerprint() {
+		t.Errorf("Fingerprint() should keep the names of named parameters")
+	}
+	if age := build(person.Property("age").Eq(30), 10); age.Fingerprint() == john.Fingerprint() {
+		t.Errorf("Fingerprint() should differ for a different property")
+	}
+	if john.Fingerprint() != john.Fingerprint() {
+		t.Errorf("Fingerprint() should be stable")
+	}
+}
@@ -2,8 +2,12 @@ package driver
 
 import (
 	"context"
+	"errors"
+	"reflect"
 	"testing"
+	"time"
 
+	"github.com/neo4j/neo4j-go-driver/v4/neo4j"
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher"
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/ast"
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
@@ -142,3 +146,271 @@ func TestContextUsage(t *testing.T) {
 	_ = ctx
 }
 
+func TestRunSessionConfigurers(t *testing.T) {
+	config := neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite}
+	for _, configurer := range []func(*neo4j.SessionConfig){ReadAccess, WithDatabase("movies")} {
+		configurer(&config)
+	}
+
+	if config.AccessMode != neo4j.AccessModeRead {
+		t.Errorf("AccessMode = %v, want AccessModeRead", config.AccessMode)
+	}
+	if config.DatabaseName != "movies" {
+		t.Errorf("DatabaseName = %q, want 'movies'", config.DatabaseName)
+	}
+}
+
+func TestRunWithContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	start := time.Now()
+	_, err := runWithContext(ctx, func() (any, error) {
+		<-release
+		return "too late", nil
+	})
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("runWithContext() error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("runWithContext() returned after %v, should return when the context expires", elapsed)
+	}
+}
+
+func TestRunWithContextCompletes(t *testing.T) {
+	value, err := runWithContext(context.Background(), func() (any, error) {
+		return 42, nil
+	})
+
+	if err != nil {
+		t.Fatalf("runWithContext() error = %v", err)
+	}
+	if value != 42 {
+		t.Errorf("runWithContext() = %v, want 42", value)
+	}
+}
+
+func TestRunWithContextAlreadyCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	called := false
+	_, err := runWithContext(ctx, func() (any, error) {
+		called = true
+		return nil, nil
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("runWithContext() error = %v, want context.Canceled", err)
+	}
+	if called {
+		t.Error("runWithContext() should not start work for a cancelled context")
+	}
+}
+
+func TestUnlessCancelledFailsWorkOfCancelledCaller(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	work := unlessCancelled(ctx, func(tx neo4j.Transaction) (any, error) {
+		cancel()
+		return "written", nil
+	})
+	if value, err := work(nil); !errors.Is(err, context.Canceled) || value != nil {
+		t.Errorf("work() = %v, %v, want context.Canceled so the transaction rolls back", value, err)
+	}
+
+	value, err := unlessCancelled(context.Background(), func(tx neo4j.Transaction) (any, error) {
+		return "written", nil
+	})(nil)
+	if err != nil || value != "written" {
+		t.Errorf("work() = %v, %v, want written", value, err)
+	}
+}
+
+func TestTxConfigurers(t *testing.T) {
+	if configurers := txConfigurers(context.Background()); len(configurers) != 0 {
+		t.Errorf("txConfigurers() without deadline = %d configurers, want 0", len(configurers))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if configurers := txConfigurers(ctx); len(configurers) != 1 {
+		t.Errorf("txConfigurers() with deadline = %d configurers, want 1", len(configurers))
+	}
+}
+
+func TestQueryHelperCounters(t *testing.T) {
+	driver := &fakeDriver{
+		keys:     []string{"name"},
+		rows:     [][]any{{"Ann"}, {"Bob"}},
+		counters: fakeCounters{nodesCreated: 2, propertiesSet: 4},
+	}
+	sm := NewSessionManager(driver)
+	stmt := core.NewStatement("UNWIND $names AS name CREATE (p:Person {name: name}) RETURN p.name AS name", nil)
+
+	names, err := sm.ExecuteWrite(context.Background(), stmt, NewQueryHelper().CollectList("name"))
+	if err != nil {
+		t.Fatalf("ExecuteWrite(CollectList) error = %v", err)
+	}
+	if !reflect.DeepEqual(names, []any{"Ann", "Bob"}) {
+		t.Errorf("CollectList() = %v, want [Ann Bob]", names)
+	}
+
+	counters, err := sm.ExecuteWrite(context.Background(), stmt, NewQueryHelper().Counters())
+	if err != nil {
+		t.Fatalf("ExecuteWrite(Counters) error = %v", err)
+	}
+	summary, ok := counters.(*SummaryCounters)
+	if !ok {
+		t.Fatalf("Counters() = %T, want *SummaryCounters", counters)
+	}
+	if summary.NodesCreated != 2 || summary.PropertiesSet != 4 || summary.RelationshipsCreated != 0 {
+		t.Errorf("Counters() = %+v, want 2 nodes and 4 properties", summary)
+	}
+}
+
+// fakeDriver hands out sessions whose transactions all return fresh cursors
+// over the same records and counters. Each transaction function runs
+// attempts times, as the driver does when it retries a transaction.
+type fakeDriver struct {
+	neo4j.Driver
+	keys     []string
+	rows     [][]any
+	counters neo4j.Counters
+	attempts int
+
+	config neo4j.SessionConfig
+	mode   neo4j.AccessMode
+	cypher string
+	params map[string]any
+}
+
+func (d *fakeDriver) NewSession(config neo4j.SessionConfig) neo4j.Session {
+	d.config = config
+	return &fakeSession{driver: d}
+}
+
+// fakeSession runs transaction functions against a fakeDriver
+type fakeSession struct {
+	neo4j.Session
+	driver *fakeDriver
+}
+
+func (s *fakeSession) ReadTransaction(work neo4j.TransactionWork, configurers ...func(*neo4j.TransactionConfig)) (any, error) {
+	s.driver.mode = neo4j.AccessModeRead
+	return s.run(work)
+}
+
+func (s *fakeSession) WriteTransaction(work neo4j.TransactionWork, configurers ...func(*neo4j.TransactionConfig)) (any, error) {
+	s.driver.mode = neo4j.AccessModeWrite
+	return s.run(work)
+}
+
+func (s *fakeSession) run(work neo4j.TransactionWork) (value any, err error) {
+	for i := 0; i < max(s.driver.attempts, 1); i++ {
+		value, err = work(&fakeTransaction{driver: s.driver})
+	}
+	return value, err
+}
+
+func (s *fakeSession) Close() error { return nil }
+
+// fakeTransaction records the query it runs
+type fakeTransaction struct {
+	neo4j.Transaction
+	driver *fakeDriver
+}
+
+func (tx *fakeTransaction) Run(cypher string, params map[string]any) (neo4j.Result, error) {
+	tx.driver.cypher, tx.driver.params = cypher, params
+	result := &fakeResult{counters: tx.driver.counters}
+	for _, row := range tx.driver.rows {
+		result.records = append(result.records, &neo4j.Record{Keys: tx.driver.keys, Values: row})
+	}
+	return result, nil
+}
+
+// fakeResult is a cursor over fixed records
+type fakeResult struct {
+	neo4j.Result
+	records  []*neo4j.Record
+	counters neo4j.Counters
+	next     int
+}
+
+func (r *fakeResult) Next() bool {
+	if r.next == len(r.records) {
+		return false
+	}
+	r.next++
+	return true
+}
+
+func (r *fakeResult) Record() *neo4j.Record { return r.records[r.next-1] }
+
+func (r *fakeResult) Err() error { return nil }
+
+func (r *fakeResult) Collect() ([]*neo4j.Record, error) {
+	rest := r.records[r.next:]
+	r.next = len(r.records)
+	return rest, nil
+}
+
+func (r *fakeResult) Consume() (neo4j.ResultSummary, error) {
+	r.next = len(r.records)
+	return fakeSummary{counters: r.counters}, nil
+}
+
+// fakeSummary reports fixed counters
+type fakeSummary struct {
+	neo4j.ResultSummary
+	counters neo4j.Counters
+}
+
+func (s fakeSummary) Counters() neo4j.Counters { return s.counters }
+
+func TestRun(t *testing.T) {
+	node := ast.Node("Person").Named("p")
+	stmt, err := cypher.Match(node).
+		Where(node.Property("name").Eq(cypher.NamedParam("name", "Ann"))).
+		Returning(node.Property("name").As("name")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	driver := &fakeDriver{
+		keys:     []string{"name"},
+		rows:     [][]any{{"Ann"}},
+		counters: fakeCounters{},
+	}
+	records, summary, err := Run(context.Background(), driver, stmt, ReadAccess, WithDatabase("movies"))
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if driver.cypher != stmt.Cypher() || driver.params["name"] != "Ann" {
+		t.Errorf("Run() ran %q with %v, want %q with name", driver.cypher, driver.params, stmt.Cypher())
+	}
+	if driver.mode != neo4j.AccessModeRead || driver.config.DatabaseName != "movies" {
+		t.Errorf("Run() used mode %v on database %q, want a read on movies", driver.mode, driver.config.DatabaseName)
+	}
+	if len(records) != 1 {
+		t.Fatalf("Run() returned %d records, want 1", len(records))
+	}
+	if name, _ := records[0].Get("name"); name != "Ann" {
+		t.Errorf("record name = %v, want Ann", name)
+	}
+	if summary == nil || summary.Counters() == nil {
+		t.Errorf("Run() summary = %v, want the consumed summary", summary)
+	}
+
+	if _, _, err := Run(context.Background(), driver, stmt); err != nil || driver.mode != neo4j.AccessModeWrite {
+		t.Errorf("Run() without configurers = %v in mode %v, want a write", err, driver.mode)
+	}
+}
@@ -0,0 +1,75 @@
+package driver
+
+import (
+	"context"
+
+	"github.com/neo4j/neo4j-go-driver/v4/neo4j"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+)
+
+// Run executes a statement in its own managed transaction and returns all
+// records and the result summary, without the session and transaction
+// boilerplate. The query is routed as a write unless a configurer selects
+// another access mode. Run is built on the v4 driver this module depends on.
+func Run(ctx context.Context, driver neo4j.Driver, statement core.Statement,
+	configurers ...func(*neo4j.SessionConfig)) ([]*neo4j.Record, neo4j.ResultSummary, error) {
+
+	config := neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite}
+	for _, configurer := range configurers {
+		configurer(&config)
+	}
+
+	eager, err := runWithContext(ctx, func() (any, error) {
+		session := driver.NewSession(config)
+		defer session.Close()
+
+		work := unlessCancelled(ctx, func(tx neo4j.Transaction) (any, error) {
+			result, err := tx.Run(statement.Cypher(), statement.Params())
+			if err != nil {
+				return nil, err
+			}
+			return collectAll(result)
+		})
+
+		if config.AccessMode == neo4j.AccessModeRead {
+			return session.ReadTransaction(work, txConfigurers(ctx)...)
+		}
+		return session.WriteTransaction(work, txConfigurers(ctx)...)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	collected := eager.(*collected)
+	return collected.records, collected.summary, nil
+}
+
+// ReadAccess is a session configurer that routes Run to a reader
+func ReadAccess(config *neo4j.SessionConfig) {
+	config.AccessMode = neo4j.AccessModeRead
+}
+
+// WithDatabase returns a session configurer that runs against the given database
+func WithDatabase(name string) func(*neo4j.SessionConfig) {
+	return func(config *neo4j.SessionConfig) {
+		config.DatabaseName = name
+	}
+}
+
+// collected holds the records and summary of a fully consumed result
+type collected struct {
+	records []*neo4j.Record
+	summary neo4j.ResultSummary
+}
+
+// collectAll consumes a result, keeping its records and summary
+func collectAll(result neo4j.Result) (*collected, error) {
+	records, err := result.Collect()
+	if err != nil {
+		return nil, err
+	}
+	summary, err := result.Consume()
+	if err != nil {
+		return nil, err
+	}
+	return &collected{records: records, summary: summary}, nil
+}
@@ -0,0 +1,73 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/expr"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/schema"
+)
+
+// indexPollInterval is how often WaitForIndexOnline checks the index state.
+// Replaced in tests.
+var indexPollInterval = 500 * time.Millisecond
+
+// WaitForIndexOnline polls SHOW INDEXES until the named index is ONLINE, e.g.
+// after creating it in a deploy script. It gives up with an error when the
+// index fails to populate, when timeout elapses or when ctx is done; an index
+// that does not exist yet is waited for as well.
+func WaitForIndexOnline(ctx context.Context, sm *SessionManager, indexName string, timeout time.Duration) error {
+	show, err := schema.ShowIndexes()
+	if err != nil {
+		return err
+	}
+	statement := show.Yield("name", "state").
+		Where(expr.Equals(expr.NewVariableExpression("name"), core.NewParameter("name", indexName)))
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return waitForIndexOnline(ctx, indexName, func() (string, error) {
+		value, err := sm.ExecuteRead(ctx, statement, NewQueryHelper().CollectSingle("state"))
+		if err != nil {
+			return "", err
+		}
+		state, _ := value.(string)
+		return state, nil
+	})
+}
+
+// waitForIndexOnline calls indexState every indexPollInterval until it
+// reports ONLINE or FAILED, returns an error or ctx is done. An empty state
+// means the index was not found.
+func waitForIndexOnline(ctx context.Context, indexName string, indexState func() (string, error)) error {
+	for {
+		state, err := indexState()
+		if err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf("waiting for index %q: %w", indexName, ctx.Err())
+			}
+			return err
+		}
+
+		switch state {
+		case "ONLINE":
+			return nil
+		case "FAILED":
+			return fmt.Errorf("index %q failed to populate", indexName)
+		}
+
+		timer := time.NewTimer(indexPollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if state == "" {
+				return fmt.Errorf("index %q not found: %w", indexName, ctx.Err())
+			}
+			return fmt.Errorf("index %q is still %s: %w", indexName, state, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func stubIndexPollInterval(t *testing.T) {
+	original := indexPollInterval
+	indexPollInterval = time.Millisecond
+	t.Cleanup(func() { indexPollInterval = original })
+}
+
+func TestWaitForIndexOnlinePollsUntilOnline(t *testing.T) {
+	stubIndexPollInterval(t)
+
+	states := []string{"", "POPULATING", "POPULATING", "ONLINE"}
+	polls := 0
+	err := waitForIndexOnline(context.Background(), "person_name", func() (string, error) {
+		state := states[polls]
+		polls++
+		return state, nil
+	})
+	if err != nil {
+		t.Fatalf("waitForIndexOnline() error = %v", err)
+	}
+	if polls != len(states) {
+		t.Errorf("waitForIndexOnline() polled %d times, want %d", polls, len(states))
+	}
+}
+
+func TestWaitForIndexOnlineFailures(t *testing.T) {
+	stubIndexPollInterval(t)
+
+	err := waitForIndexOnline(context.Background(), "person_name", func() (string, error) { return "FAILED", nil })
+	if err == nil || !strings.Contains(err.Error(), "failed to populate") {
+		t.Errorf("waitForIndexOnline() of a failed index error = %v, want a population failure", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = waitForIndexOnline(ctx, "person_name", func() (string, error) { return "POPULATING", nil })
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "still POPULATING") {
+		t.Errorf("waitForIndexOnline() past the timeout error = %v, want a deadline error naming the state", err)
+	}
+
+	errQuery := errors.New("query failed")
+	err = waitForIndexOnline(context.Background(), "person_name", func() (string, error) { return "", errQuery })
+	if !errors.Is(err, errQuery) {
+		t.Errorf("waitForIndexOnline() error = %v, want %v", err, errQuery)
+	}
+}
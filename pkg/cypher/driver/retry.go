@@ -0,0 +1,79 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v4/neo4j"
+)
+
+// ExecuteOption configures how SessionManager executes a statement
+type ExecuteOption func(*executeConfig)
+
+// executeConfig holds the settings applied by ExecuteOption values
+type executeConfig struct {
+	maxAttempts int
+	backoff     time.Duration
+}
+
+// newExecuteConfig applies options on top of the defaults (a single attempt)
+func newExecuteConfig(options []ExecuteOption) *executeConfig {
+	config := &executeConfig{maxAttempts: 1}
+	for _, option := range options {
+		option(config)
+	}
+	return config
+}
+
+// WithRetry re-runs the transaction up to maxAttempts times when it fails with
+// a transient error such as a deadlock or a cluster leader switch. The delay
+// before each new attempt starts at backoff and doubles every time.
+// Non-retryable errors are returned immediately.
+//
+// The v4 driver's ReadTransaction and WriteTransaction already retry transient
+// errors themselves, for up to the driver's MaxTransactionRetryTime (30 seconds
+// by default). WithRetry only starts a new attempt once the driver has given
+// up, so the two multiply: lower MaxTransactionRetryTime in the driver config
+// when the retries should be governed by WithRetry alone.
+func WithRetry(maxAttempts int, backoff time.Duration) ExecuteOption {
+	return func(config *executeConfig) {
+		if maxAttempts < 1 {
+			maxAttempts = 1
+		}
+		config.maxAttempts = maxAttempts
+		config.backoff = backoff
+	}
+}
+
+// isRetryable reports whether an error is transient: a lost connection, a
+// transient server error or a cluster role change. Replaced in tests.
+var isRetryable = func(err error) bool {
+	var neo4jErr *neo4j.Neo4jError
+	if errors.As(err, &neo4jErr) {
+		return neo4jErr.IsRetriableTransient() || neo4jErr.IsRetriableCluster()
+	}
+	var connectivityErr *neo4j.ConnectivityError
+	return errors.As(err, &connectivityErr)
+}
+
+// withRetry calls work until it succeeds, fails with a non-retryable error,
+// runs out of attempts or the context is done
+func withRetry(ctx context.Context, config *executeConfig, work func() (any, error)) (any, error) {
+	delay := config.backoff
+	for attempt := 1; ; attempt++ {
+		value, err := work()
+		if err == nil || attempt >= config.maxAttempts || !isRetryable(err) {
+			return value, err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
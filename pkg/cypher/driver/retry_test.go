@@ -0,0 +1,114 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v4/neo4j"
+)
+
+var (
+	errTransient = errors.New("transient")
+	errFatal     = errors.New("fatal")
+)
+
+func stubRetryable(t *testing.T) {
+	original := isRetryable
+	isRetryable = func(err error) bool { return errors.Is(err, errTransient) }
+	t.Cleanup(func() { isRetryable = original })
+}
+
+func TestWithRetryRecoversFromTransientErrors(t *testing.T) {
+	stubRetryable(t)
+
+	attempts := 0
+	value, err := withRetry(context.Background(), newExecuteConfig([]ExecuteOption{WithRetry(3, time.Millisecond)}), func() (any, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, errTransient
+		}
+		return "ok", nil
+	})
+
+	if err != nil {
+		t.Fatalf("withRetry() error = %v", err)
+	}
+	if value != "ok" || attempts != 3 {
+		t.Errorf("withRetry() = %v after %d attempts, want ok after 3", value, attempts)
+	}
+}
+
+func TestWithRetryStopsOnNonRetryableError(t *testing.T) {
+	stubRetryable(t)
+
+	attempts := 0
+	_, err := withRetry(context.Background(), newExecuteConfig([]ExecuteOption{WithRetry(5, time.Millisecond)}), func() (any, error) {
+		attempts++
+		return nil, errFatal
+	})
+
+	if !errors.Is(err, errFatal) {
+		t.Errorf("withRetry() error = %v, want %v", err, errFatal)
+	}
+	if attempts != 1 {
+		t.Errorf("withRetry() made %d attempts, want 1", attempts)
+	}
+}
+
+func TestWithRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	stubRetryable(t)
+
+	attempts := 0
+	_, err := withRetry(context.Background(), newExecuteConfig([]ExecuteOption{WithRetry(2, time.Millisecond)}), func() (any, error) {
+		attempts++
+		return nil, errTransient
+	})
+
+	if !errors.Is(err, errTransient) {
+		t.Errorf("withRetry() error = %v, want %v", err, errTransient)
+	}
+	if attempts != 2 {
+		t.Errorf("withRetry() made %d attempts, want 2", attempts)
+	}
+}
+
+func TestWithRetryDefaultsToSingleAttempt(t *testing.T) {
+	stubRetryable(t)
+
+	attempts := 0
+	_, _ = withRetry(context.Background(), newExecuteConfig(nil), func() (any, error) {
+		attempts++
+		return nil, errTransient
+	})
+
+	if attempts != 1 {
+		t.Errorf("withRetry() made %d attempts, want 1", attempts)
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"deadlock", &neo4j.Neo4jError{Code: "Neo.TransientError.Transaction.DeadlockDetected"}, true},
+		{"terminated", &neo4j.Neo4jError{Code: "Neo.TransientError.Transaction.Terminated"}, false},
+		{"not a leader", &neo4j.Neo4jError{Code: "Neo.ClientError.Cluster.NotALeader"}, true},
+		{"syntax error", &neo4j.Neo4jError{Code: "Neo.ClientError.Statement.SyntaxError"}, false},
+		{"wrapped", fmt.Errorf("run: %w", &neo4j.Neo4jError{Code: "Neo.TransientError.General.DatabaseUnavailable"}), true},
+		{"connectivity", &neo4j.ConnectivityError{}, true},
+		{"other", errFatal, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryable(tt.err); got != tt.want {
+				t.Errorf("isRetryable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/neo4j/neo4j-go-driver/v4/neo4j"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+)
+
+// ScanAll returns a handler function that maps every record into dest,
+// which must be a pointer to a slice of structs (or of struct pointers).
+// Columns are matched to fields using the `db:"column"` tag, falling back
+// to a case-insensitive match on the field name. A `db:"-"` tag skips the field.
+// Any elements already in dest are discarded. Numbers are converted to the
+// field type, but a float is never truncated to an integer and a value that
+// does not fit the field fails the scan.
+// Example: RETURN m.title AS Title, m.released AS Year fills []Movie{Title, Year}
+func (qh *QueryHelper) ScanAll(dest interface{}) func(neo4j.Result) (any, error) {
+	return func(result neo4j.Result) (any, error) {
+		destValue := reflect.ValueOf(dest)
+		if destValue.Kind() != reflect.Ptr || destValue.IsNil() || destValue.Elem().Kind() != reflect.Slice {
+			return nil, fmt.Errorf("scan destination must be a non-nil pointer to a slice, got %T", dest)
+		}
+
+		sliceValue := destValue.Elem()
+		elemType := sliceValue.Type().Elem()
+		isPtr := elemType.Kind() == reflect.Ptr
+		structType, err := scanStructType(elemType)
+		if err != nil {
+			return nil, fmt.Errorf("scan destination must be a slice of structs, got %T", dest)
+		}
+
+		// A retried transaction decodes all records again
+		sliceValue.Set(reflect.Zero(sliceValue.Type()))
+
+		fields := structFields(structType)
+		for result.Next() {
+			item := reflect.New(structType).Elem()
+			if err := scanRecord(result.Record(), item, fields); err != nil {
+				return nil, err
+			}
+			if isPtr {
+				sliceValue.Set(reflect.Append(sliceValue, item.Addr()))
+			} else {
+				sliceValue.Set(reflect.Append(sliceValue, item))
+			}
+		}
+		if err := result.Err(); err != nil {
+			return nil, err
+		}
+
+		return sliceValue.Interface(), nil
+	}
+}
+
+// Query runs a read statement and decodes every record into a T, which must
+// be a struct or a pointer to one, matching columns to fields like ScanAll:
+//
+//	movies, err := driver.Query[Movie](ctx, sm, stmt)
+func Query[T any](ctx context.Context, sm *SessionManager, statement core.Statement, options ...ExecuteOption) ([]T, error) {
+	var zero T
+	if _, err := scanStructType(reflect.TypeOf(&zero).Elem()); err != nil {
+		return nil, err
+	}
+
+	var rows []T
+	if _, err := sm.ExecuteRead(ctx, statement, NewQueryHelper().ScanAll(&rows), options...); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// scanStructType returns the struct type records are decoded into for
+// elements of the given type, a struct or a pointer to one
+func scanStructType(elemType reflect.Type) (reflect.Type, error) {
+	structType := elemType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("records can only be decoded into structs, got %s", elemType)
+	}
+	return structType, nil
+}
+
+// structFields maps lower-cased column names to the index of the field they populate
+func structFields(structType reflect.Type) map[string]int {
+	fields := make(map[string]int, structType.NumField())
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.PkgPath != "" {
+			// Unexported field
+			continue
+		}
+
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("db"); ok {
+			if tag == "-" {
+				continue
+			}
+			if tag != "" {
+				name = tag
+			}
+		}
+		fields[strings.ToLower(name)] = i
+	}
+	return fields
+}
+
+// scanRecord copies the values of a record into the matching fields of item
+func scanRecord(record *neo4j.Record, item reflect.Value, fields map[string]int) error {
+	for i, key := range record.Keys {
+		index, ok := fields[strings.ToLower(key)]
+		if !ok {
+			continue
+		}
+
+		field := item.Field(index)
+		if err := assignValue(field, record.Values[i]); err != nil {
+			return fmt.Errorf("column %q: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// assignValue stores value in field, converting between compatible types
+// such as the int64 returned by Neo4j and an int field
+func assignValue(field reflect.Value, value any) error {
+	// NULL leaves the field at its zero value
+	if value == nil {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+
+	fieldType := field.Type()
+	if fieldType.Kind() == reflect.Ptr {
+		elem := reflect.New(fieldType.Elem())
+		if err := assignValue(elem.Elem(), value); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
+	}
+
+	v := reflect.ValueOf(value)
+	switch {
+	case v.Type().AssignableTo(fieldType):
+		field.Set(v)
+	case isNumeric(v.Kind()) && isNumeric(fieldType.Kind()):
+		return assignNumber(field, v)
+	case v.Kind() == reflect.Slice && fieldType.Kind() == reflect.Slice:
+		// Lists come back from the driver as []interface{}
+		items := reflect.MakeSlice(fieldType, v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			if err := assignValue(items.Index(i), v.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+		field.Set(items)
+	default:
+		return fmt.Errorf("cannot assign value of type %T to field of type %s", value, fieldType)
+	}
+	return nil
+}
+
+// assignNumber stores the number v in field, failing rather than truncating
+// a float to an integer or wrapping a value the field cannot hold
+func assignNumber(field reflect.Value, v reflect.Value) error {
+	fieldType := field.Type()
+	overflows := false
+	switch {
+	case isFloat(fieldType.Kind()):
+		overflows = isFloat(v.Kind()) && field.OverflowFloat(v.Float())
+	case isFloat(v.Kind()):
+		return fmt.Errorf("cannot assign %v of type %s to integer field of type %s", v, v.Type(), fieldType)
+	case isSigned(fieldType.Kind()):
+		if isSigned(v.Kind()) {
+			overflows = field.OverflowInt(v.Int())
+		} else {
+			overflows = v.Uint() > math.MaxInt64 || field.OverflowInt(int64(v.Uint()))
+		}
+	default:
+		if isSigned(v.Kind()) {
+			overflows = v.Int() < 0 || field.OverflowUint(uint64(v.Int()))
+		} else {
+			overflows = field.OverflowUint(v.Uint())
+		}
+	}
+	if overflows {
+		return fmt.Errorf("value %v overflows field of type %s", v, fieldType)
+	}
+	field.Set(v.Convert(fieldType))
+	return nil
+}
+
+// isSigned reports whether kind is a signed integer kind
+func isSigned(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+// isFloat reports whether kind is a floating-point kind
+func isFloat(kind reflect.Kind) bool {
+	return kind == reflect.Float32 || kind == reflect.Float64
+}
+
+// isNumeric reports whether kind is an integer or floating-point kind
+func isNumeric(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
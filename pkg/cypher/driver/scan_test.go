@@ -0,0 +1,168 @@
+package driver
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v4/neo4j"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+)
+
+type scanMovie struct {
+	Title   string
+	Year    int `db:"released"`
+	Tagline *string
+	Genres  []string
+	Ignored string `db:"-"`
+}
+
+func TestScanRecord(t *testing.T) {
+	record := &neo4j.Record{
+		Keys:   []string{"title", "released", "tagline", "genres", "Ignored"},
+		Values: []interface{}{"The Matrix", int64(1999), nil, []interface{}{"Action", "Sci-Fi"}, "x"},
+	}
+
+	var movie scanMovie
+	fields := structFields(reflect.TypeOf(movie))
+	if err := scanRecord(record, reflect.ValueOf(&movie).Elem(), fields); err != nil {
+		t.Fatalf("scanRecord() error = %v", err)
+	}
+
+	if movie.Title != "The Matrix" {
+		t.Errorf("Title = %q, want 'The Matrix'", movie.Title)
+	}
+	if movie.Year != 1999 {
+		t.Errorf("Year = %d, want 1999", movie.Year)
+	}
+	if movie.Tagline != nil {
+		t.Errorf("Tagline = %v, want nil", movie.Tagline)
+	}
+	if !reflect.DeepEqual(movie.Genres, []string{"Action", "Sci-Fi"}) {
+		t.Errorf("Genres = %v, want [Action Sci-Fi]", movie.Genres)
+	}
+	if movie.Ignored != "" {
+		t.Errorf("Ignored = %q, should not be populated", movie.Ignored)
+	}
+}
+
+func TestScanRecordTypeMismatch(t *testing.T) {
+	record := &neo4j.Record{
+		Keys:   []string{"title"},
+		Values: []interface{}{int64(42)},
+	}
+
+	var movie scanMovie
+	err := scanRecord(record, reflect.ValueOf(&movie).Elem(), structFields(reflect.TypeOf(movie)))
+	if err == nil {
+		t.Fatal("scanRecord() should fail when a column cannot be assigned")
+	}
+}
+
+func TestScanAllInvalidDestination(t *testing.T) {
+	helper := NewQueryHelper()
+
+	var notSlice scanMovie
+	if _, err := helper.ScanAll(&notSlice)(nil); err == nil {
+		t.Error("ScanAll() should reject a pointer to a non-slice")
+	}
+
+	var notStruct []string
+	if _, err := helper.ScanAll(&notStruct)(nil); err == nil {
+		t.Error("ScanAll() should reject a slice of non-structs")
+	}
+
+	if _, err := helper.ScanAll([]scanMovie{})(nil); err == nil {
+		t.Error("ScanAll() should reject a non-pointer destination")
+	}
+}
+
+func TestQueryRejectsNonStructTypes(t *testing.T) {
+	sm := NewSessionManager(nil)
+	stmt := core.NewStatement("MATCH (m:Movie) RETURN m.title AS title", nil)
+
+	if _, err := Query[string](context.Background(), sm, stmt); err == nil {
+		t.Error("Query[string]() should fail before running the statement")
+	}
+	if _, err := Query[*int](context.Background(), sm, stmt); err == nil {
+		t.Error("Query[*int]() should fail before running the statement")
+	}
+}
+
+func TestAssignValueNumbers(t *testing.T) {
+	var (
+		i   int
+		i8  int8
+		u8  uint8
+		u   uint
+		f32 float32
+		f64 float64
+	)
+
+	tests := []struct {
+		name    string
+		field   any
+		value   any
+		want    any
+		wantErr bool
+	}{
+		{name: "int64 to int", field: &i, value: int64(42), want: 42},
+		{name: "int64 to int8", field: &i8, value: int64(-128), want: int8(-128)},
+		{name: "int64 overflows int8", field: &i8, value: int64(300), wantErr: true},
+		{name: "int64 to uint8", field: &u8, value: int64(255), want: uint8(255)},
+		{name: "negative int64 to uint", field: &u, value: int64(-1), wantErr: true},
+		{name: "float64 to int", field: &i, value: 1.5, wantErr: true},
+		{name: "whole float64 to int", field: &i, value: 2.0, wantErr: true},
+		{name: "int64 to float64", field: &f64, value: int64(3), want: 3.0},
+		{name: "float64 to float32", field: &f32, value: 1.5, want: float32(1.5)},
+		{name: "float64 overflows float32", field: &f32, value: 1e300, wantErr: true},
+		{name: "uint64 overflows int8", field: &i8, value: uint64(200), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := reflect.ValueOf(tt.field).Elem()
+			field.Set(reflect.Zero(field.Type()))
+
+			err := assignValue(field, tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("assignValue() stored %v, want an error", field.Interface())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("assignValue() error = %v", err)
+			}
+			if got := field.Interface(); got != tt.want {
+				t.Errorf("assignValue() stored %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanAllRetriedTransaction(t *testing.T) {
+	driver := &fakeDriver{
+		keys:     []string{"title", "released"},
+		rows:     [][]any{{"The Matrix", int64(1999)}, {"Heat", int64(1995)}},
+		attempts: 2,
+	}
+	stmt := core.NewStatement("MATCH (m:Movie) RETURN m.title AS title, m.released AS released", nil)
+
+	var movies []scanMovie
+	_, err := NewSessionManager(driver).ExecuteRead(context.Background(), stmt, NewQueryHelper().ScanAll(&movies))
+	if err != nil {
+		t.Fatalf("ExecuteRead() error = %v", err)
+	}
+	if len(movies) != 2 || movies[0].Title != "The Matrix" || movies[1].Year != 1995 {
+		t.Errorf("ScanAll() decoded %+v, want each movie once", movies)
+	}
+
+	rows, err := Query[scanMovie](context.Background(), NewSessionManager(driver), stmt)
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if len(rows) != 2 {
+		t.Errorf("Query() returned %d rows, want 2", len(rows))
+	}
+}
@@ -3,6 +3,7 @@ package driver
 
 import (
 	"context"
+	"time"
 
 	"github.com/neo4j/neo4j-go-driver/v4/neo4j"
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
@@ -22,33 +23,41 @@ func NewSessionManager(driver neo4j.Driver) *SessionManager {
 
 // ExecuteRead executes a read query using the provided statement
 func (sm *SessionManager) ExecuteRead(ctx context.Context, statement core.Statement,
-	handler func(neo4j.Result) (any, error)) (any, error) {
+	handler func(neo4j.Result) (any, error), options ...ExecuteOption) (any, error) {
 
-	session := sm.driver.NewSession(neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
-	defer session.Close()
+	return withRetry(ctx, newExecuteConfig(options), func() (any, error) {
+		return runWithContext(ctx, func() (any, error) {
+			session := sm.driver.NewSession(neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+			defer session.Close()
 
-	return session.ReadTransaction(func(tx neo4j.Transaction) (any, error) {
-		result, err := tx.Run(statement.Cypher(), statement.Params())
-		if err != nil {
-			return nil, err
-		}
-		return handler(result)
+			return session.ReadTransaction(unlessCancelled(ctx, func(tx neo4j.Transaction) (any, error) {
+				result, err := tx.Run(statement.Cypher(), statement.Params())
+				if err != nil {
+					return nil, err
+				}
+				return handler(result)
+			}), txConfigurers(ctx)...)
+		})
 	})
 }
 
 // ExecuteWrite executes a write query using the provided statement
 func (sm *SessionManager) ExecuteWrite(ctx context.Context, statement core.Statement,
-	handler func(neo4j.Result) (any, error)) (any, error) {
+	handler func(neo4j.Result) (any, error), options ...ExecuteOption) (any, error) {
 
-	session := sm.driver.NewSession(neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
-	defer session.Close()
+	return withRetry(ctx, newExecuteConfig(options), func() (any, error) {
+		return runWithContext(ctx, func() (any, error) {
+			session := sm.driver.NewSession(neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+			defer session.Close()
 
-	return session.WriteTransaction(func(tx neo4j.Transaction) (any, error) {
-		result, err := tx.Run(statement.Cypher(), statement.Params())
-		if err != nil {
-			return nil, err
-		}
-		return handler(result)
+			return session.WriteTransaction(unlessCancelled(ctx, func(tx neo4j.Transaction) (any, error) {
+				result, err := tx.Run(statement.Cypher(), statement.Params())
+				if err != nil {
+					return nil, err
+				}
+				return handler(result)
+			}), txConfigurers(ctx)...)
+		})
 	})
 }
 
@@ -56,22 +65,110 @@ func (sm *SessionManager) ExecuteWrite(ctx context.Context, statement core.State
 func (sm *SessionManager) ExecuteBatchWrite(ctx context.Context, statements []core.Statement,
 	handler func([]neo4j.Result) (any, error)) (any, error) {
 
-	session := sm.driver.NewSession(neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
-	defer session.Close()
+	return runWithContext(ctx, func() (any, error) {
+		session := sm.driver.NewSession(neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+		defer session.Close()
+
+		return session.WriteTransaction(unlessCancelled(ctx, func(tx neo4j.Transaction) (any, error) {
+			var results []neo4j.Result
+
+			for _, stmt := range statements {
+				result, err := tx.Run(stmt.Cypher(), stmt.Params())
+				if err != nil {
+					return nil, err
+				}
+				results = append(results, result)
+			}
 
-	return session.WriteTransaction(func(tx neo4j.Transaction) (any, error) {
-		var results []neo4j.Result
+			return handler(results)
+		}), txConfigurers(ctx)...)
+	})
+}
 
-		for _, stmt := range statements {
-			result, err := tx.Run(stmt.Cypher(), stmt.Params())
+// ExecuteBatchWriteSummary executes multiple write statements in a single transaction
+// and returns the update counters aggregated over all of them. The transaction
+// is rolled back and no counters are returned if any statement fails.
+func (sm *SessionManager) ExecuteBatchWriteSummary(ctx context.Context, statements []core.Statement) (*SummaryCounters, error) {
+	counters, err := sm.ExecuteBatchWrite(ctx, statements, func(results []neo4j.Result) (any, error) {
+		total := &SummaryCounters{}
+		for _, result := range results {
+			summary, err := result.Consume()
 			if err != nil {
 				return nil, err
 			}
-			results = append(results, result)
+			total.add(summary.Counters())
 		}
-
-		return handler(results)
+		return total, nil
 	})
+	if err != nil {
+		return nil, err
+	}
+	return counters.(*SummaryCounters), nil
+}
+
+// runWithContext runs work in its own goroutine and returns ctx.Err() as soon
+// as the context is done. The v4 driver does not observe contexts, so work
+// keeps running in the background until the server aborts the transaction
+// (see txConfigurers) or it completes; its result is then discarded. Returning
+// only stops waiting: the transaction is rolled back when its function sees
+// the cancelled context (see unlessCancelled), but a commit that was already
+// under way when the context was cancelled still takes effect.
+func runWithContext(ctx context.Context, work func() (any, error)) (any, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	type outcome struct {
+		value any
+		err   error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		value, err := work()
+		done <- outcome{value: value, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case out := <-done:
+		return out.value, out.err
+	}
+}
+
+// unlessCancelled wraps the function of a managed transaction so that it
+// fails with ctx.Err() when ctx is done before or after the work, which makes
+// the driver roll the transaction back rather than commit it after the caller
+// was told that it failed
+func unlessCancelled(ctx context.Context, work neo4j.TransactionWork) neo4j.TransactionWork {
+	return func(tx neo4j.Transaction) (any, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		value, err := work(tx)
+		if err != nil {
+			return nil, err
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return value, nil
+	}
+}
+
+// txConfigurers derives a transaction timeout from the context deadline so
+// that the server aborts queries whose caller has already given up
+func txConfigurers(ctx context.Context) []func(*neo4j.TransactionConfig) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return nil
+	}
+	timeout := time.Until(deadline)
+	if timeout <= 0 {
+		// Let runWithContext report the expired context instead
+		return nil
+	}
+	return []func(*neo4j.TransactionConfig){neo4j.WithTxTimeout(timeout)}
 }
 
 // QueryHelper provides common handler functions for Neo4j results
@@ -163,6 +260,20 @@ func (qh *QueryHelper) CountResults() func(neo4j.Result) (any, error) {
 	}
 }
 
+// Counters returns a handler function that consumes the result and returns its
+// update counters as a *SummaryCounters, e.g. to assert that a CREATE added one node
+func (qh *QueryHelper) Counters() func(neo4j.Result) (any, error) {
+	return func(result neo4j.Result) (any, error) {
+		summary, err := result.Consume()
+		if err != nil {
+			return nil, err
+		}
+		counters := &SummaryCounters{}
+		counters.add(summary.Counters())
+		return counters, nil
+	}
+}
+
 // NewQueryHelper creates a new QueryHelper
 func NewQueryHelper() *QueryHelper {
 	return &QueryHelper{}
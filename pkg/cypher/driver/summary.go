@@ -0,0 +1,44 @@
+package driver
+
+import (
+	"github.com/neo4j/neo4j-go-driver/v4/neo4j"
+)
+
+// SummaryCounters holds the update counters reported by the server for one
+// or more write queries
+type SummaryCounters struct {
+	NodesCreated         int
+	NodesDeleted         int
+	RelationshipsCreated int
+	RelationshipsDeleted int
+	PropertiesSet        int
+	LabelsAdded          int
+	LabelsRemoved        int
+	IndexesAdded         int
+	IndexesRemoved       int
+	ConstraintsAdded     int
+	ConstraintsRemoved   int
+}
+
+// ContainsUpdates reports whether any of the counters is non-zero
+func (c *SummaryCounters) ContainsUpdates() bool {
+	return *c != SummaryCounters{}
+}
+
+// add accumulates the counters of a single result summary
+func (c *SummaryCounters) add(counters neo4j.Counters) {
+	if counters == nil {
+		return
+	}
+	c.NodesCreated += counters.NodesCreated()
+	c.NodesDeleted += counters.NodesDeleted()
+	c.RelationshipsCreated += counters.RelationshipsCreated()
+	c.RelationshipsDeleted += counters.RelationshipsDeleted()
+	c.PropertiesSet += counters.PropertiesSet()
+	c.LabelsAdded += counters.LabelsAdded()
+	c.LabelsRemoved += counters.LabelsRemoved()
+	c.IndexesAdded += counters.IndexesAdded()
+	c.IndexesRemoved += counters.IndexesRemoved()
+	c.ConstraintsAdded += counters.ConstraintsAdded()
+	c.ConstraintsRemoved += counters.ConstraintsRemoved()
+}
@@ -0,0 +1,46 @@
+package driver
+
+import (
+	"testing"
+)
+
+// fakeCounters implements neo4j.Counters with fixed values
+type fakeCounters struct {
+	nodesCreated         int
+	relationshipsCreated int
+	propertiesSet        int
+}
+
+func (f fakeCounters) ContainsUpdates() bool       { return true }
+func (f fakeCounters) NodesCreated() int           { return f.nodesCreated }
+func (f fakeCounters) NodesDeleted() int           { return 0 }
+func (f fakeCounters) RelationshipsCreated() int   { return f.relationshipsCreated }
+func (f fakeCounters) RelationshipsDeleted() int   { return 0 }
+func (f fakeCounters) PropertiesSet() int          { return f.propertiesSet }
+func (f fakeCounters) LabelsAdded() int            { return 0 }
+func (f fakeCounters) LabelsRemoved() int          { return 0 }
+func (f fakeCounters) IndexesAdded() int           { return 0 }
+func (f fakeCounters) IndexesRemoved() int         { return 0 }
+func (f fakeCounters) ConstraintsAdded() int       { return 0 }
+func (f fakeCounters) ConstraintsRemoved() int     { return 0 }
+func (f fakeCounters) SystemUpdates() int          { return 0 }
+func (f fakeCounters) ContainsSystemUpdates() bool { return false }
+
+func TestSummaryCountersAdd(t *testing.T) {
+	total := &SummaryCounters{}
+	if total.ContainsUpdates() {
+		t.Error("ContainsUpdates() = true for empty counters")
+	}
+
+	total.add(fakeCounters{nodesCreated: 2, propertiesSet: 4})
+	total.add(fakeCounters{nodesCreated: 1, relationshipsCreated: 1, propertiesSet: 1})
+	total.add(nil)
+
+	want := SummaryCounters{NodesCreated: 3, RelationshipsCreated: 1, PropertiesSet: 5}
+	if *total != want {
+		t.Errorf("add() = %+v, want %+v", *total, want)
+	}
+	if !total.ContainsUpdates() {
+		t.Error("ContainsUpdates() = false after adding updates")
+	}
+}
@@ -1,25 +1,38 @@
 package cypher
 
 import (
+	"errors"
+	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/ast"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
 )
 
 func TestEmptyReturn(t *testing.T) {
 	node := ast.Node("Person").Named("p")
-	builder := Match(node).
-		Returning()
-	stmt, err := builder.Build()
-
-	// This should either error or handle gracefully
-	if err == nil {
-		cypher := stmt.Cypher()
-		// If it doesn't error, the query should still be valid
-		if !strings.Contains(cypher, "MATCH") {
-			t.Errorf("EmptyReturn query should contain MATCH")
-		}
+
+	builders := map[string]core.Buildable{
+		"after MATCH":  Match(node).Returning(),
+		"after WITH":   Match(node).With(Var("p")).Returning(),
+		"after CREATE": Create(node).Returning(),
+		"standalone":   Return(),
+	}
+	for name, builder := range builders {
+		t.Run(name, func(t *testing.T) {
+			stmt, err := builder.Build()
+			if !errors.Is(err, core.ErrEmptyReturn) {
+				t.Fatalf("Build() = %v, %v, want core.ErrEmptyReturn", stmt, err)
+			}
+			if !errors.Is(err, core.ErrInvalidQuery) {
+				t.Errorf("Build() error = %v, should also match core.ErrInvalidQuery", err)
+			}
+		})
+	}
+
+	if _, err := Match(node).Returning(Var("p"), nil).Build(); !errors.Is(err, core.ErrInvalidExpression) {
+		t.Errorf("Build() with a nil RETURN item error = %v, want core.ErrInvalidExpression", err)
 	}
 }
 
@@ -52,9 +65,20 @@ func TestNotNullHandling(t *testing.T) {
 	}
 
 	cypher := stmt.Cypher()
-	// Should contain either "IS NOT NULL" or "NOT ... IS NULL"
-	if !strings.Contains(cypher, "IS NOT NULL") && !strings.Contains(cypher, "NOT") {
-		t.Errorf("NotNullHandling query should contain 'IS NOT NULL' or 'NOT'")
+	if !strings.Contains(cypher, "IS NOT NULL") {
+		t.Errorf("NotNullHandling query should contain 'IS NOT NULL', got %q", cypher)
+	}
+
+	// Negating a null check renders the same idiomatic operator
+	stmt, err = Match(node).
+		Where(Not(node.Property("name").IsNull())).
+		Returning(node).
+		Build()
+	if err != nil {
+		t.Fatalf("NotNullHandling query Build() error = %v", err)
+	}
+	if cypher := stmt.Cypher(); !strings.Contains(cypher, "p.name IS NOT NULL") || strings.Contains(cypher, "NOT (") {
+		t.Errorf("Not(IsNull(...)) query = %q, should contain 'p.name IS NOT NULL'", cypher)
 	}
 }
 
@@ -150,6 +174,76 @@ func TestInOperator(t *testing.T) {
 	}
 }
 
+func TestInParamOperator(t *testing.T) {
+	node := ast.Node("Person").Named("p")
+	statuses := []string{"active", "pending", "inactive"}
+	stmt, err := Match(node).
+		Where(InParam(node.Property("status"), statuses)).
+		Returning(node).
+		Build()
+
+	if err != nil {
+		t.Fatalf("InParam query Build() error = %v", err)
+	}
+
+	cypher := stmt.Cypher()
+	if !strings.Contains(cypher, "p.status IN $p0") {
+		t.Errorf("InParam query = %q, should contain 'p.status IN $p0'", cypher)
+	}
+	if strings.Contains(cypher, "'active'") {
+		t.Errorf("InParam query = %q, should not inline the values", cypher)
+	}
+	if got, ok := stmt.Params()["p0"].([]string); !ok || len(got) != 3 {
+		t.Errorf("InParam query params = %v, want p0 bound to the whole slice", stmt.Params())
+	}
+}
+
+func TestInParamOnPropertiesOfTheSameName(t *testing.T) {
+	a := ast.Node("Person").Named("a")
+	b := ast.Node("Person").Named("b")
+	stmt, err := Match(a, b).
+		Where(InParam(a.Property("status"), []string{"active"}).And(InParam(b.Property("status"), []string{"banned"}))).
+		Returning(a, b).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	if !strings.Contains(stmt.Cypher(), "a.status IN $p0") || !strings.Contains(stmt.Cypher(), "b.status IN $p1") {
+		t.Errorf("Cypher() = %q, should bind each list to its own parameter", stmt.Cypher())
+	}
+	want := map[string]any{"p0": []string{"active"}, "p1": []string{"banned"}}
+	if !reflect.DeepEqual(stmt.Params(), want) {
+		t.Errorf("Params() = %v, want %v", stmt.Params(), want)
+	}
+}
+
+func TestNodeIdentityComparison(t *testing.T) {
+	a := ast.Node("Person").Named("a")
+	b := ast.Node("Person").Named("b")
+	knows := a.RelationshipBetween(b, "KNOWS")
+
+	stmt, err := Match(ast.Pattern(a, knows, b)).
+		Where(And(NotSame(a, b), Same(a, Node("Person").Named("a")))).
+		Returning(a, b).
+		Build()
+	if err != nil {
+		t.Fatalf("identity comparison Build() error = %v", err)
+	}
+	cypher := stmt.Cypher()
+	if !strings.Contains(cypher, "(a <> b)") || !strings.Contains(cypher, "(a = a)") {
+		t.Errorf("identity comparison query = %q, should compare the nodes by name", cypher)
+	}
+
+	_, err = Match(a).
+		Where(Same(a, ast.Node("Person"))).
+		Returning(a).
+		Build()
+	if !errors.Is(err, core.ErrMissingAlias) {
+		t.Errorf("Same() with an unnamed node Build() error = %v, want core.ErrMissingAlias", err)
+	}
+}
+
 func TestStringOperations(t *testing.T) {
 	node := ast.Node("Person").Named("p")
 	stmt, err := Match(node).
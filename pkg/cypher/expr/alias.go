@@ -19,9 +19,9 @@ func (a *AliasExpression) Accept(visitor core.ExpressionVisitor) any {
 	return visitor.Visit(a)
 }
 
-// quoteIdentifier quotes an identifier with backticks if it contains special characters
+// QuoteIdentifier quotes an identifier with backticks if it contains special characters
 // or if it's already quoted, returns it as-is
-func quoteIdentifier(identifier string) string {
+func QuoteIdentifier(identifier string) string {
 	// If already quoted, return as-is
 	if len(identifier) >= 2 && identifier[0] == '`' && identifier[len(identifier)-1] == '`' {
 		return identifier
@@ -50,10 +50,28 @@ func quoteIdentifier(identifier string) string {
 	return identifier
 }
 
-// String returns a string representation of this alias expression
+// String returns a string representation of this alias expression; a named
+// node or relationship is referred to by its symbolic name, e.g. n AS person
 func (a *AliasExpression) String() string {
-	quotedAlias := quoteIdentifier(a.Alias)
-	return fmt.Sprintf("%s AS %s", a.Expression.String(), quotedAlias)
+	quotedAlias := QuoteIdentifier(a.Alias)
+	return fmt.Sprintf("%s AS %s", referenceString(a.Expression), quotedAlias)
+}
+
+// Expressions returns the aliased expression, unless it is only referred to
+// by its symbolic name and so contributes no parameters
+func (a *AliasExpression) Expressions() []core.Expression {
+	if named, ok := a.Expression.(core.NamedExpression); ok && named.SymbolicName() != "" {
+		return nil
+	}
+	return []core.Expression{a.Expression}
+}
+
+// WithChildren returns the new expression under the same alias
+func (a *AliasExpression) WithChildren(children []core.Expression) core.Expression {
+	if len(children) != 1 {
+		return a
+	}
+	return &AliasExpression{Expression: children[0], Alias: a.Alias}
 }
 
 // And creates a logical AND with another expression
@@ -78,3 +96,13 @@ func As(expression core.Expression, alias string) core.Expression {
 		Alias:      alias,
 	}
 }
+
+// ProjectionItem returns what a RETURN or WITH item projects. A named node,
+// relationship or path is projected by its symbolic name, e.g. RETURN r
+// rather than the pattern -[r:KNOWS]->; other expressions are returned as is.
+func ProjectionItem(expression core.Expression) core.Expression {
+	if named, ok := expression.(core.NamedExpression); ok && named.SymbolicName() != "" {
+		return NewVariableExpression(named.SymbolicName())
+	}
+	return expression
+}
@@ -2,6 +2,8 @@ package expr
 
 import (
 	"testing"
+
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
 )
 
 func TestAs(t *testing.T) {
@@ -70,3 +72,30 @@ func TestAliasExpressionLogicalOps(t *testing.T) {
 }
 
 
+
+func TestFluentAs(t *testing.T) {
+	property := NewProperty(NewVariableExpression("p"), "name")
+	comparison := GreaterThan(NewProperty(NewVariableExpression("p"), "age"), Integer(18)).(*ComparisonExpression)
+	binary := Concat(property, String("!")).(*BinaryExpression)
+
+	tests := []struct {
+		name    string
+		aliased core.Expression
+		want    string
+	}{
+		{"property", property.As("name"), "p.name AS name"},
+		{"comparison", comparison.As("adult"), "(p.age > 18) AS adult"},
+		{"binary", binary.As("greeting"), "(p.name + '!') AS greeting"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := tt.aliased.(*AliasExpression); !ok {
+				t.Fatalf("As() returned %T, want *AliasExpression", tt.aliased)
+			}
+			if got := tt.aliased.String(); got != tt.want {
+				t.Errorf("As() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package expr
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+)
+
+// AssignmentExpression represents a SET item (e.g., n.name = $name or n += $props)
+type AssignmentExpression struct {
+	Target   core.Expression
+	Value    core.Expression
+	Operator string
+}
+
+// Accept implements the Expression interface
+func (a *AssignmentExpression) Accept(visitor core.ExpressionVisitor) any {
+	return visitor.Visit(a)
+}
+
+// String returns a string representation of this assignment
+func (a *AssignmentExpression) String() string {
+	return fmt.Sprintf("%s %s %s", referenceString(a.Target), a.Operator, a.Value.String())
+}
+
+// Left returns the target of the assignment
+func (a *AssignmentExpression) Left() core.Expression {
+	return a.Target
+}
+
+// Right returns the assigned value
+func (a *AssignmentExpression) Right() core.Expression {
+	return a.Value
+}
+
+// WithChildren returns a copy of this assignment with a new target and value
+func (a *AssignmentExpression) WithChildren(children []core.Expression) core.Expression {
+	if len(children) != 2 {
+		return a
+	}
+	return &AssignmentExpression{Target: children[0], Value: children[1], Operator: a.Operator}
+}
+
+// Validate reports an error when the target is a node or relationship without an alias
+func (a *AssignmentExpression) Validate() error {
+	return validateTarget(a.Target)
+}
+
+// And creates a logical AND with another expression
+func (a *AssignmentExpression) And(other core.Expression) core.Expression {
+	return And(a, other)
+}
+
+// Or creates a logical OR with another expression
+func (a *AssignmentExpression) Or(other core.Expression) core.Expression {
+	return Or(a, other)
+}
+
+// Not creates a logical NOT of this expression
+func (a *AssignmentExpression) Not() core.Expression {
+	return Not(a)
+}
+
+// SetProperty creates a SET item that assigns a value to a property (n.name = $name).
+// Values that are not expressions are converted with LiteralFromValue.
+func SetProperty(property core.Expression, value any) core.Expression {
+	return &AssignmentExpression{
+		Target:   property,
+		Value:    LiteralFromValue(value),
+		Operator: "=",
+	}
+}
+
+// SetMutate creates a SET item that merges a map into the properties of a
+// node or relationship, keeping the properties not in the map (n += $props)
+func SetMutate(target core.Expression, value core.Expression) core.Expression {
+	return &AssignmentExpression{
+		Target:   target,
+		Value:    value,
+		Operator: "+=",
+	}
+}
+
+// LabelsExpression represents labels of a node (e.g., n:Active:Verified), either
+// as a SET item that adds them or as a WHERE predicate that checks for them
+type LabelsExpression struct {
+	Target core.Expression
+	Labels []string
+}
+
+// Accept implements the Expression interface
+func (l *LabelsExpression) Accept(visitor core.ExpressionVisitor) any {
+	return visitor.Visit(l)
+}
+
+// String returns a string representation of this label item
+func (l *LabelsExpression) String() string {
+	var sb strings.Builder
+	sb.WriteString(referenceString(l.Target))
+	for _, label := range l.Labels {
+		sb.WriteString(":")
+		sb.WriteString(QuoteIdentifier(label))
+	}
+	return sb.String()
+}
+
+// Validate reports an error when the target is a node without an alias or no label is given
+func (l *LabelsExpression) Validate() error {
+	if len(l.Labels) == 0 {
+		return core.NewError(core.ErrNoLabels, "a label expression requires at least one label")
+	}
+	return validateTarget(l.Target)
+}
+
+// And creates a logical AND with another expression
+func (l *LabelsExpression) And(other core.Expression) core.Expression {
+	return And(l, other)
+}
+
+// Or creates a logical OR with another expression
+func (l *LabelsExpression) Or(other core.Expression) core.Expression {
+	return Or(l, other)
+}
+
+// Not creates a logical NOT of this expression
+func (l *LabelsExpression) Not() core.Expression {
+	return Not(l)
+}
+
+// SetLabels creates a SET item that adds labels to a node, e.g.
+// MERGE ... ON CREATE SET n:New
+func SetLabels(target core.Expression, labels ...string) core.Expression {
+	return &LabelsExpression{
+		Target: target,
+		Labels: labels,
+	}
+}
+
+// HasLabel creates a predicate that holds when the node has all the given
+// labels, e.g. WHERE n:Person or WHERE NOT n:Archived
+func HasLabel(node core.Expression, labels ...string) core.Expression {
+	return &LabelsExpression{
+		Target: node,
+		Labels: labels,
+	}
+}
+
+// SetItem returns an expression as an item of a SET clause. Property
+// assignments (SetProperty, SetMutate), label items (SetLabels) and raw Cypher
+// are items already. An equality comparison such as n.age = 30 is turned into
+// the assignment it describes, so that it renders without the parentheses of
+// a comparison. Any other expression cannot be set and yields an error.
+func SetItem(expression core.Expression) (core.Expression, error) {
+	switch e := expression.(type) {
+	case *AssignmentExpression, *LabelsExpression, *RawCypherExpression, *FragmentExpression:
+		return e, nil
+	case *ComparisonExpression:
+		if e.operator == string(EQ) {
+			return &AssignmentExpression{Target: e.left, Value: e.right, Operator: "="}, nil
+		}
+	}
+	return nil, core.NewError(core.ErrInvalidExpression,
+		fmt.Sprintf("%v is not a SET item; use SetProperty, SetMutate or SetLabels", expression))
+}
+
+// validateTarget reports an error when a SET target is a node or relationship without an alias
+func validateTarget(target core.Expression) error {
+	if named, ok := target.(core.NamedExpression); ok && named.SymbolicName() == "" {
+		return core.NewError(core.ErrMissingAlias,
+			fmt.Sprintf("cannot assign to %s without an alias; use Named()", target.String()))
+	}
+	return nil
+}
@@ -41,6 +41,14 @@ func (c *ComparisonExpression) Right() core.Expression {
 	return c.right
 }
 
+// WithChildren returns a copy of this comparison with new sides
+func (c *ComparisonExpression) WithChildren(children []core.Expression) core.Expression {
+	if len(children) != 2 {
+		return c
+	}
+	return &ComparisonExpression{left: children[0], right: children[1], operator: c.operator}
+}
+
 // Accept implements the Expression interface
 func (c *ComparisonExpression) Accept(visitor core.ExpressionVisitor) any {
 	return visitor.Visit(c)
@@ -66,13 +74,18 @@ func (c *ComparisonExpression) Not() core.Expression {
 	return Not(c)
 }
 
-// Xor creates a logical XOR with another expression
-func (c *ComparisonExpression) Xor(other core.Expression) core.Expression {
-	return Xor(c, other)
+// Xor creates a logical XOR with another boolean expression
+func (c *ComparisonExpression) Xor(other core.BooleanExpression) core.BooleanExpression {
+	return Xor(c, other).(core.BooleanExpression)
+}
+
+// As creates an alias for this comparison expression
+func (c *ComparisonExpression) As(alias string) core.Expression {
+	return As(c, alias)
 }
 
 // Equals creates an equality comparison
-func Equals(left, right core.Expression) core.Expression {
+func Equals(left, right core.Expression) core.AliasableExpression {
 	return &ComparisonExpression{
 		left:     left,
 		right:    right,
@@ -81,7 +94,7 @@ func Equals(left, right core.Expression) core.Expression {
 }
 
 // NotEquals creates a not-equal comparison
-func NotEquals(left, right core.Expression) core.Expression {
+func NotEquals(left, right core.Expression) core.AliasableExpression {
 	return &ComparisonExpression{
 		left:     left,
 		right:    right,
@@ -90,7 +103,7 @@ func NotEquals(left, right core.Expression) core.Expression {
 }
 
 // GreaterThan creates a greater-than comparison
-func GreaterThan(left, right core.Expression) core.Expression {
+func GreaterThan(left, right core.Expression) core.AliasableExpression {
 	return &ComparisonExpression{
 		left:     left,
 		right:    right,
@@ -99,7 +112,7 @@ func GreaterThan(left, right core.Expression) core.Expression {
 }
 
 // LessThan creates a less-than comparison
-func LessThan(left, right core.Expression) core.Expression {
+func LessThan(left, right core.Expression) core.AliasableExpression {
 	return &ComparisonExpression{
 		left:     left,
 		right:    right,
@@ -108,7 +121,7 @@ func LessThan(left, right core.Expression) core.Expression {
 }
 
 // GreaterThanEqual creates a greater-than-or-equal comparison
-func GreaterThanEqual(left, right core.Expression) core.Expression {
+func GreaterThanEqual(left, right core.Expression) core.AliasableExpression {
 	return &ComparisonExpression{
 		left:     left,
 		right:    right,
@@ -117,7 +130,7 @@ func GreaterThanEqual(left, right core.Expression) core.Expression {
 }
 
 // LessThanEqual creates a less-than-or-equal comparison
-func LessThanEqual(left, right core.Expression) core.Expression {
+func LessThanEqual(left, right core.Expression) core.AliasableExpression {
 	return &ComparisonExpression{
 		left:     left,
 		right:    right,
@@ -126,7 +139,7 @@ func LessThanEqual(left, right core.Expression) core.Expression {
 }
 
 // IsNull creates a null check
-func IsNull(expr core.Expression) core.Expression {
+func IsNull(expr core.Expression) core.AliasableExpression {
 	return &ComparisonExpression{
 		left:     expr,
 		right:    &NullLiteral{},
@@ -135,7 +148,7 @@ func IsNull(expr core.Expression) core.Expression {
 }
 
 // IsNotNull creates a not-null check
-func IsNotNull(expr core.Expression) core.Expression {
+func IsNotNull(expr core.Expression) core.AliasableExpression {
 	return &ComparisonExpression{
 		left:     expr,
 		right:    &NullLiteral{},
@@ -143,8 +156,18 @@ func IsNotNull(expr core.Expression) core.Expression {
 	}
 }
 
-// In creates an IN comparison
-func In(expr core.Expression, values ...any) core.Expression {
+// NullSafeEquals creates an equality that also holds when both sides are
+// null, rendered as ((a = b) OR ((a IS NULL) AND (b IS NULL))). Each side
+// appears twice, so a parameter on either side is bound once and referenced twice.
+func NullSafeEquals(left, right core.Expression) core.AliasableExpression {
+	return Or(Equals(left, right), And(IsNull(left), IsNull(right))).(*LogicalExpression)
+}
+
+// In creates an IN comparison with the values inlined as a list literal,
+// e.g. n.status IN ['active', 'pending']. Every distinct list produces a
+// different query text, which defeats Neo4j's query plan cache; use InParam
+// when the list varies between executions.
+func In(expr core.Expression, values ...any) core.AliasableExpression {
 	var elements []core.Expression
 	for _, v := range values {
 		if expr, ok := v.(core.Expression); ok {
@@ -161,8 +184,98 @@ func In(expr core.Expression, values ...any) core.Expression {
 	}
 }
 
+// InParam creates an IN comparison against a single list parameter,
+// e.g. n.status IN $p0, binding the whole slice as the parameter value.
+// The parameter is unnamed, so it is numbered when the statement is built and
+// two lists compared against properties of the same name don't collide. To
+// choose the name, pass a named parameter holding the list as the value.
+func InParam(expr core.Expression, value any) core.AliasableExpression {
+	param, ok := value.(*core.ParameterExpression)
+	if !ok {
+		param = core.NewParameter("", value)
+	}
+	return &ComparisonExpression{
+		left:     expr,
+		right:    param,
+		operator: "IN",
+	}
+}
+
+// Same creates an identity comparison between two bound nodes or
+// relationships, rendered with their symbolic names (a = b). Both sides
+// must be named; building a clause with an unnamed side fails with
+// core.ErrMissingAlias.
+func Same(left, right core.Expression) core.AliasableExpression {
+	return &ComparisonExpression{
+		left:     &identityReference{target: left},
+		right:    &identityReference{target: right},
+		operator: "=",
+	}
+}
+
+// NotSame creates a negated identity comparison (a <> b), see Same
+func NotSame(left, right core.Expression) core.AliasableExpression {
+	return &ComparisonExpression{
+		left:     &identityReference{target: left},
+		right:    &identityReference{target: right},
+		operator: "<>",
+	}
+}
+
+// identityReference refers to a node or relationship by its symbolic name
+type identityReference struct {
+	target core.Expression
+}
+
+// Accept implements the Expression interface
+func (i *identityReference) Accept(visitor core.ExpressionVisitor) any {
+	return visitor.Visit(i)
+}
+
+// String returns the symbolic name of the target
+func (i *identityReference) String() string {
+	if named, ok := i.target.(core.NamedExpression); ok && named.SymbolicName() != "" {
+		return named.SymbolicName()
+	}
+	if i.target == nil {
+		return ""
+	}
+	return i.target.String()
+}
+
+// Validate reports an error when the target has no symbolic name
+func (i *identityReference) Validate() error {
+	switch target := i.target.(type) {
+	case core.NamedExpression:
+		if target.SymbolicName() != "" {
+			return nil
+		}
+	case *VariableExpression:
+		if target.Name() != "" {
+			return nil
+		}
+	}
+	return core.NewError(core.ErrMissingAlias,
+		fmt.Sprintf("identity comparison requires a named node or relationship, got %s", i.String()))
+}
+
+// And creates a logical AND with another expression
+func (i *identityReference) And(other core.Expression) core.Expression {
+	return And(i, other)
+}
+
+// Or creates a logical OR with another expression
+func (i *identityReference) Or(other core.Expression) core.Expression {
+	return Or(i, other)
+}
+
+// Not creates a logical NOT of this expression
+func (i *identityReference) Not() core.Expression {
+	return Not(i)
+}
+
 // Contains creates a CONTAINS comparison
-func Contains(expr core.Expression, value string) core.Expression {
+func Contains(expr core.Expression, value string) core.AliasableExpression {
 	return &ComparisonExpression{
 		left:     expr,
 		right:    String(value),
@@ -171,7 +284,7 @@ func Contains(expr core.Expression, value string) core.Expression {
 }
 
 // StartsWith creates a STARTS WITH comparison
-func StartsWith(expr core.Expression, value string) core.Expression {
+func StartsWith(expr core.Expression, value string) core.AliasableExpression {
 	return &ComparisonExpression{
 		left:     expr,
 		right:    String(value),
@@ -180,7 +293,7 @@ func StartsWith(expr core.Expression, value string) core.Expression {
 }
 
 // EndsWith creates an ENDS WITH comparison
-func EndsWith(expr core.Expression, value string) core.Expression {
+func EndsWith(expr core.Expression, value string) core.AliasableExpression {
 	return &ComparisonExpression{
 		left:     expr,
 		right:    String(value),
@@ -189,7 +302,7 @@ func EndsWith(expr core.Expression, value string) core.Expression {
 }
 
 // RegularExpression creates a =~ comparison with a regular expression
-func RegularExpression(expr core.Expression, pattern string) core.Expression {
+func RegularExpression(expr core.Expression, pattern string) core.AliasableExpression {
 	return &ComparisonExpression{
 		left:     expr,
 		right:    String(pattern),
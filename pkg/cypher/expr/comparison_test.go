@@ -1,7 +1,10 @@
 package expr
 
 import (
+	"reflect"
 	"testing"
+
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
 )
 
 func TestEquals(t *testing.T) {
@@ -103,6 +106,31 @@ func TestIn(t *testing.T) {
 	}
 }
 
+func TestInParam(t *testing.T) {
+	property := NewProperty(NewVariableExpression("n"), "status")
+	inExpr := InParam(property, []string{"active", "pending"}).(*ComparisonExpression)
+
+	param, ok := inExpr.right.(*core.ParameterExpression)
+	if !ok || param.Name() != "" || !reflect.DeepEqual(param.Value(), []string{"active", "pending"}) {
+		t.Errorf("InParam(...) right = %v, want an unnamed parameter bound to the whole slice", inExpr.right)
+	}
+	if got := InParam(property, core.NewParameter("statuses", []int{1})).String(); got != "(n.status IN $statuses)" {
+		t.Errorf("InParam(..., named).String() = %q, want '(n.status IN $statuses)'", got)
+	}
+}
+
+func TestSame(t *testing.T) {
+	a := NewVariableExpression("a")
+	b := NewVariableExpression("b")
+
+	if got := Same(a, b).String(); got != "(a = b)" {
+		t.Errorf("Same(a, b).String() = %q, want '(a = b)'", got)
+	}
+	if got := NotSame(a, b).String(); got != "(a <> b)" {
+		t.Errorf("NotSame(a, b).String() = %q, want '(a <> b)'", got)
+	}
+}
+
 func TestContains(t *testing.T) {
 	expr := Property("n", "name")
 	containsExpr := Contains(expr, "test")
@@ -169,7 +197,7 @@ func TestComparisonLogicalOps(t *testing.T) {
 
 	// Test Xor - only works on ComparisonExpression
 	if compExpr, ok := comp.(*ComparisonExpression); ok {
-		xorExpr := compExpr.Xor(other)
+		xorExpr := compExpr.Xor(other.(*ComparisonExpression))
 		if xorExpr == nil {
 			t.Error("comp.Xor(other) returned nil")
 		}
@@ -177,3 +205,27 @@ func TestComparisonLogicalOps(t *testing.T) {
 }
 
 
+
+func TestIsType(t *testing.T) {
+	age := NewProperty(NewVariableExpression("n"), "age")
+	if got := IsType(age, "INTEGER NOT NULL").String(); got != "(n.age IS :: INTEGER NOT NULL)" {
+		t.Errorf("IsType(...).String() = %q, want '(n.age IS :: INTEGER NOT NULL)'", got)
+	}
+	if got := age.IsType("STRING").String(); got != "(n.age IS :: STRING)" {
+		t.Errorf("age.IsType(...).String() = %q, want '(n.age IS :: STRING)'", got)
+	}
+
+	for _, typeName := range []string{
+		"INTEGER", "integer", "INTEGER!", "ZONED DATETIME", "INTEGER | FLOAT",
+		"LIST<STRING NOT NULL> NOT NULL", "ANY<BOOLEAN | STRING>", "PROPERTY VALUE",
+	} {
+		if err := IsType(age, typeName).(*TypePredicateExpression).Validate(); err != nil {
+			t.Errorf("IsType(%q).Validate() error = %v", typeName, err)
+		}
+	}
+	for _, typeName := range []string{"", "INTEGR", "LIST<FOO>", "INTEGER |", "LIST<STRING"} {
+		if err := IsType(age, typeName).(*TypePredicateExpression).Validate(); err == nil {
+			t.Errorf("IsType(%q).Validate() should fail", typeName)
+		}
+	}
+}
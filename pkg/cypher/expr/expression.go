@@ -19,6 +19,11 @@ func (l *Literal) String() string {
 	return formatValue(l.Value)
 }
 
+// LiteralValue returns the value this literal inlines in the query
+func (l *Literal) LiteralValue() any {
+	return l.Value
+}
+
 // And creates a logical AND with another expression
 func (l *Literal) And(other core.Expression) core.Expression {
 	return And(l, other)
@@ -34,7 +39,9 @@ func (l *Literal) Not() core.Expression {
 	return Not(l)
 }
 
-// Var represents a variable (symbolic name) in Cypher
+// Var represents a variable (symbolic name) in Cypher.
+//
+// Deprecated: use NewVariableExpression, or cypher.Var from the root package.
 type Var struct {
 	Name string
 }
@@ -59,6 +66,11 @@ func (v *Var) Or(other core.Expression) core.Expression {
 	return Or(v, other)
 }
 
+// Xor creates a logical XOR with another expression
+func (v *Var) Xor(other core.Expression) core.Expression {
+	return Xor(v, other)
+}
+
 // Not creates a logical NOT of this expression
 func (v *Var) Not() core.Expression {
 	return Not(v)
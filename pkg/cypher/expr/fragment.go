@@ -0,0 +1,81 @@
+package expr
+
+import (
+	"sort"
+
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+)
+
+// FragmentExpression is a reusable part of a query, such as a WHERE condition
+// or a pattern, together with the parameters it references. A fragment
+// renders as its expression and contributes its parameters to every
+// statement it is used in.
+type FragmentExpression struct {
+	Expression core.Expression
+	Params     map[string]any
+}
+
+// Accept implements the Expression interface
+func (f *FragmentExpression) Accept(visitor core.ExpressionVisitor) any {
+	return visitor.Visit(f)
+}
+
+// String returns the string representation of the wrapped expression
+func (f *FragmentExpression) String() string {
+	if f.Expression == nil {
+		return ""
+	}
+	return f.Expression.String()
+}
+
+// Expressions returns the wrapped expression followed by the bound parameters
+// of the fragment, sorted by name
+func (f *FragmentExpression) Expressions() []core.Expression {
+	names := make([]string, 0, len(f.Params))
+	for name := range f.Params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]core.Expression, 0, len(names)+1)
+	if f.Expression != nil {
+		result = append(result, f.Expression)
+	}
+	for _, name := range names {
+		result = append(result, core.NewParameter(name, f.Params[name]))
+	}
+	return result
+}
+
+// WithParam returns a copy of the fragment with an additional bound parameter
+func (f *FragmentExpression) WithParam(name string, value any) *FragmentExpression {
+	params := make(map[string]any, len(f.Params)+1)
+	for k, v := range f.Params {
+		params[k] = v
+	}
+	params[name] = value
+	return &FragmentExpression{Expression: f.Expression, Params: params}
+}
+
+// And creates a logical AND with another expression
+func (f *FragmentExpression) And(other core.Expression) core.Expression {
+	return And(f, other)
+}
+
+// Or creates a logical OR with another expression
+func (f *FragmentExpression) Or(other core.Expression) core.Expression {
+	return Or(f, other)
+}
+
+// Not creates a logical NOT of this expression
+func (f *FragmentExpression) Not() core.Expression {
+	return Not(f)
+}
+
+// Fragment wraps an expression and the parameters it references so that it
+// can be defined once and used in several queries. Since a fragment is
+// shared, the parameters inside it should be named; unnamed parameters keep
+// the name they get in the first statement built with them.
+func Fragment(expression core.Expression, params map[string]any) *FragmentExpression {
+	return &FragmentExpression{Expression: expression, Params: params}
+}
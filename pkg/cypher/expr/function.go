@@ -2,6 +2,7 @@ package expr
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
@@ -27,12 +28,25 @@ func (f *FunctionExpression) String() string {
 		if i > 0 {
 			sb.WriteString(", ")
 		}
-		sb.WriteString(arg.String())
+		sb.WriteString(referenceString(arg))
 	}
 	sb.WriteString(")")
 	return sb.String()
 }
 
+// Expressions returns the arguments of this function expression
+func (f *FunctionExpression) Expressions() []core.Expression {
+	return f.Arguments
+}
+
+// WithChildren returns a call of the same function with new arguments
+func (f *FunctionExpression) WithChildren(children []core.Expression) core.Expression {
+	if len(children) != len(f.Arguments) {
+		return f
+	}
+	return &FunctionExpression{Name: f.Name, Arguments: children}
+}
+
 // And creates a logical AND with another expression
 func (f *FunctionExpression) And(other core.Expression) core.Expression {
 	return And(f, other)
@@ -65,7 +79,20 @@ func (d *DistinctExpression) Accept(visitor core.ExpressionVisitor) any {
 
 // String returns a string representation of this distinct expression
 func (d *DistinctExpression) String() string {
-	return "DISTINCT " + d.Expression.String()
+	return "DISTINCT " + referenceString(d.Expression)
+}
+
+// Expressions returns the expression wrapped with DISTINCT
+func (d *DistinctExpression) Expressions() []core.Expression {
+	return []core.Expression{d.Expression}
+}
+
+// WithChildren returns DISTINCT of the new expression
+func (d *DistinctExpression) WithChildren(children []core.Expression) core.Expression {
+	if len(children) != 1 {
+		return d
+	}
+	return &DistinctExpression{Expression: children[0]}
 }
 
 // And creates a logical AND with another expression
@@ -107,7 +134,7 @@ func (f *FunctionExpression) Distinct() core.Expression {
 }
 
 // Function creates a new function expression
-func Function(name string, args ...core.Expression) core.Expression {
+func Function(name string, args ...core.Expression) core.AliasableExpression {
 	return &FunctionExpression{
 		Name:      name,
 		Arguments: args,
@@ -115,43 +142,131 @@ func Function(name string, args ...core.Expression) core.Expression {
 }
 
 // Count creates a COUNT function expression
-func Count(expr core.Expression) core.Expression {
+func Count(expr core.Expression) core.AliasableExpression {
 	return Function("count", expr)
 }
 
 // CountStar creates a COUNT(*) function expression
-func CountStar() core.Expression {
+func CountStar() core.AliasableExpression {
 	return &FunctionExpression{
 		Name:      "count",
-		Arguments: []core.Expression{&Literal{Value: "*"}},
+		Arguments: []core.Expression{&RawCypherExpression{Cypher: "*"}},
 	}
 }
 
 // Sum creates a SUM function expression
-func Sum(expr core.Expression) core.Expression {
+func Sum(expr core.Expression) core.AliasableExpression {
 	return Function("sum", expr)
 }
 
 // Avg creates an AVG function expression
-func Avg(expr core.Expression) core.Expression {
+func Avg(expr core.Expression) core.AliasableExpression {
 	return Function("avg", expr)
 }
 
 // Min creates a MIN function expression
-func Min(expr core.Expression) core.Expression {
+func Min(expr core.Expression) core.AliasableExpression {
 	return Function("min", expr)
 }
 
 // Max creates a MAX function expression
-func Max(expr core.Expression) core.Expression {
+func Max(expr core.Expression) core.AliasableExpression {
 	return Function("max", expr)
 }
 
 // Collect creates a COLLECT function expression
-func Collect(expr core.Expression) core.Expression {
+func Collect(expr core.Expression) core.AliasableExpression {
 	return Function("collect", expr)
 }
 
+// StDev creates a stDev function expression (standard deviation of a sample)
+func StDev(expr core.Expression) core.AliasableExpression {
+	return Function("stDev", expr)
+}
+
+// StDevP creates a stDevP function expression (standard deviation of a population)
+func StDevP(expr core.Expression) core.AliasableExpression {
+	return Function("stDevP", expr)
+}
+
+// Length creates a length function expression, the number of relationships
+// of a path, e.g. length(p)
+func Length(path core.Expression) core.AliasableExpression {
+	return Function("length", path)
+}
+
+// PercentileCont creates a percentileCont function expression, e.g.
+// percentileCont(n.age, 0.95). The percentile must be a number between 0 and
+// 1 or a parameter; anything else fails the build with ErrInvalidExpression.
+func PercentileCont(expr, percentile core.Expression) core.AliasableExpression {
+	return Function("percentileCont", expr, &percentileArgument{value: percentile})
+}
+
+// PercentileDisc creates a percentileDisc function expression; the
+// percentile is checked like in PercentileCont
+func PercentileDisc(expr, percentile core.Expression) core.AliasableExpression {
+	return Function("percentileDisc", expr, &percentileArgument{value: percentile})
+}
+
+// percentileArgument is the percentile of percentileCont and percentileDisc
+type percentileArgument struct {
+	value core.Expression
+}
+
+// Accept implements the Expression interface
+func (p *percentileArgument) Accept(visitor core.ExpressionVisitor) any {
+	return visitor.Visit(p)
+}
+
+// String returns the percentile as it is rendered
+func (p *percentileArgument) String() string {
+	if p.value == nil {
+		return ""
+	}
+	return p.value.String()
+}
+
+// Expressions returns the percentile so that a parameter is collected
+func (p *percentileArgument) Expressions() []core.Expression {
+	return []core.Expression{p.value}
+}
+
+// Validate reports an error unless the percentile is a parameter or a number between 0 and 1
+func (p *percentileArgument) Validate() error {
+	var number float64
+	switch v := p.value.(type) {
+	case *core.ParameterExpression, *ParameterExpression:
+		return nil
+	case *IntegerLiteral:
+		number = float64(v.Value)
+	case *FloatLiteral:
+		number = v.Value
+	default:
+		return core.NewError(core.ErrInvalidExpression,
+			fmt.Sprintf("percentile must be a numeric literal or a parameter, got %v", p.value))
+	}
+	if number < 0 || number > 1 {
+		return core.NewError(core.ErrInvalidExpression,
+			fmt.Sprintf("percentile must be between 0 and 1, got %v", number))
+	}
+	return nil
+}
+
+// And creates a logical AND with another expression
+func (p *percentileArgument) And(other core.Expression) core.Expression {
+	return And(p, other)
+}
+
+// Or creates a logical OR with another expression
+func (p *percentileArgument) Or(other core.Expression) core.Expression {
+	return Or(p, other)
+}
+
+// Not creates a logical NOT of this expression
+func (p *percentileArgument) Not() core.Expression {
+	return Not(p)
+}
+
 // BinaryExpression represents a binary operation (e.g., a + b)
 type BinaryExpression struct {
 	Left     core.Expression
@@ -166,7 +281,25 @@ func (b *BinaryExpression) Accept(visitor core.ExpressionVisitor) any {
 
 // String returns a string representation of this binary expression
 func (b *BinaryExpression) String() string {
-	return fmt.Sprintf("(%s %s %s)", b.Left.String(), b.Operator, b.Right.String())
+	right := b.Right.String()
+	if strings.HasPrefix(right, "-") {
+		// Keep a negative operand apart from the operator: a - (-1), not a - -1
+		right = "(" + right + ")"
+	}
+	return fmt.Sprintf("(%s %s %s)", b.Left.String(), b.Operator, right)
+}
+
+// Expressions returns the operands of this binary expression
+func (b *BinaryExpression) Expressions() []core.Expression {
+	return []core.Expression{b.Left, b.Right}
+}
+
+// WithChildren returns a copy of this binary expression with new operands
+func (b *BinaryExpression) WithChildren(children []core.Expression) core.Expression {
+	if len(children) != 2 {
+		return b
+	}
+	return &BinaryExpression{Left: children[0], Right: children[1], Operator: b.Operator}
 }
 
 // And creates a logical AND with another expression
@@ -184,6 +317,11 @@ func (b *BinaryExpression) Not() core.Expression {
 	return Not(b)
 }
 
+// As creates an alias for this binary expression
+func (b *BinaryExpression) As(alias string) core.Expression {
+	return As(b, alias)
+}
+
 // Concat concatenates multiple string expressions using the + operator
 // This chains expressions: expr1 + expr2 + expr3 + ...
 func Concat(expressions ...core.Expression) core.Expression {
@@ -211,7 +349,7 @@ func Concat(expressions ...core.Expression) core.Expression {
 
 // Substring creates a SUBSTRING function expression
 // substring(expr, start [, length])
-func Substring(expr core.Expression, start core.Expression, length ...core.Expression) core.Expression {
+func Substring(expr core.Expression, start core.Expression, length ...core.Expression) core.AliasableExpression {
 	args := []core.Expression{expr, start}
 	if len(length) > 0 {
 		args = append(args, length[0])
@@ -221,45 +359,70 @@ func Substring(expr core.Expression, start core.Expression, length ...core.Expre
 
 // Replace creates a REPLACE function expression
 // replace(expr, search, replace)
-func Replace(expr, search, replace core.Expression) core.Expression {
+func Replace(expr, search, replace core.Expression) core.AliasableExpression {
 	return Function("replace", expr, search, replace)
 }
 
 // Split creates a SPLIT function expression
 // split(expr, delimiter)
-func Split(expr, delimiter core.Expression) core.Expression {
+func Split(expr, delimiter core.Expression) core.AliasableExpression {
 	return Function("split", expr, delimiter)
 }
 
 // ToLower creates a toLower function expression
-func ToLower(expr core.Expression) core.Expression {
+func ToLower(expr core.Expression) core.AliasableExpression {
 	return Function("toLower", expr)
 }
 
 // ToUpper creates a toUpper function expression
-func ToUpper(expr core.Expression) core.Expression {
+func ToUpper(expr core.Expression) core.AliasableExpression {
 	return Function("toUpper", expr)
 }
 
 // Trim creates a TRIM function expression
-func Trim(expr core.Expression) core.Expression {
+func Trim(expr core.Expression) core.AliasableExpression {
 	return Function("trim", expr)
 }
 
 // LTrim creates a lTrim function expression
-func LTrim(expr core.Expression) core.Expression {
+func LTrim(expr core.Expression) core.AliasableExpression {
 	return Function("lTrim", expr)
 }
 
 // RTrim creates a rTrim function expression
-func RTrim(expr core.Expression) core.Expression {
+func RTrim(expr core.Expression) core.AliasableExpression {
 	return Function("rTrim", expr)
 }
 
+// Point creates a point() function expression from a map of coordinates,
+// such as Map({latitude: ..., longitude: ...}) or a map parameter
+func Point(coordinates core.Expression) core.AliasableExpression {
+	return Function("point", coordinates)
+}
+
+// Distance creates a point.distance() function expression that returns the
+// distance between two points
+func Distance(a, b core.Expression) core.AliasableExpression {
+	return Function("point.distance", a, b)
+}
+
+// Timestamp creates a timestamp() function expression, which returns the
+// current time in milliseconds since the epoch
+func Timestamp() core.AliasableExpression {
+	return Function("timestamp")
+}
+
+// DurationBetween creates a duration.between() function expression that
+// returns the duration between two temporal values
+func DurationBetween(a, b core.Expression) core.AliasableExpression {
+	return Function("duration.between", a, b)
+}
+
 // RawCypherExpression represents a raw Cypher string that will be inserted as-is
 // WARNING: Use with caution to avoid Cypher injection vulnerabilities
 type RawCypherExpression struct {
 	Cypher string
+	Params map[string]any // parameters referenced by the fragment, e.g. $threshold
 }
 
 // Accept implements the Expression interface
@@ -272,6 +435,22 @@ func (r *RawCypherExpression) String() string {
 	return r.Cypher
 }
 
+// Expressions returns the bound parameters of the fragment so that
+// builders extract them into the statement's parameter map
+func (r *RawCypherExpression) Expressions() []core.Expression {
+	names := make([]string, 0, len(r.Params))
+	for name := range r.Params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]core.Expression, len(names))
+	for i, name := range names {
+		result[i] = core.NewParameter(name, r.Params[name])
+	}
+	return result
+}
+
 // And creates a logical AND with another expression
 func (r *RawCypherExpression) And(other core.Expression) core.Expression {
 	return And(r, other)
@@ -293,3 +472,10 @@ func (r *RawCypherExpression) Not() core.Expression {
 func RawCypher(cypher string) core.Expression {
 	return &RawCypherExpression{Cypher: cypher}
 }
+
+// RawCypherWithParams creates a raw Cypher expression whose values are passed
+// as parameters instead of being inlined, e.g. RawCypherWithParams("n.x = $threshold",
+// map[string]any{"threshold": 5}). The fragment itself is still inserted as-is.
+func RawCypherWithParams(cypher string, params map[string]any) core.Expression {
+	return &RawCypherExpression{Cypher: cypher, Params: params}
+}
@@ -51,8 +51,8 @@ func TestCountStar(t *testing.T) {
 	if !containsString(result, "count") {
 		t.Errorf("CountStar().String() = %q, should contain 'count'", result)
 	}
-	if !containsString(result, "*") {
-		t.Errorf("CountStar().String() = %q, should contain '*'", result)
+	if result != "count(*)" {
+		t.Errorf("CountStar().String() = %q, want 'count(*)'", result)
 	}
 }
 
@@ -165,3 +165,51 @@ func TestFunctionLogicalOps(t *testing.T) {
 }
 
 
+
+func TestBinaryExpressionNegativeOperand(t *testing.T) {
+	property := NewProperty(NewVariableExpression("n"), "score")
+	tests := []struct {
+		name     string
+		expr     core.Expression
+		expected string
+	}{
+		{"negative integer", &BinaryExpression{Left: property, Operator: "-", Right: Integer(-1)}, "(n.score - (-1))"},
+		{"negative float", &BinaryExpression{Left: property, Operator: "-", Right: Float(-1.5e18)}, "(n.score - (-1.5e18))"},
+		{"positive operand", &BinaryExpression{Left: property, Operator: "-", Right: Float(1e-9)}, "(n.score - 1e-9)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.expr.String(); result != tt.expected {
+				t.Errorf("String() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPointAndDistance(t *testing.T) {
+	point := Point(Map(map[string]core.Expression{
+		"latitude":  core.NewParameter("lat", 52.5),
+		"longitude": core.NewParameter("lon", 13.4),
+	}))
+	if got, want := point.String(), "point({latitude: $lat, longitude: $lon})"; got != want {
+		t.Errorf("Point().String() = %q, want %q", got, want)
+	}
+
+	distance := Distance(NewProperty(NewVariableExpression("n"), "location"), point)
+	if got, want := distance.String(), "point.distance(n.location, point({latitude: $lat, longitude: $lon}))"; got != want {
+		t.Errorf("Distance().String() = %q, want %q", got, want)
+	}
+}
+
+func TestTemporalFunctions(t *testing.T) {
+	if got, want := Timestamp().String(), "timestamp()"; got != want {
+		t.Errorf("Timestamp().String() = %q, want %q", got, want)
+	}
+
+	born := NewProperty(NewVariableExpression("p"), "born")
+	between := DurationBetween(born, Function("date"))
+	if got, want := between.String(), "duration.between(p.born, date())"; got != want {
+		t.Errorf("DurationBetween().String() = %q, want %q", got, want)
+	}
+}
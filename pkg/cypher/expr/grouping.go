@@ -0,0 +1,120 @@
+package expr
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+)
+
+// aggregateFunctions are the functions that make a projection an aggregation
+var aggregateFunctions = map[string]bool{
+	"count": true, "sum": true, "avg": true, "min": true, "max": true, "collect": true,
+	"stdev": true, "stdevp": true, "percentilecont": true, "percentiledisc": true,
+}
+
+// GroupingExpression is a WITH or RETURN projection that spells out its
+// grouping: the keys are projected as they are and Cypher groups the
+// aggregates by them, e.g. a.country, count(*) AS people
+type GroupingExpression struct {
+	Keys       []core.Expression
+	Aggregates []core.Expression
+}
+
+// Accept implements the Expression interface
+func (g *GroupingExpression) Accept(visitor core.ExpressionVisitor) any {
+	return visitor.Visit(g)
+}
+
+// String returns the keys followed by the aggregates, comma-separated
+func (g *GroupingExpression) String() string {
+	items := make([]string, 0, len(g.Keys)+len(g.Aggregates))
+	for _, expr := range g.Expressions() {
+		items = append(items, expr.String())
+	}
+	return strings.Join(items, ", ")
+}
+
+// Expressions returns the keys followed by the aggregates
+func (g *GroupingExpression) Expressions() []core.Expression {
+	return append(append([]core.Expression{}, g.Keys...), g.Aggregates...)
+}
+
+// Validate reports an error when a key uses an aggregate function, an
+// aggregate does not, or no aggregate is given
+func (g *GroupingExpression) Validate() error {
+	if len(g.Aggregates) == 0 {
+		return core.NewError(core.ErrInvalidExpression, "GroupBy requires at least one aggregate")
+	}
+	for _, key := range g.Keys {
+		if key == nil || IsAggregate(key) {
+			return core.NewError(core.ErrInvalidExpression,
+				fmt.Sprintf("grouping key %v must not be an aggregate", key))
+		}
+	}
+	for _, agg := range g.Aggregates {
+		if agg == nil || !IsAggregate(agg) {
+			return core.NewError(core.ErrInvalidExpression,
+				fmt.Sprintf("%v is not an aggregate; add it to the grouping keys instead", agg))
+		}
+	}
+	return nil
+}
+
+// And creates a logical AND with another expression
+func (g *GroupingExpression) And(other core.Expression) core.Expression {
+	return And(g, other)
+}
+
+// Or creates a logical OR with another expression
+func (g *GroupingExpression) Or(other core.Expression) core.Expression {
+	return Or(g, other)
+}
+
+// Not creates a logical NOT of this expression
+func (g *GroupingExpression) Not() core.Expression {
+	return Not(g)
+}
+
+// Grouping collects the grouping keys of a projection until its aggregates are added
+type Grouping struct {
+	keys []core.Expression
+}
+
+// GroupBy starts a projection grouped by the given keys; complete it with Aggregate
+func GroupBy(keys ...core.Expression) *Grouping {
+	return &Grouping{keys: keys}
+}
+
+// Aggregate completes the projection with the aggregates computed per group
+func (g *Grouping) Aggregate(aggregates ...core.Expression) *GroupingExpression {
+	return &GroupingExpression{Keys: g.keys, Aggregates: aggregates}
+}
+
+// IsAggregate reports whether an expression calls an aggregate function such
+// as count() or collect(), directly or inside an alias or another expression
+func IsAggregate(expr core.Expression) bool {
+	if expr == nil {
+		return false
+	}
+
+	if fn, ok := expr.(*FunctionExpression); ok && aggregateFunctions[strings.ToLower(fn.Name)] {
+		return true
+	}
+
+	if container, ok := expr.(interface{ Expressions() []core.Expression }); ok {
+		for _, subExpr := range container.Expressions() {
+			if IsAggregate(subExpr) {
+				return true
+			}
+		}
+	}
+
+	if binaryExpr, ok := expr.(interface {
+		Left() core.Expression
+		Right() core.Expression
+	}); ok {
+		return IsAggregate(binaryExpr.Left()) || IsAggregate(binaryExpr.Right())
+	}
+	return false
+}
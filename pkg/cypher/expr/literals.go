@@ -3,6 +3,7 @@ package expr
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 
@@ -24,6 +25,11 @@ func (b *BooleanLiteral) String() string {
 	return strconv.FormatBool(b.Value)
 }
 
+// LiteralValue returns the value this literal inlines in the query
+func (b *BooleanLiteral) LiteralValue() any {
+	return b.Value
+}
+
 // And creates a logical AND with another expression
 func (b *BooleanLiteral) And(other core.Expression) core.Expression {
 	return And(b, other)
@@ -54,6 +60,11 @@ func (i *IntegerLiteral) String() string {
 	return strconv.FormatInt(i.Value, 10)
 }
 
+// LiteralValue returns the value this literal inlines in the query
+func (i *IntegerLiteral) LiteralValue() any {
+	return i.Value
+}
+
 // And creates a logical AND with another expression
 func (i *IntegerLiteral) And(other core.Expression) core.Expression {
 	return And(i, other)
@@ -81,7 +92,12 @@ func (f *FloatLiteral) Accept(visitor core.ExpressionVisitor) any {
 
 // String returns a string representation of this float literal
 func (f *FloatLiteral) String() string {
-	return strconv.FormatFloat(f.Value, 'f', -1, 64)
+	return core.FormatFloat(f.Value)
+}
+
+// LiteralValue returns the value this literal inlines in the query
+func (f *FloatLiteral) LiteralValue() any {
+	return f.Value
 }
 
 // And creates a logical AND with another expression
@@ -112,6 +128,11 @@ func (n *NullLiteral) String() string {
 	return "NULL"
 }
 
+// LiteralValue returns the value this literal inlines in the query
+func (n *NullLiteral) LiteralValue() any {
+	return nil
+}
+
 // And creates a logical AND with another expression
 func (n *NullLiteral) And(other core.Expression) core.Expression {
 	return And(n, other)
@@ -143,6 +164,11 @@ func (s *StringLiteral) String() string {
 	return fmt.Sprintf("'%s'", escaped)
 }
 
+// LiteralValue returns the value this literal inlines in the query
+func (s *StringLiteral) LiteralValue() any {
+	return s.Value
+}
+
 // And creates a logical AND with another expression
 func (s *StringLiteral) And(other core.Expression) core.Expression {
 	return And(s, other)
@@ -182,6 +208,19 @@ func (l *ListExpression) String() string {
 	return sb.String()
 }
 
+// Expressions returns the elements of the list
+func (l *ListExpression) Expressions() []core.Expression {
+	return l.Elements
+}
+
+// WithChildren returns a list of the new elements
+func (l *ListExpression) WithChildren(children []core.Expression) core.Expression {
+	if len(children) != len(l.Elements) {
+		return l
+	}
+	return &ListExpression{Elements: children}
+}
+
 // And creates a logical AND with another expression
 func (l *ListExpression) And(other core.Expression) core.Expression {
 	return And(l, other)
@@ -211,20 +250,42 @@ func (m *MapLiteralExpression) Accept(visitor core.ExpressionVisitor) any {
 func (m *MapLiteralExpression) String() string {
 	var sb strings.Builder
 	sb.WriteString("{")
-	i := 0
-	for key, value := range m.Entries {
+	for i, key := range sortedKeys(m.Entries) {
 		if i > 0 {
 			sb.WriteString(", ")
 		}
-		sb.WriteString(key)
+		sb.WriteString(QuoteIdentifier(key))
 		sb.WriteString(": ")
-		sb.WriteString(value.String())
-		i++
+		sb.WriteString(m.Entries[key].String())
 	}
 	sb.WriteString("}")
 	return sb.String()
 }
 
+// Expressions returns the values of the map in key order, so that the
+// parameters inside a map are collected and named deterministically
+func (m *MapLiteralExpression) Expressions() []core.Expression {
+	keys := sortedKeys(m.Entries)
+	result := make([]core.Expression, len(keys))
+	for i, key := range keys {
+		result[i] = m.Entries[key]
+	}
+	return result
+}
+
+// WithChildren returns a map with the new values, given in key order like Expressions
+func (m *MapLiteralExpression) WithChildren(children []core.Expression) core.Expression {
+	keys := sortedKeys(m.Entries)
+	if len(children) != len(keys) {
+		return m
+	}
+	entries := make(map[string]core.Expression, len(keys))
+	for i, key := range keys {
+		entries[key] = children[i]
+	}
+	return &MapLiteralExpression{Entries: entries}
+}
+
 // And creates a logical AND with another expression
 func (m *MapLiteralExpression) And(other core.Expression) core.Expression {
 	return And(m, other)
@@ -266,6 +327,11 @@ func (p *ParameterExpression) Or(other core.Expression) core.Expression {
 	return Or(p, other)
 }
 
+// Xor creates a logical XOR with another boolean expression
+func (p *ParameterExpression) Xor(other core.BooleanExpression) core.BooleanExpression {
+	return Xor(p, other).(core.BooleanExpression)
+}
+
 // Not creates a logical NOT of this expression
 func (p *ParameterExpression) Not() core.Expression {
 	return Not(p)
@@ -314,7 +380,56 @@ func Param(name string, value any) core.Expression {
 	}
 }
 
-// LiteralFromValue converts a Go value to an Expression
+// unsignedInteger converts an unsigned value to an integer literal, or to an
+// expression that fails validation when it exceeds the largest Cypher integer
+func unsignedInteger(value uint64) core.Expression {
+	if value > math.MaxInt64 {
+		return &integerOverflow{value: value}
+	}
+	return Integer(int64(value))
+}
+
+// integerOverflow is an unsigned Go value too large for a Cypher integer,
+// which is a signed 64-bit integer
+type integerOverflow struct {
+	value uint64
+}
+
+// Accept implements the Expression interface
+func (i *integerOverflow) Accept(visitor core.ExpressionVisitor) any {
+	return visitor.Visit(i)
+}
+
+// String returns the value as it would be written
+func (i *integerOverflow) String() string {
+	return strconv.FormatUint(i.value, 10)
+}
+
+// Validate reports that the value does not fit a Cypher integer
+func (i *integerOverflow) Validate() error {
+	return core.NewError(core.ErrInvalidExpression,
+		fmt.Sprintf("integer %d exceeds the largest Cypher integer %d", i.value, int64(math.MaxInt64)))
+}
+
+// And creates a logical AND with another expression
+func (i *integerOverflow) And(other core.Expression) core.Expression {
+	return And(i, other)
+}
+
+// Or creates a logical OR with another expression
+func (i *integerOverflow) Or(other core.Expression) core.Expression {
+	return Or(i, other)
+}
+
+// Not creates a logical NOT of this expression
+func (i *integerOverflow) Not() core.Expression {
+	return Not(i)
+}
+
+// LiteralFromValue converts a Go value to an Expression. Unsigned values
+// above math.MaxInt64 produce an expression whose Validate method returns an
+// error wrapping core.ErrInvalidExpression, so a statement using one fails
+// to build.
 func LiteralFromValue(value any) core.Expression {
 	if value == nil {
 		return Null()
@@ -327,8 +442,26 @@ func LiteralFromValue(value any) core.Expression {
 		return String(v)
 	case int:
 		return Integer(int64(v))
+	case int8:
+		return Integer(int64(v))
+	case int16:
+		return Integer(int64(v))
+	case int32:
+		return Integer(int64(v))
 	case int64:
 		return Integer(v)
+	case uint:
+		return unsignedInteger(uint64(v))
+	case uint8:
+		return Integer(int64(v))
+	case uint16:
+		return Integer(int64(v))
+	case uint32:
+		return Integer(int64(v))
+	case uint64:
+		return unsignedInteger(v)
+	case float32:
+		return Float(float64(v))
 	case float64:
 		return Float(v)
 	case bool:
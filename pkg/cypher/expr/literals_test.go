@@ -1,6 +1,8 @@
 package expr
 
 import (
+	"errors"
+	"math"
 	"testing"
 
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
@@ -63,6 +65,11 @@ func TestFloatLiteral(t *testing.T) {
 		{"negative float", -2.5, "-2.5"},
 		{"small float", 0.001, "0.001"},
 		{"large float", 123456.789, "123456.789"},
+		{"tiny float", 1e-9, "1e-9"},
+		{"negative zero", math.Copysign(0, -1), "0"},
+		{"huge float", 1.5e18, "1.5e18"},
+		{"huge negative float", -1e20, "-1e20"},
+		{"negative tiny float", -2.5e-7, "-2.5e-7"},
 	}
 
 	for _, tt := range tests {
@@ -199,6 +206,9 @@ func TestLiteralFromValue(t *testing.T) {
 		{"string", "hello", "'hello'"},
 		{"int", 42, "42"},
 		{"int64", int64(100), "100"},
+		{"uint", uint(5), "5"},
+		{"uint32", uint32(7), "7"},
+		{"uint64", uint64(math.MaxInt64), "9223372036854775807"},
 		{"float64", 3.14, "3.14"},
 		{"bool true", true, "true"},
 		{"bool false", false, "false"},
@@ -221,6 +231,17 @@ func TestLiteralFromValue(t *testing.T) {
 	}
 }
 
+func TestLiteralFromValueUnsignedOverflow(t *testing.T) {
+	lit := LiteralFromValue(uint64(math.MaxInt64) + 1)
+	validatable, ok := lit.(interface{ Validate() error })
+	if !ok {
+		t.Fatalf("LiteralFromValue(%d) = %T, want an expression that fails validation", uint64(math.MaxInt64)+1, lit)
+	}
+	if err := validatable.Validate(); !errors.Is(err, core.ErrInvalidExpression) {
+		t.Errorf("Validate() error = %v, want core.ErrInvalidExpression", err)
+	}
+}
+
 func TestParameterExpression(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -55,9 +55,9 @@ func (l *LogicalExpression) Or(other core.Expression) core.Expression {
 	return Or(l, other)
 }
 
-// Xor creates a logical XOR with another expression
-func (l *LogicalExpression) Xor(other core.Expression) core.Expression {
-	return Xor(l, other)
+// Xor creates a logical XOR with another boolean expression
+func (l *LogicalExpression) Xor(other core.BooleanExpression) core.BooleanExpression {
+	return Xor(l, other).(core.BooleanExpression)
 }
 
 // Expressions returns all expressions contained in this logical expression
@@ -65,6 +65,14 @@ func (l *LogicalExpression) Expressions() []core.Expression {
 	return []core.Expression{l.left, l.right}
 }
 
+// WithChildren returns a copy of this logical expression with new operands
+func (l *LogicalExpression) WithChildren(children []core.Expression) core.Expression {
+	if len(children) != 2 {
+		return l
+	}
+	return &LogicalExpression{left: children[0], right: children[1], operator: l.operator}
+}
+
 // And creates a logical AND expression
 func And(left, right core.Expression) core.Expression {
 	return &LogicalExpression{
@@ -83,6 +91,39 @@ func Or(left, right core.Expression) core.Expression {
 	}
 }
 
+// AndAll combines conditions with AND, skipping nil conditions so optional
+// filters can be passed as they are. A single condition is returned
+// unchanged and no conditions yield true, which matches everything.
+func AndAll(conditions ...core.Expression) core.Expression {
+	return foldConditions(And, conditions)
+}
+
+// OrAll combines conditions with OR, skipping nil conditions. A single
+// condition is returned unchanged; like AndAll, no conditions yield true so
+// that an empty filter does not exclude anything.
+func OrAll(conditions ...core.Expression) core.Expression {
+	return foldConditions(Or, conditions)
+}
+
+// foldConditions combines the non-nil conditions from left to right with combine
+func foldConditions(combine func(left, right core.Expression) core.Expression, conditions []core.Expression) core.Expression {
+	var result core.Expression
+	for _, condition := range conditions {
+		if condition == nil {
+			continue
+		}
+		if result == nil {
+			result = condition
+		} else {
+			result = combine(result, condition)
+		}
+	}
+	if result == nil {
+		return Boolean(true)
+	}
+	return result
+}
+
 // Xor creates a logical XOR expression
 func Xor(left, right core.Expression) core.Expression {
 	return &LogicalExpression{
@@ -102,8 +143,20 @@ func (n *NotExpression) Accept(visitor core.ExpressionVisitor) any {
 	return visitor.Visit(n)
 }
 
-// String returns a string representation of this NOT expression
+// String returns a string representation of this NOT expression.
+// A negated null check is rendered with the idiomatic operator instead,
+// so NOT (n.x IS NULL) becomes n.x IS NOT NULL and vice versa.
 func (n *NotExpression) String() string {
+	if check, ok := n.expr.(*ComparisonExpression); ok {
+		if _, isNull := check.right.(*NullLiteral); isNull {
+			switch check.operator {
+			case "IS":
+				return IsNotNull(check.left).String()
+			case "IS NOT":
+				return IsNull(check.left).String()
+			}
+		}
+	}
 	return fmt.Sprintf("NOT %s", n.expr.String())
 }
 
@@ -117,11 +170,24 @@ func (n *NotExpression) Or(other core.Expression) core.Expression {
 	return Or(n, other)
 }
 
+// Xor creates a logical XOR with another boolean expression
+func (n *NotExpression) Xor(other core.BooleanExpression) core.BooleanExpression {
+	return Xor(n, other).(core.BooleanExpression)
+}
+
 // Expressions returns the expressions in this NOT expression
 func (n *NotExpression) Expressions() []core.Expression {
 	return []core.Expression{n.expr}
 }
 
+// WithChildren returns a NOT of the new negated expression
+func (n *NotExpression) WithChildren(children []core.Expression) core.Expression {
+	if len(children) != 1 {
+		return n
+	}
+	return &NotExpression{expr: children[0]}
+}
+
 // Not creates a logical NOT expression
 func Not(expr core.Expression) core.Expression {
 	return &NotExpression{
@@ -129,11 +195,105 @@ func Not(expr core.Expression) core.Expression {
 	}
 }
 
+// GroupExpression wraps an expression in parentheses, e.g. (a OR b), so that
+// it keeps its meaning when combined with other operators. Logical
+// expressions are parenthesized already; raw Cypher and fragments are not.
+type GroupExpression struct {
+	expr core.Expression
+}
+
+// Accept implements the Expression interface
+func (g *GroupExpression) Accept(visitor core.ExpressionVisitor) any {
+	return visitor.Visit(g)
+}
+
+// String returns the grouped expression in parentheses, adding none when the
+// expression is already enclosed in a pair of its own
+func (g *GroupExpression) String() string {
+	inner := g.expr.String()
+	if isParenthesized(inner) {
+		return inner
+	}
+	return "(" + inner + ")"
+}
+
+// Expressions returns the grouped expression
+func (g *GroupExpression) Expressions() []core.Expression {
+	return []core.Expression{g.expr}
+}
+
+// WithChildren returns a group of the new grouped expression
+func (g *GroupExpression) WithChildren(children []core.Expression) core.Expression {
+	if len(children) != 1 {
+		return g
+	}
+	return &GroupExpression{expr: children[0]}
+}
+
+// And creates a logical AND with another expression
+func (g *GroupExpression) And(other core.Expression) core.Expression {
+	return And(g, other)
+}
+
+// Or creates a logical OR with another expression
+func (g *GroupExpression) Or(other core.Expression) core.Expression {
+	return Or(g, other)
+}
+
+// Xor creates a logical XOR with another boolean expression
+func (g *GroupExpression) Xor(other core.BooleanExpression) core.BooleanExpression {
+	return Xor(g, other).(core.BooleanExpression)
+}
+
+// Not creates a logical NOT of this expression
+func (g *GroupExpression) Not() core.Expression {
+	return Not(g)
+}
+
+// Group encloses an expression in parentheses, e.g. to keep the OR of a raw
+// Cypher condition from binding looser than a surrounding AND
+func Group(expr core.Expression) core.Expression {
+	return &GroupExpression{expr: expr}
+}
+
+// isParenthesized reports whether s is enclosed in one pair of matching
+// parentheses, so that (a) OR (b) is not mistaken for a group
+func isParenthesized(s string) bool {
+	if len(s) < 2 || s[0] != '(' || s[len(s)-1] != ')' {
+		return false
+	}
+	depth := 0
+	var quote rune
+	for i, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+			if depth == 0 && i < len(s)-1 {
+				return false
+			}
+		}
+	}
+	return depth == 0
+}
+
 // Not creates a logical NOT of this expression
 func (l *LogicalExpression) Not() core.Expression {
 	return Not(l)
 }
 
+// As creates an alias for this logical expression
+func (l *LogicalExpression) As(alias string) core.Expression {
+	return As(l, alias)
+}
+
 // Not creates a logical NOT of this expression
 func (n *NotExpression) Not() core.Expression {
 	// Double negation cancels out
@@ -2,6 +2,8 @@ package expr
 
 import (
 	"testing"
+
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
 )
 
 func TestAnd(t *testing.T) {
@@ -37,6 +39,52 @@ func TestXor(t *testing.T) {
 	}
 }
 
+func TestFluentXor(t *testing.T) {
+	a := NewProperty(NewVariableExpression("n"), "a")
+	b := NewProperty(NewVariableExpression("n"), "b")
+
+	tests := map[string]struct {
+		expr core.Expression
+		want string
+	}{
+		"comparison":       {a.Eq(1).Xor(b.Eq(2)), "((n.a = 1) XOR (n.b = 2))"},
+		"logical":          {And(a.Eq(1), b.Eq(2)).(*LogicalExpression).Xor(b.IsNull()), "(((n.a = 1) AND (n.b = 2)) XOR (n.b IS NULL))"},
+		"string operation": {a.StartsWith("x").Xor(b.Contains("y")), "((n.a STARTS WITH 'x') XOR (n.b CONTAINS 'y'))"},
+		"null check":       {a.IsNotNull().Xor(b.IsFalse()), "((n.a IS NOT NULL) XOR NOT n.b)"},
+		"nested":           {a.Gt(3).Xor(a.Eq(1).Xor(b.Eq(2))), "((n.a > 3) XOR ((n.a = 1) XOR (n.b = 2)))"},
+		"chained":          {a.Eq(1).Xor(b.Eq(2)).Xor(a.Gt(3)), "(((n.a = 1) XOR (n.b = 2)) XOR (n.a > 3))"},
+	}
+	for name, tt := range tests {
+		if got := tt.expr.String(); got != tt.want {
+			t.Errorf("%s: String() = %q, want %q", name, got, tt.want)
+		}
+	}
+}
+
+func TestGroupPrecedence(t *testing.T) {
+	a := NewProperty(NewVariableExpression("n"), "a").Eq(1)
+	b := NewProperty(NewVariableExpression("n"), "b").Eq(2)
+	c := NewProperty(NewVariableExpression("n"), "c").Eq(3)
+
+	tests := map[string]struct {
+		expr core.Expression
+		want string
+	}{
+		"and binds tighter": {Or(a, And(b, c)), "((n.a = 1) OR ((n.b = 2) AND (n.c = 3)))"},
+		"or grouped first":  {And(Or(a, b), c), "(((n.a = 1) OR (n.b = 2)) AND (n.c = 3))"},
+		"raw condition":     {Group(RawCypher("n.a = 1 OR n.b = 2")).And(c), "((n.a = 1 OR n.b = 2) AND (n.c = 3))"},
+		"negated raw":       {Not(Group(RawCypher("n.a = 1 OR n.b = 2"))), "NOT (n.a = 1 OR n.b = 2)"},
+		"already grouped":   {Group(Or(a, b)), "((n.a = 1) OR (n.b = 2))"},
+		"separate groups":   {Group(RawCypher("(n.a = 1) OR (n.b = 2)")), "((n.a = 1) OR (n.b = 2))"},
+		"paren in string":   {Group(RawCypher("(n.a = ')') OR n.b")), "((n.a = ')') OR n.b)"},
+	}
+	for name, tt := range tests {
+		if got := tt.expr.String(); got != tt.want {
+			t.Errorf("%s: String() = %q, want %q", name, got, tt.want)
+		}
+	}
+}
+
 func TestNot(t *testing.T) {
 	expr := Property("n", "deleted")
 	notExpr := Not(expr)
@@ -57,6 +105,17 @@ func TestNotDoubleNegation(t *testing.T) {
 	}
 }
 
+func TestNotNullCheck(t *testing.T) {
+	name := NewProperty(NewVariableExpression("n"), "name")
+
+	if got := Not(IsNull(name)).String(); got != "(n.name IS NOT NULL)" {
+		t.Errorf("Not(IsNull(...)).String() = %q, want '(n.name IS NOT NULL)'", got)
+	}
+	if got := Not(IsNotNull(name)).String(); got != "(n.name IS NULL)" {
+		t.Errorf("Not(IsNotNull(...)).String() = %q, want '(n.name IS NULL)'", got)
+	}
+}
+
 func TestComplexLogicalExpressions(t *testing.T) {
 	age := Property("n", "age")
 	active := Property("n", "active")
@@ -139,7 +198,7 @@ func TestLogicalExpressionMethods(t *testing.T) {
 
 	// Xor only works on LogicalExpression
 	if logExpr, ok := andExpr.(*LogicalExpression); ok {
-		xorExpr := logExpr.Xor(right)
+		xorExpr := logExpr.Xor(right.(core.BooleanExpression))
 		if xorExpr == nil {
 			t.Error("andExpr.Xor() returned nil")
 		}
@@ -151,4 +210,31 @@ func TestLogicalExpressionMethods(t *testing.T) {
 	}
 }
 
+func TestAndAllOrAll(t *testing.T) {
+	age := GreaterThan(NewVariableExpression("age"), Integer(30))
+	active := Equals(NewVariableExpression("active"), Boolean(true))
+	city := Equals(NewVariableExpression("city"), String("Berlin"))
+
+	tests := []struct {
+		name string
+		expr core.Expression
+		want string
+	}{
+		{"and empty", AndAll(), "true"},
+		{"and nil only", AndAll(nil, nil), "true"},
+		{"and single", AndAll(age), "(age > 30)"},
+		{"and skips nil", AndAll(nil, age, nil, active), "((age > 30) AND (active = true))"},
+		{"and folds left", AndAll(age, active, city), "(((age > 30) AND (active = true)) AND (city = 'Berlin'))"},
+		{"or empty", OrAll(), "true"},
+		{"or single", OrAll(city), "(city = 'Berlin')"},
+		{"or two", OrAll(age, city), "((age > 30) OR (city = 'Berlin'))"},
+	}
 
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.expr.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
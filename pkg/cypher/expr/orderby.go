@@ -20,9 +20,22 @@ func (o *OrderByExpression) Accept(visitor core.ExpressionVisitor) any {
 // String returns a string representation of this order by expression
 func (o *OrderByExpression) String() string {
 	if o.Descending {
-		return fmt.Sprintf("%s DESC", o.Expression.String())
+		return fmt.Sprintf("%s DESC", SortKey(o.Expression).String())
 	}
-	return fmt.Sprintf("%s ASC", o.Expression.String())
+	return fmt.Sprintf("%s ASC", SortKey(o.Expression).String())
+}
+
+// Expressions returns the ordered expression
+func (o *OrderByExpression) Expressions() []core.Expression {
+	return []core.Expression{o.Expression}
+}
+
+// WithChildren returns the new expression ordered in the same direction
+func (o *OrderByExpression) WithChildren(children []core.Expression) core.Expression {
+	if len(children) != 1 {
+		return o
+	}
+	return &OrderByExpression{Expression: children[0], Descending: o.Descending}
 }
 
 // And creates a logical AND with another expression
@@ -40,6 +53,16 @@ func (o *OrderByExpression) Not() core.Expression {
 	return Not(o)
 }
 
+// SortKey returns what an ORDER BY item sorts on. An aliased projection item,
+// e.g. count(*) AS c, sorts on its alias, since ORDER BY can reference the
+// aliases of the RETURN or WITH it belongs to; other expressions are returned as is.
+func SortKey(expression core.Expression) core.Expression {
+	if alias, ok := expression.(*AliasExpression); ok {
+		return NewVariableExpression(QuoteIdentifier(alias.Alias))
+	}
+	return expression
+}
+
 // Desc creates a descending order by expression
 func Desc(expression core.Expression) core.Expression {
 	return &OrderByExpression{
@@ -34,6 +34,24 @@ func TestOrderByWithFunction(t *testing.T) {
 	}
 }
 
+func TestOrderByAlias(t *testing.T) {
+	tests := []struct {
+		name string
+		item interface{ String() string }
+		want string
+	}{
+		{"aliased item", SortKey(As(CountStar(), "c")), "c"},
+		{"quoted alias", SortKey(As(CountStar(), "order count")), "`order count`"},
+		{"descending aliased item", Desc(As(CountStar(), "c")), "c DESC"},
+		{"plain expression", SortKey(NewVariableExpression("c")), "c"},
+	}
+	for _, tt := range tests {
+		if got := tt.item.String(); got != tt.want {
+			t.Errorf("%s: String() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
 func TestOrderByExpressionLogicalOps(t *testing.T) {
 	expr := Property("n", "age")
 	orderExpr := Desc(expr)
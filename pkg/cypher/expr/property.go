@@ -37,64 +37,102 @@ func (p *PropertyExpression) String() string {
 	return fmt.Sprintf("%s.%s", subjectStr, strings.Join(allProps, "."))
 }
 
+// Validate reports an error when the subject is a node or relationship
+// pattern without an alias, since its properties cannot be referenced
+func (p *PropertyExpression) Validate() error {
+	named, ok := p.Subject.(core.NamedExpression)
+	if !ok || named.SymbolicName() != "" {
+		return nil
+	}
+
+	kind := "node"
+	if _, isRelationship := p.Subject.(core.RelationshipPattern); isRelationship {
+		kind = "relationship"
+	}
+	return core.NewError(core.ErrMissingAlias,
+		fmt.Sprintf("property %q of %s %s cannot be referenced without an alias; use Named()", p.PropertyName, kind, p.Subject.String()))
+}
+
 // Eq creates an equals comparison with the given value
-func (p *PropertyExpression) Eq(value any) core.Expression {
-	return Equals(p, LiteralFromValue(value))
+func (p *PropertyExpression) Eq(value any) core.BooleanExpression {
+	return Equals(p, LiteralFromValue(value)).(*ComparisonExpression)
 }
 
 // Gt creates a greater-than comparison with the given value
-func (p *PropertyExpression) Gt(value any) core.Expression {
-	return GreaterThan(p, LiteralFromValue(value))
+func (p *PropertyExpression) Gt(value any) core.BooleanExpression {
+	return GreaterThan(p, LiteralFromValue(value)).(*ComparisonExpression)
 }
 
 // Lt creates a less-than comparison with the given value
-func (p *PropertyExpression) Lt(value any) core.Expression {
-	return LessThan(p, LiteralFromValue(value))
+func (p *PropertyExpression) Lt(value any) core.BooleanExpression {
+	return LessThan(p, LiteralFromValue(value)).(*ComparisonExpression)
 }
 
 // Gte creates a greater-than-or-equal comparison with the given value
-func (p *PropertyExpression) Gte(value any) core.Expression {
-	return GreaterThanEqual(p, LiteralFromValue(value))
+func (p *PropertyExpression) Gte(value any) core.BooleanExpression {
+	return GreaterThanEqual(p, LiteralFromValue(value)).(*ComparisonExpression)
 }
 
 // Lte creates a less-than-or-equal comparison with the given value
-func (p *PropertyExpression) Lte(value any) core.Expression {
-	return LessThanEqual(p, LiteralFromValue(value))
+func (p *PropertyExpression) Lte(value any) core.BooleanExpression {
+	return LessThanEqual(p, LiteralFromValue(value)).(*ComparisonExpression)
 }
 
 // IsNull creates a null check
-func (p *PropertyExpression) IsNull() core.Expression {
-	return IsNull(p)
+func (p *PropertyExpression) IsNull() core.BooleanExpression {
+	return IsNull(p).(*ComparisonExpression)
 }
 
 // IsNotNull creates a not-null check
-func (p *PropertyExpression) IsNotNull() core.Expression {
-	return IsNotNull(p)
+func (p *PropertyExpression) IsNotNull() core.BooleanExpression {
+	return IsNotNull(p).(*ComparisonExpression)
+}
+
+// IsTrue uses a boolean property as a condition on its own, e.g.
+// WHERE p.active; use Eq(true) for an explicit comparison
+func (p *PropertyExpression) IsTrue() core.BooleanExpression {
+	return p
+}
+
+// IsFalse negates a boolean property, e.g. WHERE NOT p.active. Like
+// p.active = false it does not hold when the property is null.
+func (p *PropertyExpression) IsFalse() core.BooleanExpression {
+	return &NotExpression{expr: p}
+}
+
+// IsType creates a type predicate, e.g. p.age IS :: INTEGER NOT NULL
+func (p *PropertyExpression) IsType(typeName string) core.BooleanExpression {
+	return &TypePredicateExpression{Expression: p, TypeName: typeName}
 }
 
 // In creates an IN comparison with the given values
-func (p *PropertyExpression) In(values ...any) core.Expression {
-	return In(p, values...)
+func (p *PropertyExpression) In(values ...any) core.BooleanExpression {
+	return In(p, values...).(*ComparisonExpression)
+}
+
+// InParam creates an IN comparison against a single list parameter
+func (p *PropertyExpression) InParam(value any) core.BooleanExpression {
+	return InParam(p, value).(*ComparisonExpression)
 }
 
 // StartsWith creates a STARTS WITH comparison
-func (p *PropertyExpression) StartsWith(value string) core.Expression {
-	return StartsWith(p, value)
+func (p *PropertyExpression) StartsWith(value string) core.BooleanExpression {
+	return StartsWith(p, value).(*ComparisonExpression)
 }
 
 // EndsWith creates an ENDS WITH comparison
-func (p *PropertyExpression) EndsWith(value string) core.Expression {
-	return EndsWith(p, value)
+func (p *PropertyExpression) EndsWith(value string) core.BooleanExpression {
+	return EndsWith(p, value).(*ComparisonExpression)
 }
 
 // Contains creates a CONTAINS comparison
-func (p *PropertyExpression) Contains(value string) core.Expression {
-	return Contains(p, value)
+func (p *PropertyExpression) Contains(value string) core.BooleanExpression {
+	return Contains(p, value).(*ComparisonExpression)
 }
 
 // RegularExpression creates a =~ comparison with a regular expression
-func (p *PropertyExpression) RegularExpression(pattern string) core.Expression {
-	return RegularExpression(p, pattern)
+func (p *PropertyExpression) RegularExpression(pattern string) core.BooleanExpression {
+	return RegularExpression(p, pattern).(*ComparisonExpression)
 }
 
 // Property creates a property access expression
@@ -116,11 +154,21 @@ func (p *PropertyExpression) Or(other core.Expression) core.Expression {
 	return Or(p, other)
 }
 
+// Xor creates a logical XOR with another boolean expression
+func (p *PropertyExpression) Xor(other core.BooleanExpression) core.BooleanExpression {
+	return Xor(p, other).(core.BooleanExpression)
+}
+
 // Not creates a logical NOT of this expression
 func (p *PropertyExpression) Not() core.Expression {
 	return Not(p)
 }
 
+// As creates an alias for this property expression
+func (p *PropertyExpression) As(alias string) core.Expression {
+	return As(p, alias)
+}
+
 // NewProperty creates a new property expression
 func NewProperty(subject core.Expression, propertyName string) *PropertyExpression {
 	return &PropertyExpression{
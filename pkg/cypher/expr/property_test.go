@@ -1,6 +1,7 @@
 package expr
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
@@ -85,6 +86,23 @@ func TestPropertyComparisons(t *testing.T) {
 	}
 }
 
+func TestPropertyBooleanFlags(t *testing.T) {
+	active := NewProperty(NewVariableExpression("n"), "active")
+
+	if got := active.IsTrue().String(); got != "n.active" {
+		t.Errorf("IsTrue() = %q, want %q", got, "n.active")
+	}
+	if got := active.IsFalse().String(); got != "NOT n.active" {
+		t.Errorf("IsFalse() = %q, want %q", got, "NOT n.active")
+	}
+	if got := active.IsTrue().And(active.IsFalse()).String(); got != "(n.active AND NOT n.active)" {
+		t.Errorf("IsTrue().And(IsFalse()) = %q, want %q", got, "(n.active AND NOT n.active)")
+	}
+	if got := active.Eq(true).String(); got != "(n.active = true)" {
+		t.Errorf("Eq(true) = %q, want %q", got, "(n.active = true)")
+	}
+}
+
 func TestPropertyLogicalOps(t *testing.T) {
 	prop := Property("n", "age")
 	other := Property("n", "score")
@@ -109,3 +127,30 @@ func TestPropertyLogicalOps(t *testing.T) {
 }
 
 
+
+func TestPropertyValidate(t *testing.T) {
+	if err := NewProperty(NewVariableExpression("n"), "name").Validate(); err != nil {
+		t.Errorf("Validate() on a named subject error = %v", err)
+	}
+	if err := Property("n", "name").(*PropertyExpression).Validate(); err != nil {
+		t.Errorf("Validate() on a string subject error = %v", err)
+	}
+
+	err := NewProperty(unnamedPattern{}, "name").Validate()
+	if !errors.Is(err, core.ErrMissingAlias) {
+		t.Errorf("Validate() on an unnamed subject error = %v, want core.ErrMissingAlias", err)
+	}
+}
+
+// unnamedPattern is a named expression without an alias
+type unnamedPattern struct {
+	core.Expression
+}
+
+func (unnamedPattern) SymbolicName() string {
+	return ""
+}
+
+func (unnamedPattern) String() string {
+	return "(:Person)"
+}
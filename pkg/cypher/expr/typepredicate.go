@@ -0,0 +1,146 @@
+package expr
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+)
+
+// cypherTypes are the type names accepted in IS :: type predicates (Neo4j 5)
+var cypherTypes = map[string]bool{
+	"ANY": true, "ANY VALUE": true, "NOTHING": true, "NULL": true,
+	"BOOL": true, "BOOLEAN": true, "STRING": true,
+	"INT": true, "INTEGER": true, "SIGNED INTEGER": true, "FLOAT": true,
+	"DATE": true, "LOCAL TIME": true, "TIME WITHOUT TIME ZONE": true,
+	"ZONED TIME": true, "TIME WITH TIME ZONE": true,
+	"LOCAL DATETIME": true, "TIMESTAMP WITHOUT TIME ZONE": true,
+	"ZONED DATETIME": true, "TIMESTAMP WITH TIME ZONE": true,
+	"DURATION": true, "POINT": true,
+	"NODE": true, "ANY NODE": true, "VERTEX": true, "ANY VERTEX": true,
+	"RELATIONSHIP": true, "ANY RELATIONSHIP": true, "EDGE": true, "ANY EDGE": true,
+	"MAP": true, "ANY MAP": true, "PATH": true,
+	"PROPERTY VALUE": true, "ANY PROPERTY VALUE": true,
+}
+
+// TypePredicateExpression represents a type predicate (e.g., n.age IS :: INTEGER NOT NULL)
+type TypePredicateExpression struct {
+	Expression core.Expression
+	TypeName   string
+}
+
+// Accept implements the Expression interface
+func (t *TypePredicateExpression) Accept(visitor core.ExpressionVisitor) any {
+	return visitor.Visit(t)
+}
+
+// String returns a string representation of this type predicate; the type is rendered verbatim
+func (t *TypePredicateExpression) String() string {
+	return fmt.Sprintf("(%s IS :: %s)", t.Expression.String(), t.TypeName)
+}
+
+// Expressions returns the checked expression
+func (t *TypePredicateExpression) Expressions() []core.Expression {
+	return []core.Expression{t.Expression}
+}
+
+// WithChildren returns the same type check of the new expression
+func (t *TypePredicateExpression) WithChildren(children []core.Expression) core.Expression {
+	if len(children) != 1 {
+		return t
+	}
+	return &TypePredicateExpression{Expression: children[0], TypeName: t.TypeName}
+}
+
+// Validate reports an error when the type is not one of the documented Cypher types
+func (t *TypePredicateExpression) Validate() error {
+	if !isCypherType(t.TypeName) {
+		return core.NewError(core.ErrInvalidExpression,
+			fmt.Sprintf("unknown type %q in type predicate", t.TypeName))
+	}
+	return nil
+}
+
+// And creates a logical AND with another expression
+func (t *TypePredicateExpression) And(other core.Expression) core.Expression {
+	return And(t, other)
+}
+
+// Or creates a logical OR with another expression
+func (t *TypePredicateExpression) Or(other core.Expression) core.Expression {
+	return Or(t, other)
+}
+
+// Xor creates a logical XOR with another boolean expression
+func (t *TypePredicateExpression) Xor(other core.BooleanExpression) core.BooleanExpression {
+	return Xor(t, other).(core.BooleanExpression)
+}
+
+// Not creates a logical NOT of this expression
+func (t *TypePredicateExpression) Not() core.Expression {
+	return Not(t)
+}
+
+// IsType creates a type predicate such as n.age IS :: INTEGER NOT NULL.
+// The type may be a union (INTEGER | FLOAT), a list type (LIST<STRING>) and
+// carry a NOT NULL or ! suffix; an unknown type fails the build.
+func IsType(expr core.Expression, typeName string) core.Expression {
+	return &TypePredicateExpression{Expression: expr, TypeName: typeName}
+}
+
+// isCypherType reports whether typeName is a documented Cypher type, a list
+// of one, or a union of them, optionally followed by NOT NULL or !
+func isCypherType(typeName string) bool {
+	normalized := strings.ToUpper(strings.Join(strings.Fields(typeName), " "))
+	if normalized == "" {
+		return false
+	}
+
+	// A closed dynamic union: ANY<INTEGER | FLOAT>
+	if inner, ok := strings.CutPrefix(normalized, "ANY<"); ok {
+		return strings.HasSuffix(inner, ">") && isCypherType(strings.TrimSuffix(inner, ">"))
+	}
+
+	for _, alternative := range splitTypeUnion(normalized) {
+		alternative = strings.TrimSpace(alternative)
+		alternative = strings.TrimSpace(strings.TrimSuffix(alternative, "NOT NULL"))
+		alternative = strings.TrimSpace(strings.TrimSuffix(alternative, "!"))
+
+		if inner, ok := cutListType(alternative); ok {
+			if !strings.HasSuffix(inner, ">") || !isCypherType(strings.TrimSuffix(inner, ">")) {
+				return false
+			}
+		} else if !cypherTypes[alternative] {
+			return false
+		}
+	}
+	return true
+}
+
+// cutListType strips the LIST< or ARRAY< prefix of a list type
+func cutListType(typeName string) (string, bool) {
+	if inner, ok := strings.CutPrefix(typeName, "LIST<"); ok {
+		return inner, true
+	}
+	return strings.CutPrefix(typeName, "ARRAY<")
+}
+
+// splitTypeUnion splits a type union on the | separators outside of angle brackets
+func splitTypeUnion(typeName string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range typeName {
+		switch r {
+		case '<':
+			depth++
+		case '>':
+			depth--
+		case '|':
+			if depth == 0 {
+				parts = append(parts, typeName[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, typeName[start:])
+}
@@ -1,37 +1,35 @@
 package expr
 
 import (
-	"fmt"
-	"strconv"
-	"strings"
+	"sort"
+
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
 )
 
-// formatValue formats a value to be used in a Cypher query
+// formatValue formats a value to be used in a Cypher query. It delegates to
+// core.LiteralExpression so that NULL, booleans and numbers render the same
+// way on every code path.
 func formatValue(value any) string {
-	if value == nil {
-		return "NULL"
+	return core.NewLiteral(value).String()
+}
+
+// referenceString renders an expression as it is referenced from another
+// expression: named nodes, relationships and paths by their symbolic name,
+// everything else as is
+func referenceString(expr core.Expression) string {
+	if named, ok := expr.(core.NamedExpression); ok && named.SymbolicName() != "" {
+		return named.SymbolicName()
 	}
+	return expr.String()
+}
 
-	switch v := value.(type) {
-	case string:
-		return "'" + strings.ReplaceAll(v, "'", "\\'") + "'"
-	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
-		return fmt.Sprintf("%v", v)
-	case bool:
-		return strconv.FormatBool(v)
-	case []any:
-		parts := make([]string, len(v))
-		for i, item := range v {
-			parts[i] = formatValue(item)
-		}
-		return "[" + strings.Join(parts, ", ") + "]"
-	case map[string]any:
-		parts := make([]string, 0, len(v))
-		for key, val := range v {
-			parts = append(parts, key+": "+formatValue(val))
-		}
-		return "{" + strings.Join(parts, ", ") + "}"
-	default:
-		return fmt.Sprintf("%v", v)
+// sortedKeys returns the keys of a map in alphabetical order, so that maps
+// render the same way on every run
+func sortedKeys(entries map[string]core.Expression) []string {
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
+	return keys
 }
@@ -31,6 +31,16 @@ func (v *VariableExpression) Name() string {
 	return v.name
 }
 
+// Property returns a property access expression for this variable (e.g., row.name)
+func (v *VariableExpression) Property(propertyName string) core.PropertyExpression {
+	return NewProperty(v, propertyName)
+}
+
+// Prop is an alias for Property
+func (v *VariableExpression) Prop(propertyName string) core.PropertyExpression {
+	return v.Property(propertyName)
+}
+
 // And creates a logical AND with another expression
 func (v *VariableExpression) And(other core.Expression) core.Expression {
 	return And(v, other)
@@ -53,3 +53,23 @@ func TestVariableExpressionLogicalOps(t *testing.T) {
 	}
 }
 
+func TestVariableExpressionProperty(t *testing.T) {
+	row := NewVariableExpression("row")
+
+	if got := row.Property("name").String(); got != "row.name" {
+		t.Errorf("Property() = %q, want 'row.name'", got)
+	}
+	if got := row.Prop("id").Eq(1).String(); got != "(row.id = 1)" {
+		t.Errorf("Prop().Eq() = %q, want '(row.id = 1)'", got)
+	}
+}
+
+func TestDeprecatedVar(t *testing.T) {
+	v := &Var{Name: "p"}
+	if got := v.Property("name").String(); got != "p.name" {
+		t.Errorf("Var.Property().String() = %q, want %q", got, "p.name")
+	}
+	if v.SymbolicName() != "p" {
+		t.Errorf("Var.SymbolicName() = %q, want %q", v.SymbolicName(), "p")
+	}
+}
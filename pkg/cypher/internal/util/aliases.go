@@ -0,0 +1,44 @@
+package util
+
+import (
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+)
+
+// ValidateExpressions checks expressions recursively and returns the first
+// error reported by an expression that can validate itself, such as a
+// property of a node or relationship that has no alias
+func ValidateExpressions(exprs ...core.Expression) error {
+	for _, expr := range exprs {
+		if err := validateExpression(expr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateExpression walks a single expression the same way parameters are collected
+func validateExpression(expr core.Expression) error {
+	if expr == nil {
+		return nil
+	}
+
+	if validatable, ok := expr.(interface{ Validate() error }); ok {
+		if err := validatable.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if container, ok := expr.(interface{ Expressions() []core.Expression }); ok {
+		if err := ValidateExpressions(container.Expressions()...); err != nil {
+			return err
+		}
+	}
+
+	if binaryExpr, ok := expr.(interface {
+		Left() core.Expression
+		Right() core.Expression
+	}); ok {
+		return ValidateExpressions(binaryExpr.Left(), binaryExpr.Right())
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package util
+
+import (
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+)
+
+// HasInlinePredicate reports whether a pattern contains a node with an
+// inline WHERE predicate, which Neo4j only accepts in MATCH patterns
+func HasInlinePredicate(expr core.Expression) bool {
+	if expr == nil {
+		return false
+	}
+
+	if element, ok := expr.(interface{ Predicate() core.Expression }); ok && element.Predicate() != nil {
+		return true
+	}
+
+	if container, ok := expr.(interface{ Expressions() []core.Expression }); ok {
+		for _, subExpr := range container.Expressions() {
+			if HasInlinePredicate(subExpr) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -1,6 +1,7 @@
 package renderer
 
 import (
+	"regexp"
 	"strings"
 
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
@@ -58,29 +59,51 @@ func (r *CypherRenderer) Render(statement core.Statement) string {
 	return cypher
 }
 
-// RenderWithParams renders a statement and returns the Cypher and parameters
+// RenderWithParams renders a statement and returns the Cypher and parameters.
+// The parameters are normalized with core.NormalizeParams so they can be
+// passed to the driver directly. If a parameter cannot be normalized, none
+// of them are: the statement's parameters are returned unchanged and the
+// error is dropped. Use RenderWithParamsE to get the error.
 func (r *CypherRenderer) RenderWithParams(statement core.Statement) (string, map[string]any) {
+	cypher, params, err := r.RenderWithParamsE(statement)
+	if err != nil {
+		return cypher, statement.Params()
+	}
+	return cypher, params
+}
+
+// RenderWithParamsE is like RenderWithParams but returns the error wrapping
+// core.ErrInvalidParameter when a parameter value cannot be sent to Neo4j
+func (r *CypherRenderer) RenderWithParamsE(statement core.Statement) (string, map[string]any, error) {
 	if statement == nil {
-		return "", nil
+		return "", nil, nil
 	}
 
 	cypher := r.Render(statement)
 	params := statement.Params()
-
-	return cypher, params
+	if params == nil {
+		return cypher, nil, nil
+	}
+	normalized, err := core.NormalizeParams(params)
+	if err != nil {
+		return cypher, nil, err
+	}
+	return cypher, normalized, nil
 }
 
+// prettyClausePattern matches the clause keywords that start a new line when
+// pretty printing; compound keywords come first so that they win over MATCH
+// and DELETE
+var prettyClausePattern = regexp.MustCompile(` (OPTIONAL MATCH|DETACH DELETE|ORDER BY|MATCH|WHERE|WITH|RETURN|SKIP|LIMIT|CREATE|MERGE|DELETE|SET|REMOVE|UNWIND) `)
+
 // prettyPrint formats a Cypher query for better readability
 func (r *CypherRenderer) prettyPrint(cypher string) string {
 	// A simple implementation for now
 	// A more sophisticated implementation would parse the query and format it properly
 
-	// Split by keywords
-	keywords := []string{"MATCH", "OPTIONAL MATCH", "WHERE", "WITH", "RETURN", "ORDER BY", "SKIP", "LIMIT", "CREATE", "MERGE", "DELETE", "DETACH DELETE", "SET", "REMOVE", "UNWIND"}
-	for _, keyword := range keywords {
-		// Replace keywords with newline + keyword
-		cypher = strings.ReplaceAll(cypher, " "+keyword+" ", "\n"+keyword+" ")
-	}
+	// Split by keywords. A single pass keeps compound keywords such as
+	// OPTIONAL MATCH and DETACH DELETE on one line.
+	cypher = prettyClausePattern.ReplaceAllString(cypher, "\n$1 ")
 
 	// Add proper indentation
 	lines := strings.Split(cypher, "\n")
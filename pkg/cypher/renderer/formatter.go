@@ -1,18 +1,22 @@
 package renderer
 
 import (
-	"fmt"
-	"regexp"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 // FormattingOptions contains configuration options for Cypher formatting
 type FormattingOptions struct {
 	IndentString     string // String used for indentation
 	KeywordCase      KeywordCase
+	FunctionCase     FunctionCase
 	ClauseNewline    bool // Whether to put each clause on a new line
 	IndentSubClauses bool // Whether to indent subclauses
 	MaxLineLength    int  // Maximum line length before wrapping (0 = no limit)
+	// BreakBooleanOperators puts each top-level AND/OR of a WHERE condition
+	// on its own line, aligned under the first condition
+	BreakBooleanOperators bool
 }
 
 // KeywordCase defines how to format Cypher keywords
@@ -27,6 +31,39 @@ const (
 	KeywordCaseLower
 )
 
+// FunctionCase defines how to format the names of built-in function calls
+type FunctionCase int
+
+const (
+	// FunctionCaseAsIs leaves function names as they are
+	FunctionCaseAsIs FunctionCase = iota
+	// FunctionCaseUpper converts function names to uppercase
+	FunctionCaseUpper
+	// FunctionCaseLower converts function names to lowercase
+	FunctionCaseLower
+)
+
+// keywordWords contains every word of the clause and operator keywords,
+// so that multi-word keywords such as ORDER BY, STARTS WITH and IS NOT NULL
+// are cased consistently word by word
+var keywordWords = map[string]bool{
+	"MATCH": true, "OPTIONAL": true, "WHERE": true, "WITH": true, "RETURN": true,
+	"ORDER": true, "BY": true, "SKIP": true, "LIMIT": true, "CREATE": true,
+	"MERGE": true, "DELETE": true, "DETACH": true, "SET": true, "REMOVE": true,
+	"UNWIND": true, "CALL": true, "YIELD": true, "UNION": true, "ALL": true,
+	"ON": true, "AND": true, "OR": true, "XOR": true, "NOT": true, "IN": true,
+	"IS": true, "NULL": true, "STARTS": true, "ENDS": true, "CONTAINS": true,
+	"AS": true, "DISTINCT": true, "ASC": true, "DESC": true, "ASCENDING": true,
+	"DESCENDING": true, "CASE": true, "WHEN": true, "THEN": true, "ELSE": true, "END": true,
+}
+
+// keywordContinuations are the words after which a clause keyword is part
+// of a longer construct (STARTS WITH, ON CREATE SET) rather than a new clause
+var keywordContinuations = map[string]bool{
+	"STARTS": true, "ENDS": true, "OPTIONAL": true, "DETACH": true,
+	"ON": true, "CREATE": true, "MATCH": true,
+}
+
 // DefaultFormattingOptions returns a default set of formatting options
 func DefaultFormattingOptions() FormattingOptions {
 	return FormattingOptions{
@@ -57,58 +94,32 @@ func NewDefaultFormatter() *CypherFormatter {
 
 // Format formats a Cypher query string
 func (f *CypherFormatter) Format(query string) string {
-	// List of Cypher keywords that should trigger formatter rules
-	keywords := []string{
-		"MATCH", "OPTIONAL MATCH", "WHERE", "WITH", "RETURN", "ORDER BY",
-		"SKIP", "LIMIT", "CREATE", "MERGE", "DELETE", "DETACH DELETE",
-		"SET", "REMOVE", "UNWIND", "CALL", "YIELD", "UNION", "UNION ALL",
-	}
-
 	// First pass: normalize whitespace
 	query = normalizeWhitespace(query)
 
-	// Format keywords according to case preference
-	for _, keyword := range keywords {
-		var replacement string
-		switch f.options.KeywordCase {
-		case KeywordCaseUpper:
-			replacement = strings.ToUpper(keyword)
-		case KeywordCaseLower:
-			replacement = strings.ToLower(keyword)
-		default:
-			replacement = keyword
-		}
-
-		// Replace only stand-alone keywords (not substrings of other words)
-		// This uses a case-insensitive search to find keywords regardless of their current case
-		regex := fmt.Sprintf(`(?i)(^|\s)%s(\s|$)`, regexp.QuoteMeta(keyword))
-		query = regexp.MustCompile(regex).ReplaceAllStringFunc(query, func(match string) string {
-			// Preserve the spacing around the keyword
-			if strings.HasPrefix(match, " ") && strings.HasSuffix(match, " ") {
-				return " " + replacement + " "
-			} else if strings.HasPrefix(match, " ") {
-				return " " + replacement
-			} else if strings.HasSuffix(match, " ") {
-				return replacement + " "
-			}
-			return replacement
-		})
-	}
+	// Variables keep their name even when it is also a keyword, e.g. (order:Order)
+	variables := declaredVariables(query)
+
+	// Format keywords and function names according to case preference
+	query = f.applyCase(query, variables)
 
 	// Apply formatting rules if requested
 	if f.options.ClauseNewline {
-		// Replace keywords with newline + keyword
-		for _, keyword := range keywords {
-			if f.options.KeywordCase == KeywordCaseUpper {
-				keyword = strings.ToUpper(keyword)
-			} else if f.options.KeywordCase == KeywordCaseLower {
-				keyword = strings.ToLower(keyword)
+		// Start each top-level clause on a new line; a leading comment stays
+		// on the line of the first clause
+		var sb strings.Builder
+		words := topLevelWords(query)
+		written := 0
+		for i, w := range words {
+			if i == 0 || variables[w.text] || !startsClause(query, words, i) {
+				continue
 			}
-
-			pattern := fmt.Sprintf(` %s `, keyword)
-			replacement := fmt.Sprintf("\n%s ", keyword)
-			query = strings.ReplaceAll(query, pattern, replacement)
+			sb.WriteString(strings.TrimRight(query[written:w.start], " "))
+			sb.WriteString("\n")
+			written = w.start
 		}
+		sb.WriteString(query[written:])
+		query = sb.String()
 
 		// Split into lines and indent
 		lines := strings.Split(query, "\n")
@@ -121,19 +132,381 @@ func (f *CypherFormatter) Format(query string) string {
 		query = strings.Join(lines, "\n")
 	}
 
+	if f.options.BreakBooleanOperators {
+		query = breakBooleanOperators(query)
+	}
+
 	return query
 }
 
-// normalizeWhitespace reduces multiple spaces to a single space
-func normalizeWhitespace(s string) string {
-	// Replace tabs and newlines with spaces
-	s = strings.ReplaceAll(s, "\t", " ")
-	s = strings.ReplaceAll(s, "\n", " ")
+// clauseWords are the words that start a clause
+var clauseWords = map[string]bool{
+	"MATCH": true, "OPTIONAL": true, "WHERE": true, "WITH": true, "RETURN": true, "ORDER": true,
+	"SKIP": true, "LIMIT": true, "CREATE": true, "MERGE": true, "DELETE": true,
+	"DETACH": true, "SET": true, "REMOVE": true, "UNWIND": true, "CALL": true,
+	"YIELD": true, "UNION": true,
+}
+
+// word is a word found outside brackets and string literals, with its byte offsets
+type word struct {
+	text       string
+	start, end int
+}
+
+// topLevelWords returns the words of s that are not nested in brackets,
+// string literals, backtick-quoted names or comments
+func topLevelWords(s string) []word {
+	var words []word
+	depth := 0
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case quotedEnd(s, i) >= 0:
+			i = quotedEnd(s, i)
+		case c == '(' || c == '[' || c == '{':
+			depth++
+			i++
+		case c == ')' || c == ']' || c == '}':
+			depth--
+			i++
+		case c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+			start := i
+			for i < len(s) && (s[i] == '_' || s[i] >= 'a' && s[i] <= 'z' || s[i] >= 'A' && s[i] <= 'Z' || s[i] >= '0' && s[i] <= '9') {
+				i++
+			}
+			if depth == 0 && (start == 0 || !strings.ContainsRune(".:$", rune(s[start-1]))) {
+				words = append(words, word{text: s[start:i], start: start, end: i})
+			}
+		default:
+			i++
+		}
+	}
+	return words
+}
+
+// startsClause reports whether words[i] begins a clause, as opposed to being
+// the second word of STARTS WITH, OPTIONAL MATCH, ON CREATE SET and the like
+func startsClause(query string, words []word, i int) bool {
+	if !clauseWords[strings.ToUpper(words[i].text)] {
+		return false
+	}
+	if i == 0 {
+		return true
+	}
+	prev := words[i-1]
+	adjacent := strings.TrimSpace(query[prev.end:words[i].start]) == ""
+	return !adjacent || !keywordContinuations[strings.ToUpper(prev.text)]
+}
+
+// breakBooleanOperators rewrites every WHERE condition with top-level AND/OR
+// operators so that each operand is on its own line, with the operators
+// right-aligned to the WHERE keyword:
+//
+//	WHERE p.age > 30
+//	  AND p.name = $name
+//	   OR p.admin
+func breakBooleanOperators(query string) string {
+	var sb strings.Builder
+	words := topLevelWords(query)
+	written := 0
+
+	for i, w := range words {
+		if !strings.EqualFold(w.text, "WHERE") {
+			continue
+		}
+
+		end := len(query)
+		for j := i + 1; j < len(words); j++ {
+			if startsClause(query, words, j) {
+				end = words[j].start
+				break
+			}
+		}
+
+		condition := strings.TrimRightFunc(query[w.end:end], unicode.IsSpace)
+		terms, operators := booleanTerms(condition)
+		if len(operators) == 0 {
+			continue
+		}
+
+		// Continuation lines start with the same indentation as the WHERE line
+		lineStart := strings.LastIndex(query[:w.start], "\n") + 1
+		indent := query[lineStart:w.start]
+		if strings.TrimSpace(indent) != "" {
+			indent = strings.Repeat(" ", len([]rune(indent)))
+		}
+
+		sb.WriteString(query[written:w.end])
+		sb.WriteString(" ")
+		sb.WriteString(terms[0])
+		for k, operator := range operators {
+			sb.WriteString("\n")
+			sb.WriteString(indent)
+			sb.WriteString(strings.Repeat(" ", max(len(w.text)-len(operator), 0)))
+			sb.WriteString(operator)
+			sb.WriteString(" ")
+			sb.WriteString(terms[k+1])
+		}
+		written = w.end + len(condition)
+	}
+
+	sb.WriteString(query[written:])
+	return sb.String()
+}
+
+// booleanTerms splits a condition at its top-level AND/OR operators.
+// Parentheses around the whole condition are removed, and nested groups
+// joined by the same operator are flattened, so that ((a AND b) AND c)
+// yields the terms a, b and c.
+func booleanTerms(condition string) ([]string, []string) {
+	condition = strings.TrimSpace(condition)
+	if inner, ok := unwrapParentheses(condition); ok {
+		if terms, operators := booleanTerms(inner); len(operators) > 0 {
+			return terms, operators
+		}
+	}
+
+	var terms, operators []string
+	start := 0
+	for _, w := range topLevelWords(condition) {
+		if !strings.EqualFold(w.text, "AND") && !strings.EqualFold(w.text, "OR") {
+			continue
+		}
+		terms = append(terms, strings.TrimSpace(condition[start:w.start]))
+		operators = append(operators, w.text)
+		start = w.end
+	}
+	terms = append(terms, strings.TrimSpace(condition[start:]))
+	if len(operators) == 0 || !sameOperator(operators) {
+		return terms, operators
+	}
+
+	var flatTerms, flatOperators []string
+	for i, term := range terms {
+		if i > 0 {
+			flatOperators = append(flatOperators, operators[i-1])
+		}
+		if inner, ok := unwrapParentheses(term); ok {
+			innerTerms, innerOperators := booleanTerms(inner)
+			if len(innerOperators) > 0 && sameOperator(append(innerOperators, operators[0])) {
+				flatTerms = append(flatTerms, innerTerms...)
+				flatOperators = append(flatOperators, innerOperators...)
+				continue
+			}
+		}
+		flatTerms = append(flatTerms, term)
+	}
+	return flatTerms, flatOperators
+}
+
+// sameOperator reports whether all operators are the same, ignoring case
+func sameOperator(operators []string) bool {
+	for _, operator := range operators[1:] {
+		if !strings.EqualFold(operator, operators[0]) {
+			return false
+		}
+	}
+	return true
+}
+
+// unwrapParentheses returns s without the parentheses that enclose all of it
+func unwrapParentheses(s string) (string, bool) {
+	if !strings.HasPrefix(s, "(") || !strings.HasSuffix(s, ")") {
+		return s, false
+	}
+	// The opening parenthesis must close at the very end, not in (a) AND (b)
+	inner := s[1 : len(s)-1]
+	if !balanced(inner) {
+		return s, false
+	}
+	return inner, true
+}
+
+// balanced reports whether the brackets in s, outside string literals and
+// comments, are balanced without ever closing more than they opened
+func balanced(s string) bool {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		if end := quotedEnd(s, i); end >= 0 {
+			i = end - 1
+			continue
+		}
+		switch s[i] {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+			if depth < 0 {
+				return false
+			}
+		}
+	}
+	return depth == 0
+}
+
+// applyCase changes the case of keywords and function names. String
+// literals, backtick-quoted names, comments, parameters, property and map
+// keys, labels and variables are left untouched.
+func (f *CypherFormatter) applyCase(query string, variables map[string]bool) string {
+	var sb strings.Builder
+
+	for i := 0; i < len(query); {
+		if end := quotedEnd(query, i); end >= 0 {
+			sb.WriteString(query[i:end])
+			i = end
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(query[i:])
+		if !isWordRune(r) {
+			sb.WriteString(query[i : i+size])
+			i += size
+			continue
+		}
+		start := i
+		i = wordEnd(query, i)
+		word := query[start:i]
+		if start > 0 && strings.ContainsRune(".:$", rune(query[start-1])) || unicode.IsDigit(r) ||
+			variables[word] || isMapKey(query, i) {
+			sb.WriteString(word)
+			continue
+		}
+		sb.WriteString(f.caseWord(word, nextNonSpace(query, i)))
+	}
+
+	return sb.String()
+}
+
+// declaredVariables returns the names the query binds as variables: those
+// opening a node or relationship pattern, such as order in (order:Order),
+// and those following AS
+func declaredVariables(query string) map[string]bool {
+	variables := make(map[string]bool)
+	previous := ""
+	for i := 0; i < len(query); {
+		if end := quotedEnd(query, i); end >= 0 {
+			i = end
+			previous = ""
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(query[i:])
+		if !isWordRune(r) {
+			i += size
+			continue
+		}
+		start := i
+		i = wordEnd(query, i)
+		word := query[start:i]
+		opener := strings.TrimRightFunc(query[:start], unicode.IsSpace)
+		inPattern := strings.HasSuffix(opener, "(") || strings.HasSuffix(opener, "[")
+		if inPattern && strings.ContainsRune(":{)]", nextNonSpace(query, i)) || strings.EqualFold(previous, "AS") {
+			variables[word] = true
+		}
+		previous = word
+	}
+	return variables
+}
+
+// quotedEnd returns the offset just past the string literal, backtick-quoted
+// name or /* */ comment that starts at s[i], or -1 if none starts there
+func quotedEnd(s string, i int) int {
+	switch c := s[i]; {
+	case c == '\'' || c == '"' || c == '`':
+		j := i + 1
+		for j < len(s) && s[j] != c {
+			if s[j] == '\\' && c != '`' {
+				j++
+			}
+			j++
+		}
+		return min(j+1, len(s))
+	case strings.HasPrefix(s[i:], "/*"):
+		if end := strings.Index(s[i+2:], "*/"); end >= 0 {
+			return i + 2 + end + 2
+		}
+		return len(s)
+	}
+	return -1
+}
+
+// wordEnd returns the offset just past the word that starts at s[i]
+func wordEnd(s string, i int) int {
+	for i < len(s) {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if !isWordRune(r) {
+			break
+		}
+		i += size
+	}
+	return i
+}
+
+// isMapKey reports whether the word ending at s[i] is followed by a single
+// colon, as the keys of map literals such as {end: 2} are; the :: of a
+// type predicate does not count
+func isMapKey(s string, i int) bool {
+	rest := strings.TrimLeftFunc(s[i:], unicode.IsSpace)
+	return strings.HasPrefix(rest, ":") && !strings.HasPrefix(rest, "::")
+}
+
+// caseWord applies the keyword or function case to a single word
+func (f *CypherFormatter) caseWord(word string, next rune) string {
+	upper := strings.ToUpper(word)
+	switch {
+	case next == '(' && (!keywordWords[upper] || upper == "ALL"):
+		// A function call such as count(n) or all(x IN list WHERE ...)
+		switch f.options.FunctionCase {
+		case FunctionCaseUpper:
+			return upper
+		case FunctionCaseLower:
+			return strings.ToLower(word)
+		}
+	case keywordWords[upper] && next != '.':
+		switch f.options.KeywordCase {
+		case KeywordCaseUpper:
+			return upper
+		case KeywordCaseLower:
+			return strings.ToLower(word)
+		}
+	}
+	return word
+}
+
+// isWordRune reports whether r can be part of a keyword or identifier
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
 
-	// Collapse multiple spaces into a single space
-	for strings.Contains(s, "  ") {
-		s = strings.ReplaceAll(s, "  ", " ")
+// nextNonSpace returns the first non-space rune at or after s[i], or 0 if there is none
+func nextNonSpace(s string, i int) rune {
+	for _, r := range s[i:] {
+		if !unicode.IsSpace(r) {
+			return r
+		}
 	}
+	return 0
+}
 
-	return strings.TrimSpace(s)
+// normalizeWhitespace reduces runs of whitespace to a single space, leaving
+// string literals, quoted names and comments as they are
+func normalizeWhitespace(s string) string {
+	var sb strings.Builder
+	space := false
+	for i := 0; i < len(s); {
+		end := quotedEnd(s, i)
+		if end < 0 && strings.ContainsRune(" \t\r\n", rune(s[i])) {
+			space = true
+			i++
+			continue
+		}
+		if space && sb.Len() > 0 {
+			sb.WriteByte(' ')
+		}
+		space = false
+		if end < 0 {
+			end = i + 1
+		}
+		sb.WriteString(s[i:end])
+		i = end
+	}
+	return sb.String()
 }
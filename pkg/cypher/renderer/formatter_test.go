@@ -0,0 +1,164 @@
+package renderer
+
+import (
+	"testing"
+)
+
+func TestFormatKeywordAndFunctionCase(t *testing.T) {
+	query := "match (n:Person) where n.name starts with 'match me' and n.age is not null " +
+		"return Count(n) AS total, n.order order by toLower(n.name) desc"
+
+	tests := []struct {
+		name    string
+		options FormattingOptions
+		want    string
+	}{
+		{
+			name:    "upper keywords, functions as is",
+			options: FormattingOptions{KeywordCase: KeywordCaseUpper},
+			want: "MATCH (n:Person) WHERE n.name STARTS WITH 'match me' AND n.age IS NOT NULL " +
+				"RETURN Count(n) AS total, n.order ORDER BY toLower(n.name) DESC",
+		},
+		{
+			name:    "lower keywords, lower functions",
+			options: FormattingOptions{KeywordCase: KeywordCaseLower, FunctionCase: FunctionCaseLower},
+			want: "match (n:Person) where n.name starts with 'match me' and n.age is not null " +
+				"return count(n) as total, n.order order by tolower(n.name) desc",
+		},
+		{
+			name:    "keywords as is, upper functions",
+			options: FormattingOptions{FunctionCase: FunctionCaseUpper},
+			want: "match (n:Person) where n.name starts with 'match me' and n.age is not null " +
+				"return COUNT(n) AS total, n.order order by TOLOWER(n.name) desc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewCypherFormatter(tt.options).Format(query); got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatFunctionCaseSkipsNamespacesAndKeywords(t *testing.T) {
+	formatter := NewCypherFormatter(FormattingOptions{KeywordCase: KeywordCaseUpper, FunctionCase: FunctionCaseLower})
+
+	got := formatter.Format("call apoc.coll.sumLongs($list) yield value where ALL(x in value where x > 0) and not (value in [1]) return `Count`(value), $Param")
+	want := "CALL apoc.coll.sumLongs($list) YIELD value WHERE all(x IN value WHERE x > 0) AND NOT (value IN [1]) RETURN `Count`(value), $Param"
+	if got != want {
+		t.Errorf("Format() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatClauseNewline(t *testing.T) {
+	formatter := NewCypherFormatter(FormattingOptions{
+		IndentString:     "  ",
+		ClauseNewline:    true,
+		IndentSubClauses: true,
+	})
+
+	got := formatter.Format("match (n) where n.age > 30 return n")
+	want := "match (n)\n  where n.age > 30\n  return n"
+	if got != want {
+		t.Errorf("Format() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatClauseNewlineKeepsCompoundKeywords(t *testing.T) {
+	formatter := NewCypherFormatter(FormattingOptions{KeywordCase: KeywordCaseUpper, ClauseNewline: true})
+
+	got := formatter.Format("optional match (n) where n.name starts with 'a' merge (m) on create set m.x = 1 detach delete n")
+	want := "OPTIONAL MATCH (n)\nWHERE n.name STARTS WITH 'a'\nMERGE (m) ON CREATE SET m.x = 1\nDETACH DELETE n"
+	if got != want {
+		t.Errorf("Format() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatBreakBooleanOperators(t *testing.T) {
+	tests := []struct {
+		name    string
+		options FormattingOptions
+		query   string
+		want    string
+	}{
+		{
+			name:    "flattens nested AND",
+			options: FormattingOptions{BreakBooleanOperators: true},
+			query:   "MATCH (p) WHERE (((p.age > 30) AND (p.name = $name)) AND (p.active = true)) RETURN p",
+			want:    "MATCH (p) WHERE (p.age > 30)\n            AND (p.name = $name)\n            AND (p.active = true) RETURN p",
+		},
+		{
+			name:    "keeps nested groups of another operator",
+			options: FormattingOptions{IndentString: "  ", ClauseNewline: true, IndentSubClauses: true, BreakBooleanOperators: true},
+			query:   "MATCH (p) WHERE (((p.age > 30) AND (p.name = 'a or b')) OR (p.admin IS NULL)) RETURN p",
+			want:    "MATCH (p)\n  WHERE ((p.age > 30) AND (p.name = 'a or b'))\n     OR (p.admin IS NULL)\n  RETURN p",
+		},
+		{
+			name:    "ignores operators in comprehensions",
+			options: FormattingOptions{ClauseNewline: true, BreakBooleanOperators: true},
+			query:   "MATCH (p) WHERE any(x IN p.tags WHERE x = 'a' OR x = 'b') AND p.name STARTS WITH 'J' RETURN p",
+			want:    "MATCH (p)\nWHERE any(x IN p.tags WHERE x = 'a' OR x = 'b')\n  AND p.name STARTS WITH 'J'\nRETURN p",
+		},
+		{
+			name:    "single condition",
+			options: FormattingOptions{BreakBooleanOperators: true},
+			query:   "MATCH (p) WHERE (p.age > 30) RETURN p",
+			want:    "MATCH (p) WHERE (p.age > 30) RETURN p",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewCypherFormatter(tt.options).Format(tt.query); got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatKeepsKeywordLikeKeysAndVariables(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{
+			query: "create (n:Item {desc: 'x', end: 2, order: 1}) return n",
+			want:  "CREATE (n:Item {desc: 'x', end: 2, order: 1})\n  RETURN n",
+		},
+		{
+			query: "MATCH (order:Order)-[in:IN]->(end) WITH order, count(in) AS set WHERE order.total > 1 RETURN order, set ORDER BY set DESC",
+			want:  "MATCH (order:Order)-[in:IN]->(end)\n  WITH order, count(in) AS set\n  WHERE order.total > 1\n  RETURN order, set\n  ORDER BY set DESC",
+		},
+		{
+			query: "match (n) where n.age is :: integer return n {.name, end: n.end}",
+			want:  "MATCH (n)\n  WHERE n.age IS :: integer\n  RETURN n {.name, end: n.end}",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := NewDefaultFormatter().Format(tt.query); got != tt.want {
+			t.Errorf("Format(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestFormatKeepsComments(t *testing.T) {
+	options := DefaultFormattingOptions()
+	options.BreakBooleanOperators = true
+
+	query := "/* return  active users where name and age match */ match (p) where p.age > 30 and p.active return p"
+	want := "/* return  active users where name and age match */ MATCH (p)\n" +
+		"  WHERE p.age > 30\n" +
+		"    AND p.active\n" +
+		"  RETURN p"
+	if got := NewCypherFormatter(options).Format(query); got != want {
+		t.Errorf("Format() = %q, want %q", got, want)
+	}
+
+	got := NewDefaultFormatter().Format("match (p) where p.name = 'a  and  b' return p")
+	if want := "MATCH (p)\n  WHERE p.name = 'a  and  b'\n  RETURN p"; got != want {
+		t.Errorf("Format() = %q, want %q", got, want)
+	}
+}
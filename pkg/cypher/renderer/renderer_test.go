@@ -1,6 +1,7 @@
 package renderer
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
@@ -58,6 +59,37 @@ func TestRenderWithParams(t *testing.T) {
 	}
 }
 
+func TestRenderWithParamsNormalizes(t *testing.T) {
+	params := map[string]any{"ids": []int{1, 2}, "limit": 10}
+	stmt := core.NewStatement("MATCH (n) WHERE n.id IN $ids RETURN n LIMIT $limit", params)
+	_, resultParams := NewCypherRenderer().RenderWithParams(stmt)
+
+	if _, ok := resultParams["ids"].([]int64); !ok {
+		t.Errorf("RenderWithParams() ids = %#v, want []int64", resultParams["ids"])
+	}
+	if resultParams["limit"] != int64(10) {
+		t.Errorf("RenderWithParams() limit = %#v, want int64(10)", resultParams["limit"])
+	}
+}
+
+func TestRenderWithParamsInvalidValue(t *testing.T) {
+	params := map[string]any{"limit": 10, "user": struct{ Name string }{"Ann"}}
+	stmt := core.NewStatement("MATCH (n) WHERE n.name = $user RETURN n LIMIT $limit", params)
+
+	cypher, resultParams, err := NewCypherRenderer().RenderWithParamsE(stmt)
+	if !errors.Is(err, core.ErrInvalidParameter) {
+		t.Errorf("RenderWithParamsE() error = %v, want ErrInvalidParameter", err)
+	}
+	if cypher != stmt.Cypher() || resultParams != nil {
+		t.Errorf("RenderWithParamsE() = %q, %v, want the cypher and no parameters", cypher, resultParams)
+	}
+
+	_, resultParams = NewCypherRenderer().RenderWithParams(stmt)
+	if resultParams["limit"] != 10 {
+		t.Errorf("RenderWithParams() limit = %#v, want the unnormalized 10", resultParams["limit"])
+	}
+}
+
 func TestRenderWithParamsNilStatement(t *testing.T) {
 	renderer := NewCypherRenderer()
 	cypher, params := renderer.RenderWithParams(nil)
@@ -81,3 +113,12 @@ func TestWithIndentString(t *testing.T) {
 	}
 }
 
+func TestRenderWithPrettyPrintKeepsCompoundKeywords(t *testing.T) {
+	stmt := core.NewStatement("MATCH (p) WITH p OPTIONAL MATCH (p)-->(m) DETACH DELETE m", nil)
+	result := NewCypherRenderer().WithPrettyPrint(true).WithIndentString("").Render(stmt)
+
+	expected := "MATCH (p)\nWITH p\nOPTIONAL MATCH (p)-->(m)\nDETACH DELETE m"
+	if result != expected {
+		t.Errorf("Render() with pretty print = %q, want %q", result, expected)
+	}
+}
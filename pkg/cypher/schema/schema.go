@@ -1,4 +1,7 @@
-// Package schema provides helper functions for working with Neo4j schema operations
+// Package schema provides helper functions for working with Neo4j schema operations.
+// The statements use the Neo4j 5 syntax, with names, labels and properties
+// escaped with backticks where needed. CREATE statements include IF NOT
+// EXISTS and DROP statements IF EXISTS, so that migrations can be run again.
 package schema
 
 import (
@@ -6,24 +9,18 @@ import (
 	"strings"
 
 	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/expr"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/internal/util"
 )
 
 // CreateNodeKeyConstraint generates a Cypher statement to create a node key constraint
 func CreateNodeKeyConstraint(constraintName string, label string, properties ...string) (core.Statement, error) {
 	if len(properties) == 0 {
-		return nil, fmt.Errorf("at least one property is required for a node key constraint")
+		return nil, core.NewError(core.ErrNoProperties, "at least one property is required for a node key constraint")
 	}
 
-	var propsList strings.Builder
-	for i, prop := range properties {
-		if i > 0 {
-			propsList.WriteString(", ")
-		}
-		propsList.WriteString(prop)
-	}
-
-	query := fmt.Sprintf("CREATE CONSTRAINT %s IF NOT EXISTS FOR (n:%s) REQUIRE (n.%s) IS NODE KEY",
-		constraintName, label, propsList.String())
+	query := fmt.Sprintf("CREATE CONSTRAINT %s IF NOT EXISTS FOR (n:%s) REQUIRE (%s) IS NODE KEY",
+		expr.QuoteIdentifier(constraintName), expr.QuoteIdentifier(label), propertyList(properties))
 
 	return core.NewStatement(query, nil), nil
 }
@@ -31,7 +28,7 @@ func CreateNodeKeyConstraint(constraintName string, label string, properties ...
 // CreateUniqueConstraint generates a Cypher statement to create a uniqueness constraint
 func CreateUniqueConstraint(constraintName string, label string, property string) (core.Statement, error) {
 	query := fmt.Sprintf("CREATE CONSTRAINT %s IF NOT EXISTS FOR (n:%s) REQUIRE n.%s IS UNIQUE",
-		constraintName, label, property)
+		expr.QuoteIdentifier(constraintName), expr.QuoteIdentifier(label), expr.QuoteIdentifier(property))
 
 	return core.NewStatement(query, nil), nil
 }
@@ -39,7 +36,7 @@ func CreateUniqueConstraint(constraintName string, label string, property string
 // CreateExistsConstraint generates a Cypher statement to create a property existence constraint
 func CreateExistsConstraint(constraintName string, label string, property string) (core.Statement, error) {
 	query := fmt.Sprintf("CREATE CONSTRAINT %s IF NOT EXISTS FOR (n:%s) REQUIRE n.%s IS NOT NULL",
-		constraintName, label, property)
+		expr.QuoteIdentifier(constraintName), expr.QuoteIdentifier(label), expr.QuoteIdentifier(property))
 
 	return core.NewStatement(query, nil), nil
 }
@@ -47,83 +44,180 @@ func CreateExistsConstraint(constraintName string, label string, property string
 // CreateRelationshipConstraint generates a Cypher statement to create a relationship constraint
 func CreateRelationshipConstraint(constraintName string, relType string, property string) (core.Statement, error) {
 	query := fmt.Sprintf("CREATE CONSTRAINT %s IF NOT EXISTS FOR ()-[r:%s]-() REQUIRE r.%s IS NOT NULL",
-		constraintName, relType, property)
+		expr.QuoteIdentifier(constraintName), expr.QuoteIdentifier(relType), expr.QuoteIdentifier(property))
 
 	return core.NewStatement(query, nil), nil
 }
 
-// CreateIndex generates a Cypher statement to create an index
+// CreateIndex generates a Cypher statement to create an index of the default
+// type, which is a range index in Neo4j 5
 func CreateIndex(indexName string, label string, properties ...string) (core.Statement, error) {
-	if len(properties) == 0 {
-		return nil, fmt.Errorf("at least one property is required for an index")
+	return createIndex("INDEX", indexName, label, properties)
+}
+
+// CreateRangeIndex generates a Cypher statement to create a range index
+func CreateRangeIndex(indexName string, label string, properties ...string) (core.Statement, error) {
+	return createIndex("RANGE INDEX", indexName, label, properties)
+}
+
+// CreateTextIndex generates a Cypher statement to create a text index, which
+// speeds up CONTAINS and ENDS WITH on a single string property
+func CreateTextIndex(indexName string, label string, properties ...string) (core.Statement, error) {
+	if len(properties) > 1 {
+		return nil, core.NewError(core.ErrInvalidProperty, "a text index covers a single property")
 	}
+	return createIndex("TEXT INDEX", indexName, label, properties)
+}
 
-	var propsList strings.Builder
-	for i, prop := range properties {
-		if i > 0 {
-			propsList.WriteString(", ")
-		}
-		propsList.WriteString("n." + prop)
+// CreatePointIndex generates a Cypher statement to create a point index for
+// spatial queries on a single point property
+func CreatePointIndex(indexName string, label string, properties ...string) (core.Statement, error) {
+	if len(properties) > 1 {
+		return nil, core.NewError(core.ErrInvalidProperty, "a point index covers a single property")
 	}
+	return createIndex("POINT INDEX", indexName, label, properties)
+}
 
-	query := fmt.Sprintf("CREATE INDEX %s IF NOT EXISTS FOR (n:%s) ON (%s)",
-		indexName, label, propsList.String())
+// createIndex generates the CREATE statement of an index of the given kind,
+// e.g. TEXT INDEX
+func createIndex(kind string, indexName string, label string, properties []string) (core.Statement, error) {
+	if len(properties) == 0 {
+		return nil, core.NewError(core.ErrNoProperties, "at least one property is required for an index")
+	}
+
+	query := fmt.Sprintf("CREATE %s %s IF NOT EXISTS FOR (n:%s) ON (%s)",
+		kind, expr.QuoteIdentifier(indexName), expr.QuoteIdentifier(label), propertyList(properties))
 
 	return core.NewStatement(query, nil), nil
 }
 
-// CreateFullTextIndex generates a Cypher statement to create a full-text search index
+// CreateFullTextIndex generates a Cypher statement to create a full-text search
+// index over the given properties of nodes with any of the labels
 func CreateFullTextIndex(indexName string, labels []string, properties []string) (core.Statement, error) {
 	if len(labels) == 0 {
-		return nil, fmt.Errorf("at least one label is required for a full-text index")
+		return nil, core.NewError(core.ErrNoLabels, "at least one label is required for a full-text index")
 	}
 
 	if len(properties) == 0 {
-		return nil, fmt.Errorf("at least one property is required for a full-text index")
+		return nil, core.NewError(core.ErrNoProperties, "at least one property is required for a full-text index")
 	}
 
-	var labelsList strings.Builder
+	quotedLabels := make([]string, len(labels))
 	for i, label := range labels {
-		if i > 0 {
-			labelsList.WriteString(", ")
-		}
-		labelsList.WriteString(fmt.Sprintf("'%s'", label))
+		quotedLabels[i] = expr.QuoteIdentifier(label)
 	}
 
-	var propsList strings.Builder
-	for i, prop := range properties {
-		if i > 0 {
-			propsList.WriteString(", ")
-		}
-		propsList.WriteString(fmt.Sprintf("'%s'", prop))
+	query := fmt.Sprintf("CREATE FULLTEXT INDEX %s IF NOT EXISTS FOR (n:%s) ON EACH [%s]",
+		expr.QuoteIdentifier(indexName), strings.Join(quotedLabels, "|"), propertyList(properties))
+
+	return core.NewStatement(query, nil), nil
+}
+
+// CreateVectorIndex generates a Cypher statement to create a vector index over
+// embeddings with the given number of dimensions, compared with the cosine or
+// euclidean similarity function. Vector indexes require Neo4j 5.13 or later.
+func CreateVectorIndex(indexName string, label string, property string, dimensions int, similarity string) (core.Statement, error) {
+	if dimensions <= 0 {
+		return nil, core.NewError(core.ErrInvalidParameter,
+			fmt.Sprintf("a vector index needs a positive number of dimensions, got %d", dimensions))
+	}
+	similarity = strings.ToLower(similarity)
+	if similarity != "cosine" && similarity != "euclidean" {
+		return nil, core.NewError(core.ErrInvalidParameter,
+			fmt.Sprintf("unknown vector similarity function %q, want cosine or euclidean", similarity))
 	}
 
-	query := fmt.Sprintf("CALL db.index.fulltext.createNodeIndex('%s', [%s], [%s])",
-		indexName, labelsList.String(), propsList.String())
+	query := fmt.Sprintf("CREATE VECTOR INDEX %s IF NOT EXISTS FOR (n:%s) ON (n.%s) "+
+		"OPTIONS { indexConfig: { `vector.dimensions`: %d, `vector.similarity_function`: '%s' } }",
+		expr.QuoteIdentifier(indexName), expr.QuoteIdentifier(label), expr.QuoteIdentifier(property), dimensions, similarity)
 
 	return core.NewStatement(query, nil), nil
 }
 
-// DropConstraint generates a Cypher statement to drop a constraint
+// propertyList renders properties of the node n, e.g. n.id, n.`e-mail`
+func propertyList(properties []string) string {
+	items := make([]string, len(properties))
+	for i, property := range properties {
+		items[i] = "n." + expr.QuoteIdentifier(property)
+	}
+	return strings.Join(items, ", ")
+}
+
+// DropConstraint generates a Cypher statement to drop a constraint; it
+// includes IF EXISTS, so it does not fail when the constraint is gone
 func DropConstraint(constraintName string) (core.Statement, error) {
-	query := fmt.Sprintf("DROP CONSTRAINT %s IF EXISTS", constraintName)
+	query := fmt.Sprintf("DROP CONSTRAINT %s IF EXISTS", expr.QuoteIdentifier(constraintName))
 
 	return core.NewStatement(query, nil), nil
 }
 
-// DropIndex generates a Cypher statement to drop an index
+// DropIndex generates a Cypher statement to drop an index; it includes IF
+// EXISTS, so it does not fail when the index is gone
 func DropIndex(indexName string) (core.Statement, error) {
-	query := fmt.Sprintf("DROP INDEX %s IF EXISTS", indexName)
+	query := fmt.Sprintf("DROP INDEX %s IF EXISTS", expr.QuoteIdentifier(indexName))
 
 	return core.NewStatement(query, nil), nil
 }
 
+// ShowStatement is a SHOW CONSTRAINTS or SHOW INDEXES command. It is a
+// complete statement that Yield and Where narrow down, e.g.
+// SHOW INDEXES YIELD name, state WHERE state <> 'ONLINE' to wait for
+// indexes to come online.
+type ShowStatement struct {
+	*core.StatementImpl
+	command string
+	fields  []string
+	where   core.Expression
+}
+
+// newShowStatement renders a SHOW command with its YIELD and WHERE parts
+func newShowStatement(command string, fields []string, where core.Expression) *ShowStatement {
+	query := command
+	if len(fields) > 0 {
+		quoted := make([]string, len(fields))
+		for i, field := range fields {
+			quoted[i] = field
+			if field != "*" {
+				quoted[i] = expr.QuoteIdentifier(field)
+			}
+		}
+		query += " YIELD " + strings.Join(quoted, ", ")
+	}
+
+	// Where cannot fail, so a name bound to two values in the condition keeps
+	// the later one rather than being reported
+	params := util.NewParameters(nil)
+	if where != nil {
+		_ = params.Add(where)
+		query += " WHERE " + where.String()
+	}
+
+	return &ShowStatement{
+		StatementImpl: core.NewStatement(params.Render(query), params.Values()),
+		command:       command,
+		fields:        fields,
+		where:         where,
+	}
+}
+
+// Yield returns a copy of the command that only returns the given columns,
+// or all of them for "*"
+func (s *ShowStatement) Yield(fields ...string) *ShowStatement {
+	return newShowStatement(s.command, fields, s.where)
+}
+
+// Where returns a copy of the command that only returns the rows matching
+// the condition, which refers to the yielded columns by name
+func (s *ShowStatement) Where(condition core.Expression) *ShowStatement {
+	return newShowStatement(s.command, s.fields, condition)
+}
+
 // ShowConstraints generates a Cypher statement to show all constraints
-func ShowConstraints() (core.Statement, error) {
-	return core.NewStatement("SHOW CONSTRAINTS", nil), nil
+func ShowConstraints() (*ShowStatement, error) {
+	return newShowStatement("SHOW CONSTRAINTS", nil, nil), nil
 }
 
 // ShowIndexes generates a Cypher statement to show all indexes
-func ShowIndexes() (core.Statement, error) {
-	return core.NewStatement("SHOW INDEXES", nil), nil
+func ShowIndexes() (*ShowStatement, error) {
+	return newShowStatement("SHOW INDEXES", nil, nil), nil
 }
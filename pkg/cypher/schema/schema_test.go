@@ -1,8 +1,15 @@
 package schema
 
 import (
+	"errors"
+	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/ast"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/builder"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/expr"
 )
 
 func TestCreateUniqueConstraint(t *testing.T) {
@@ -87,8 +94,89 @@ func TestCreateFullTextIndex(t *testing.T) {
 	}
 
 	cypher := stmt.Cypher()
-	if !strings.Contains(cypher, "db.index.fulltext.createNodeIndex") {
-		t.Errorf("Cypher() = %q, should contain 'db.index.fulltext.createNodeIndex'", cypher)
+	if !strings.Contains(cypher, "CREATE FULLTEXT INDEX") {
+		t.Errorf("Cypher() = %q, should contain 'CREATE FULLTEXT INDEX'", cypher)
+	}
+}
+
+func TestCreateStatementsUseNeo4j5Syntax(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func() (core.Statement, error)
+		want  string
+	}{
+		{"node key", func() (core.Statement, error) { return CreateNodeKeyConstraint("user_key", "User", "id", "email") },
+			"CREATE CONSTRAINT user_key IF NOT EXISTS FOR (n:User) REQUIRE (n.id, n.email) IS NODE KEY"},
+		{"unique", func() (core.Statement, error) { return CreateUniqueConstraint("user_email", "User", "email") },
+			"CREATE CONSTRAINT user_email IF NOT EXISTS FOR (n:User) REQUIRE n.email IS UNIQUE"},
+		{"exists", func() (core.Statement, error) { return CreateExistsConstraint("user_name", "User", "name") },
+			"CREATE CONSTRAINT user_name IF NOT EXISTS FOR (n:User) REQUIRE n.name IS NOT NULL"},
+		{"relationship", func() (core.Statement, error) { return CreateRelationshipConstraint("paid_amount", "PAID", "amount") },
+			"CREATE CONSTRAINT paid_amount IF NOT EXISTS FOR ()-[r:PAID]-() REQUIRE r.amount IS NOT NULL"},
+		{"index", func() (core.Statement, error) { return CreateIndex("user_names", "User", "firstName", "lastName") },
+			"CREATE INDEX user_names IF NOT EXISTS FOR (n:User) ON (n.firstName, n.lastName)"},
+		{"range index", func() (core.Statement, error) { return CreateRangeIndex("user_age", "User", "age") },
+			"CREATE RANGE INDEX user_age IF NOT EXISTS FOR (n:User) ON (n.age)"},
+		{"text index", func() (core.Statement, error) { return CreateTextIndex("user_bio", "User", "bio") },
+			"CREATE TEXT INDEX user_bio IF NOT EXISTS FOR (n:User) ON (n.bio)"},
+		{"point index", func() (core.Statement, error) { return CreatePointIndex("user_location", "User", "location") },
+			"CREATE POINT INDEX user_location IF NOT EXISTS FOR (n:User) ON (n.location)"},
+		{"vector index", func() (core.Statement, error) { return CreateVectorIndex("doc_embeddings", "Doc", "embedding", 1536, "COSINE") },
+			"CREATE VECTOR INDEX doc_embeddings IF NOT EXISTS FOR (n:Doc) ON (n.embedding) " +
+				"OPTIONS { indexConfig: { `vector.dimensions`: 1536, `vector.similarity_function`: 'cosine' } }"},
+		{"full-text index", func() (core.Statement, error) {
+			return CreateFullTextIndex("search", []string{"Post", "Comment"}, []string{"title", "content"})
+		}, "CREATE FULLTEXT INDEX search IF NOT EXISTS FOR (n:Post|Comment) ON EACH [n.title, n.content]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stmt, err := tt.build()
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if stmt.Cypher() != tt.want {
+				t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), tt.want)
+			}
+		})
+	}
+}
+
+func TestCompositeSchemaStatements(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func() (core.Statement, error)
+		want  string
+	}{
+		{"composite node key", func() (core.Statement, error) {
+			return CreateNodeKeyConstraint("k", "Person", "firstName", "lastName", "dob")
+		}, "CREATE CONSTRAINT k IF NOT EXISTS FOR (n:Person) REQUIRE (n.firstName, n.lastName, n.dob) IS NODE KEY"},
+		{"composite index", func() (core.Statement, error) {
+			return CreateIndex("person_name", "Person", "firstName", "lastName")
+		}, "CREATE INDEX person_name IF NOT EXISTS FOR (n:Person) ON (n.firstName, n.lastName)"},
+		{"escaped node key", func() (core.Statement, error) {
+			return CreateNodeKeyConstraint("person-key", "Legal Person", "tax id", "country`code")
+		}, "CREATE CONSTRAINT `person-key` IF NOT EXISTS FOR (n:`Legal Person`) REQUIRE (n.`tax id`, n.`country``code`) IS NODE KEY"},
+		{"escaped unique", func() (core.Statement, error) {
+			return CreateUniqueConstraint("user_email", "User", "e-mail")
+		}, "CREATE CONSTRAINT user_email IF NOT EXISTS FOR (n:User) REQUIRE n.`e-mail` IS UNIQUE"},
+		{"escaped full-text index", func() (core.Statement, error) {
+			return CreateFullTextIndex("search", []string{"Blog Post", "Comment"}, []string{"title", "body text"})
+		}, "CREATE FULLTEXT INDEX search IF NOT EXISTS FOR (n:`Blog Post`|Comment) ON EACH [n.title, n.`body text`]"},
+		{"escaped drop", func() (core.Statement, error) { return DropIndex("person-name") },
+			"DROP INDEX `person-name` IF EXISTS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stmt, err := tt.build()
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if stmt.Cypher() != tt.want {
+				t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), tt.want)
+			}
+		})
 	}
 }
 
@@ -99,8 +187,8 @@ func TestDropConstraint(t *testing.T) {
 	}
 
 	cypher := stmt.Cypher()
-	if !strings.Contains(cypher, "DROP CONSTRAINT") {
-		t.Errorf("Cypher() = %q, should contain 'DROP CONSTRAINT'", cypher)
+	if want := "DROP CONSTRAINT user_email_unique IF EXISTS"; cypher != want {
+		t.Errorf("Cypher() = %q, want %q", cypher, want)
 	}
 }
 
@@ -111,8 +199,8 @@ func TestDropIndex(t *testing.T) {
 	}
 
 	cypher := stmt.Cypher()
-	if !strings.Contains(cypher, "DROP INDEX") {
-		t.Errorf("Cypher() = %q, should contain 'DROP INDEX'", cypher)
+	if want := "DROP INDEX user_name_idx IF EXISTS"; cypher != want {
+		t.Errorf("Cypher() = %q, want %q", cypher, want)
 	}
 }
 
@@ -140,6 +228,34 @@ func TestShowIndexes(t *testing.T) {
 	}
 }
 
+func TestShowIndexesYieldWhere(t *testing.T) {
+	show, err := ShowIndexes()
+	if err != nil {
+		t.Fatalf("ShowIndexes() error = %v", err)
+	}
+
+	online := show.Yield("name", "state").Where(expr.Equals(expr.NewVariableExpression("state"), expr.String("ONLINE")))
+	if want := "SHOW INDEXES YIELD name, state WHERE (state = 'ONLINE')"; online.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", online.Cypher(), want)
+	}
+	if show.Cypher() != "SHOW INDEXES" {
+		t.Errorf("Yield() and Where() should not change the original, got %q", show.Cypher())
+	}
+
+	var stmt core.Statement = show.Where(expr.Equals(expr.NewVariableExpression("name"), core.NewParameter("", "person_name")))
+	if want := "SHOW INDEXES WHERE (name = $p0)"; stmt.Cypher() != want {
+		t.Errorf("Cypher() = %q, want %q", stmt.Cypher(), want)
+	}
+	if want := map[string]any{"p0": "person_name"}; !reflect.DeepEqual(stmt.Params(), want) {
+		t.Errorf("Params() = %v, want %v", stmt.Params(), want)
+	}
+
+	constraints, _ := ShowConstraints()
+	if got := constraints.Yield("*").Cypher(); got != "SHOW CONSTRAINTS YIELD *" {
+		t.Errorf("Cypher() = %q, want %q", got, "SHOW CONSTRAINTS YIELD *")
+	}
+}
+
 func TestCreateNodeKeyConstraintNoProperties(t *testing.T) {
 	_, err := CreateNodeKeyConstraint("test", "User")
 	if err == nil {
@@ -168,3 +284,46 @@ func TestCreateFullTextIndexNoProperties(t *testing.T) {
 	}
 }
 
+func TestSchemaErrors(t *testing.T) {
+	if _, err := CreateVectorIndex("idx", "Doc", "embedding", 0, "cosine"); !errors.Is(err, core.ErrInvalidParameter) {
+		t.Errorf("CreateVectorIndex() without dimensions error = %v, want core.ErrInvalidParameter", err)
+	}
+	if _, err := CreateVectorIndex("idx", "Doc", "embedding", 3, "manhattan"); !errors.Is(err, core.ErrInvalidParameter) {
+		t.Errorf("CreateVectorIndex() with an unknown similarity error = %v, want core.ErrInvalidParameter", err)
+	}
+	if _, err := CreateTextIndex("idx", "User"); !errors.Is(err, core.ErrNoProperties) {
+		t.Errorf("CreateTextIndex() without properties error = %v, want core.ErrNoProperties", err)
+	}
+	if _, err := CreateTextIndex("idx", "User", "bio", "name"); !errors.Is(err, core.ErrInvalidProperty) {
+		t.Errorf("CreateTextIndex() with two properties error = %v, want core.ErrInvalidProperty", err)
+	}
+	if _, err := CreatePointIndex("idx", "User", "home", "work"); !errors.Is(err, core.ErrInvalidProperty) {
+		t.Errorf("CreatePointIndex() with two properties error = %v, want core.ErrInvalidProperty", err)
+	}
+	if _, err := CreateIndex("idx", "User"); !errors.Is(err, core.ErrNoProperties) {
+		t.Errorf("CreateIndex() without properties error = %v, want core.ErrNoProperties", err)
+	}
+	if _, err := CreateFullTextIndex("idx", nil, []string{"name"}); !errors.Is(err, core.ErrNoLabels) {
+		t.Errorf("CreateFullTextIndex() without labels error = %v, want core.ErrNoLabels", err)
+	}
+}
+
+func TestStatementsMixSchemaAndData(t *testing.T) {
+	index, err := CreateIndex("person_name", "Person", "name")
+	if err != nil {
+		t.Fatalf("CreateIndex() error = %v", err)
+	}
+	create, err := builder.Create(ast.Node("Person").Named("p").WithProps(map[string]any{"name": core.NewParameter("name", "Ann")})).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	migration := builder.Statements(index).Add(create)
+	want := "CREATE INDEX person_name IF NOT EXISTS FOR (n:Person) ON (n.name);\nCREATE (p:Person {name: $name});\n"
+	if got := migration.Script(); got != want {
+		t.Errorf("Script() = %q, want %q", got, want)
+	}
+	if params, collisions := migration.Params(); !reflect.DeepEqual(params, map[string]any{"name": "Ann"}) || len(collisions) != 0 {
+		t.Errorf("Params() = %v, %v, want the parameters of the CREATE only", params, collisions)
+	}
+}
@@ -0,0 +1,137 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// aggregateFunctions are the functions that turn a projection into an aggregation
+var aggregateFunctions = map[string]bool{
+	"count": true, "sum": true, "avg": true, "min": true, "max": true, "collect": true,
+	"stdev": true, "stdevp": true, "percentilecont": true, "percentiledisc": true,
+}
+
+// checkAggregation flags WITH and RETURN items that combine an aggregation
+// with values that are not grouping keys, such as RETURN n.name + count(*)
+// when n.name is not projected on its own. Every non-aggregated item of an
+// aggregating projection is an implicit grouping key; an aggregated item may
+// only use values outside the aggregation that are among those keys,
+// otherwise Neo4j rejects the query at runtime.
+func checkAggregation(clauses []clause) []*ValidationError {
+	var errs []*ValidationError
+
+	for _, c := range clauses {
+		if c.keyword != "WITH" && c.keyword != "RETURN" {
+			continue
+		}
+
+		var aggregated [][]token
+		groupingKeys := make(map[string]bool)
+		for _, item := range projectionItems(c.tokens) {
+			expression := withoutAlias(item)
+			if containsAggregate(expression) {
+				aggregated = append(aggregated, expression)
+			} else {
+				groupingKeys[joinTokens(expression)] = true
+			}
+		}
+
+		for _, expression := range aggregated {
+			for _, value := range groupedValues(expression) {
+				variable := strings.SplitN(value, ".", 2)[0]
+				if groupingKeys[value] || groupingKeys[variable] {
+					continue
+				}
+				errs = append(errs, &ValidationError{
+					Clause:   c.index,
+					Keyword:  c.keyword,
+					Variable: variable,
+					Message: fmt.Sprintf("%q mixes an aggregation with %q, which is not a grouping key",
+						joinTokens(expression), value),
+				})
+			}
+		}
+	}
+	return errs
+}
+
+// withoutAlias strips a trailing "AS alias" from a projection item
+func withoutAlias(item []token) []token {
+	if len(item) >= 2 && item[len(item)-2].is("AS") {
+		return item[:len(item)-2]
+	}
+	return item
+}
+
+// isAggregateCall reports whether tokens[i] is the name of an aggregate function call
+func isAggregateCall(tokens []token, i int) bool {
+	return tokens[i].kind == tokenIdent && aggregateFunctions[strings.ToLower(tokens[i].text)] &&
+		tokenAt(tokens, i+1).is("(") && !tokenAt(tokens, i-1).is(".")
+}
+
+// containsAggregate reports whether an expression calls an aggregate function
+func containsAggregate(tokens []token) bool {
+	for i := range tokens {
+		if isAggregateCall(tokens, i) {
+			return true
+		}
+	}
+	return false
+}
+
+// groupedValues returns the variables and property chains (n or n.name)
+// that an expression uses outside of its aggregate function calls
+func groupedValues(tokens []token) []string {
+	var outside []token
+	for i := 0; i < len(tokens); i++ {
+		if isAggregateCall(tokens, i) {
+			i = closingIndex(tokens, i+1)
+			continue
+		}
+		outside = append(outside, tokens[i])
+	}
+
+	variables := make(map[string]bool)
+	for _, name := range references(outside) {
+		variables[name] = true
+	}
+
+	var values []string
+	seen := make(map[string]bool)
+	for i := 0; i < len(outside); i++ {
+		if !variables[outside[i].text] || tokenAt(outside, i-1).is(".") {
+			continue
+		}
+		value := outside[i].text
+		for tokenAt(outside, i+1).is(".") && tokenAt(outside, i+2).kind == tokenIdent {
+			value += "." + outside[i+2].text
+			i += 2
+		}
+		if !seen[value] {
+			seen[value] = true
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// joinTokens renders tokens back into compact Cypher text
+func joinTokens(tokens []token) string {
+	var sb strings.Builder
+	for i, t := range tokens {
+		if i > 0 && needsSpace(tokens[i-1], t) {
+			sb.WriteString(" ")
+		}
+		sb.WriteString(t.text)
+	}
+	return sb.String()
+}
+
+// needsSpace reports whether two adjacent tokens are separated by a space when joined
+func needsSpace(prev, next token) bool {
+	if prev.is(".") || next.is(".") || prev.is("(") || prev.is("[") ||
+		next.is(")") || next.is("]") || next.is(",") || next.is("(") && prev.kind == tokenIdent {
+		return false
+	}
+	return true
+}
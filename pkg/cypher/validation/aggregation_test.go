@@ -0,0 +1,58 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateAggregation(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		invalid []string
+	}{
+		{"grouping key", "MATCH (n) RETURN n.name, count(*)", nil},
+		{"grouping variable", "MATCH (n)-->(m) RETURN n, count(m), collect(m.title)", nil},
+		{"aggregation only", "MATCH (n) RETURN count(*), avg(n.age) AS age", nil},
+		{"no aggregation", "MATCH (n) RETURN n.name, n.age + 1", nil},
+		{"uses grouping key", "MATCH (n) RETURN n.age, n.age + count(*)", nil},
+		{"property of grouping variable", "MATCH (n) WITH n, n.age * count(*) AS score RETURN score", nil},
+		{"literals and parameters", "MATCH (n) RETURN count(n) * 2 + $offset", nil},
+		{"namespaced function", "MATCH (n) RETURN n.name, apoc.coll.max(n.scores)", nil},
+		{"mixed in return", "MATCH (n) RETURN n.name + count(*)", []string{"n"}},
+		{"mixed with other key", "MATCH (n) RETURN n.age, n.name + count(*)", []string{"n"}},
+		{"mixed in with", "MATCH (n)-->(m) WITH m, n.age - sum(m.rating) AS diff RETURN diff", []string{"n"}},
+		{"aggregate in function", "MATCH (n) RETURN size(n.tags) + max(n.score)", []string{"n"}},
+	}
+
+	validator := NewValidator(ValidationLevelStrict)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate(tt.query)
+			got := ruleVariables(err, "aggregation-mixing")
+			if strings.Join(got, ",") != strings.Join(tt.invalid, ",") {
+				t.Errorf("Validate(%q) invalid = %v, want %v (error: %v)", tt.query, got, tt.invalid, err)
+			}
+		})
+	}
+}
+
+func TestAggregationErrorDetails(t *testing.T) {
+	err := NewValidator(ValidationLevelStrict).Validate("MATCH (n) RETURN n.age, n.name + count(*) AS total")
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Validate() error should be a *ValidationError, got %v", err)
+	}
+	if validationErr.Rule != "aggregation-mixing" || validationErr.Clause != 1 {
+		t.Errorf("ValidationError = %+v, want aggregation-mixing in clause 1", validationErr)
+	}
+	if !strings.Contains(err.Error(), `"n.name + count(*)"`) || !strings.Contains(err.Error(), `"n.name"`) {
+		t.Errorf("Error() = %q, should quote the item and the non-grouping value", err.Error())
+	}
+
+	if err := NewValidator(ValidationLevelBasic).Validate("MATCH (n) RETURN n.name + count(*)"); err != nil {
+		t.Errorf("ValidationLevelBasic should not check aggregations, got %v", err)
+	}
+}
@@ -0,0 +1,217 @@
+package validation
+
+import (
+	"strings"
+	"unicode"
+)
+
+// tokenKind classifies the tokens produced by tokenize
+type tokenKind int
+
+const (
+	tokenIdent tokenKind = iota
+	tokenString
+	tokenNumber
+	tokenParam
+	tokenPunct
+)
+
+// token is a lexical element of a Cypher query
+type token struct {
+	kind   tokenKind
+	text   string
+	depth  int  // nesting depth of (), [] and {} at this token
+	quoted bool // backtick-quoted identifier, never a keyword
+}
+
+// is reports whether the token is the given keyword or punctuation, ignoring case
+func (t token) is(text string) bool {
+	return (t.kind == tokenIdent && !t.quoted || t.kind == tokenPunct) && strings.EqualFold(t.text, text)
+}
+
+// clause is a top-level Cypher clause together with the tokens that follow its keyword
+type clause struct {
+	index   int
+	keyword string
+	tokens  []token
+}
+
+// multiCharPunct lists the operators that are made of more than one character
+var multiCharPunct = []string{"<>", "<=", ">=", "=~", "->", "<-", "+=", ".."}
+
+// tokenize splits a query into tokens. String literals, backtick-quoted
+// identifiers and comments are handled so that their content is never
+// mistaken for Cypher syntax.
+func tokenize(query string) []token {
+	var tokens []token
+	depth := 0
+	runes := []rune(query)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			i += 2
+			for i < len(runes) && !(runes[i] == '*' && i+1 < len(runes) && runes[i+1] == '/') {
+				i++
+			}
+			i += 2
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '/':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '\'' || r == '"':
+			start := i
+			i++
+			for i < len(runes) && runes[i] != r {
+				if runes[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			i++
+			if i > len(runes) {
+				i = len(runes)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: string(runes[start:i]), depth: depth})
+		case r == '`':
+			start := i + 1
+			i++
+			for i < len(runes) && runes[i] != '`' {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: string(runes[start:i]), depth: depth, quoted: true})
+			i++
+		case r == '$':
+			start := i
+			i++
+			for i < len(runes) && isIdentRune(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenParam, text: string(runes[start:i]), depth: depth})
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && (isIdentRune(runes[i]) || (runes[i] == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1]))) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: string(runes[start:i]), depth: depth})
+		case isIdentRune(r):
+			start := i
+			for i < len(runes) && isIdentRune(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: string(runes[start:i]), depth: depth})
+		default:
+			text := string(r)
+			for _, op := range multiCharPunct {
+				if strings.HasPrefix(string(runes[i:]), op) {
+					text = op
+					break
+				}
+			}
+			if r == ')' || r == ']' || r == '}' {
+				depth--
+			}
+			tokens = append(tokens, token{kind: tokenPunct, text: text, depth: depth})
+			if r == '(' || r == '[' || r == '{' {
+				depth++
+			}
+			i += len([]rune(text))
+		}
+	}
+
+	return tokens
+}
+
+// isIdentRune reports whether r can be part of an unquoted identifier
+func isIdentRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// clauseKeywords lists the keywords that start a clause, longest first so that
+// multi-word keywords win over their prefixes
+var clauseKeywords = [][]string{
+	{"OPTIONAL", "MATCH"},
+	{"ORDER", "BY"},
+	{"DETACH", "DELETE"},
+	{"UNION", "ALL"},
+	{"LOAD", "CSV"},
+	{"MATCH"},
+	{"WHERE"},
+	{"WITH"},
+	{"RETURN"},
+	{"SKIP"},
+	{"LIMIT"},
+	{"CREATE"},
+	{"MERGE"},
+	{"SET"},
+	{"DELETE"},
+	{"REMOVE"},
+	{"UNWIND"},
+	{"CALL"},
+	{"YIELD"},
+	{"UNION"},
+}
+
+// splitClauses groups the top-level tokens of a query into clauses
+func splitClauses(tokens []token) []clause {
+	var clauses []clause
+
+	for i := 0; i < len(tokens); {
+		keyword, width := clauseKeywordAt(tokens, i)
+		if keyword == "" {
+			if len(clauses) > 0 {
+				clauses[len(clauses)-1].tokens = append(clauses[len(clauses)-1].tokens, tokens[i])
+			}
+			i++
+			continue
+		}
+
+		clauses = append(clauses, clause{index: len(clauses), keyword: keyword})
+		i += width
+	}
+
+	return clauses
+}
+
+// clauseKeywordAt returns the clause keyword starting at tokens[i], if any
+func clauseKeywordAt(tokens []token, i int) (string, int) {
+	if tokens[i].depth != 0 || tokens[i].kind != tokenIdent {
+		return "", 0
+	}
+	// Property keys and labels may be spelled like keywords (n.limit, :Match)
+	if i > 0 && (tokens[i-1].is(".") || tokens[i-1].is(":")) {
+		return "", 0
+	}
+	// STARTS WITH and ENDS WITH are operators and LOAD CSV WITH HEADERS is
+	// part of LOAD CSV, not a WITH clause
+	if i > 0 && (tokens[i-1].is("STARTS") || tokens[i-1].is("ENDS") || tokens[i-1].is("CSV")) {
+		return "", 0
+	}
+	// ON CREATE SET and ON MATCH SET belong to the preceding MERGE
+	if i > 0 && tokens[i-1].is("ON") {
+		return "", 0
+	}
+	if i > 1 && tokens[i-2].is("ON") {
+		return "", 0
+	}
+
+	for _, keyword := range clauseKeywords {
+		if i+len(keyword) > len(tokens) {
+			continue
+		}
+		matched := true
+		for j, word := range keyword {
+			if !tokens[i+j].is(word) || tokens[i+j].depth != 0 {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return strings.Join(keyword, " "), len(keyword)
+		}
+	}
+	return "", 0
+}
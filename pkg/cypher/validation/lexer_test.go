@@ -0,0 +1,46 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tokens := tokenize("MATCH (n:`My Label` {name: 'it''s'}) WHERE n.age >= $min // comment\nRETURN n")
+
+	var texts []string
+	for _, tok := range tokens {
+		texts = append(texts, tok.text)
+	}
+	got := strings.Join(texts, " ")
+	want := "MATCH ( n : My Label { name : 'it' 's' } ) WHERE n . age >= $min RETURN n"
+	if got != want {
+		t.Errorf("tokenize() = %q, want %q", got, want)
+	}
+}
+
+func TestSplitClauses(t *testing.T) {
+	tests := []struct {
+		query    string
+		keywords []string
+	}{
+		{"MATCH (n) RETURN n", []string{"MATCH", "RETURN"}},
+		{"OPTIONAL MATCH (n) WITH n ORDER BY n.name SKIP 1 LIMIT 2 RETURN n", []string{"OPTIONAL MATCH", "WITH", "ORDER BY", "SKIP", "LIMIT", "RETURN"}},
+		{"MATCH (n) WHERE n.name STARTS WITH 'a' RETURN n", []string{"MATCH", "WHERE", "RETURN"}},
+		{"MERGE (n) ON CREATE SET n.a = 1 ON MATCH SET n.b = 2 RETURN n", []string{"MERGE", "RETURN"}},
+		{"MATCH (n) CALL { WITH n RETURN n AS m } RETURN m", []string{"MATCH", "CALL", "RETURN"}},
+		{"LOAD CSV WITH HEADERS FROM $url AS row CREATE (n) SET n = row", []string{"LOAD CSV", "CREATE", "SET"}},
+		{"MATCH (n) DETACH DELETE n", []string{"MATCH", "DETACH DELETE"}},
+		{"MATCH (`match`) RETURN `match`", []string{"MATCH", "RETURN"}},
+	}
+
+	for _, tt := range tests {
+		var keywords []string
+		for _, c := range splitClauses(tokenize(tt.query)) {
+			keywords = append(keywords, c.keyword)
+		}
+		if strings.Join(keywords, ",") != strings.Join(tt.keywords, ",") {
+			t.Errorf("splitClauses(%q) = %v, want %v", tt.query, keywords, tt.keywords)
+		}
+	}
+}
@@ -0,0 +1,408 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// checkBrackets verifies that parentheses, brackets and braces are balanced
+func checkBrackets(tokens []token) *ValidationError {
+	pairs := map[string]string{")": "(", "]": "[", "}": "{"}
+	var stack []string
+
+	for _, t := range tokens {
+		if t.kind != tokenPunct {
+			continue
+		}
+		switch t.text {
+		case "(", "[", "{":
+			stack = append(stack, t.text)
+		case ")", "]", "}":
+			if len(stack) == 0 || stack[len(stack)-1] != pairs[t.text] {
+				return &ValidationError{
+					Rule:    "balanced-brackets",
+					Clause:  -1,
+					Message: fmt.Sprintf("unexpected %q", t.text),
+				}
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	if len(stack) > 0 {
+		return &ValidationError{
+			Rule:    "balanced-brackets",
+			Clause:  -1,
+			Message: fmt.Sprintf("unclosed %q", stack[len(stack)-1]),
+		}
+	}
+	return nil
+}
+
+// unresolved is a variable reference that is not in scope where it is used
+type unresolved struct {
+	clause    clause
+	name      string
+	droppedBy int // index of the WITH that dropped the variable, or -1 if it was never bound
+}
+
+// referencingClauses are the clauses whose expressions are checked for unresolved variables
+var referencingClauses = map[string]bool{
+	"WHERE": true, "WITH": true, "RETURN": true, "ORDER BY": true, "UNWIND": true,
+	"SET": true, "REMOVE": true, "DELETE": true, "DETACH DELETE": true,
+}
+
+// resolve walks the clause chain, tracking the variables in scope the way
+// Neo4j does, and returns every reference that is out of scope. A WITH
+// replaces the scope with its projection unless it is WITH *.
+func resolve(clauses []clause) []unresolved {
+	var result []unresolved
+	bound := make(map[string]bool)
+	dropped := make(map[string]int)
+	// ORDER BY may still use the variables that were in scope before the projection
+	var beforeProjection map[string]bool
+
+	for _, c := range clauses {
+		if referencingClauses[c.keyword] {
+			reported := make(map[string]bool)
+			for _, name := range references(c.tokens) {
+				if bound[name] || reported[name] || c.keyword == "ORDER BY" && beforeProjection[name] {
+					continue
+				}
+				reported[name] = true
+				droppedBy, ok := dropped[name]
+				if !ok {
+					droppedBy = -1
+				}
+				result = append(result, unresolved{clause: c, name: name, droppedBy: droppedBy})
+			}
+		}
+
+		switch c.keyword {
+		case "WITH":
+			beforeProjection = bound
+			if !hasStar(c.tokens) {
+				bound = make(map[string]bool)
+			} else {
+				bound = copyScope(bound)
+			}
+			for _, name := range bindings(c) {
+				bound[name] = true
+			}
+			for name := range beforeProjection {
+				if !bound[name] {
+					dropped[name] = c.index
+				}
+			}
+			continue
+		case "RETURN":
+			beforeProjection = bound
+			bound = copyScope(bound)
+		case "UNION", "UNION ALL":
+			// Each part of a union has its own scope
+			bound = make(map[string]bool)
+			dropped = make(map[string]int)
+			beforeProjection = nil
+		}
+
+		for _, name := range bindings(c) {
+			bound[name] = true
+			delete(dropped, name)
+		}
+	}
+	return result
+}
+
+// checkUndefinedVariables flags variables that are referenced but never bound
+func checkUndefinedVariables(clauses []clause) []*ValidationError {
+	var errs []*ValidationError
+	for _, ref := range resolve(clauses) {
+		if ref.droppedBy >= 0 {
+			continue
+		}
+		errs = append(errs, &ValidationError{
+			Clause:   ref.clause.index,
+			Keyword:  ref.clause.keyword,
+			Variable: ref.name,
+			Message:  fmt.Sprintf("variable %q is not defined", ref.name),
+		})
+	}
+	return errs
+}
+
+// checkWithScope flags variables that are referenced after a WITH that did not carry them
+func checkWithScope(clauses []clause) []*ValidationError {
+	var errs []*ValidationError
+	for _, ref := range resolve(clauses) {
+		if ref.droppedBy < 0 {
+			continue
+		}
+		errs = append(errs, &ValidationError{
+			Clause:   ref.clause.index,
+			Keyword:  ref.clause.keyword,
+			Variable: ref.name,
+			Message:  fmt.Sprintf("variable %q is not carried over by the WITH in clause %d", ref.name, ref.droppedBy),
+		})
+	}
+	return errs
+}
+
+// hasStar reports whether a projection includes every variable in scope (WITH *)
+func hasStar(tokens []token) bool {
+	for i, t := range tokens {
+		if t.depth == 0 && t.is("*") && (i == 0 || tokens[i-1].is(",")) {
+			return true
+		}
+	}
+	return false
+}
+
+// copyScope returns a copy of a set of bound variables
+func copyScope(scope map[string]bool) map[string]bool {
+	copied := make(map[string]bool, len(scope))
+	for name := range scope {
+		copied[name] = true
+	}
+	return copied
+}
+
+// bindings returns the variables a clause introduces
+func bindings(c clause) []string {
+	switch c.keyword {
+	case "MATCH", "OPTIONAL MATCH", "CREATE", "MERGE":
+		return patternVariables(c.tokens)
+	case "UNWIND", "LOAD CSV":
+		for i, t := range c.tokens {
+			if t.depth == 0 && t.is("AS") && i+1 < len(c.tokens) {
+				return []string{c.tokens[i+1].text}
+			}
+		}
+	case "WITH", "RETURN", "YIELD":
+		return projectionNames(c.tokens)
+	case "CALL":
+		// A subquery exposes the variables returned by its final RETURN
+		for i := len(c.tokens) - 1; i >= 0; i-- {
+			if c.tokens[i].depth == 1 && c.tokens[i].is("RETURN") {
+				return projectionNames(c.tokens[i+1 : len(c.tokens)-1])
+			}
+		}
+	}
+	return nil
+}
+
+// patternVariables returns the node, relationship and path variables of a pattern
+func patternVariables(tokens []token) []string {
+	var names []string
+	for i, t := range tokens {
+		if t.depth == 0 && t.is("ON") {
+			// ON CREATE SET and ON MATCH SET don't bind anything
+			break
+		}
+		if t.kind != tokenIdent {
+			continue
+		}
+		prev, next := tokenAt(tokens, i-1), tokenAt(tokens, i+1)
+		switch {
+		case (prev.is("(") || prev.is("[")) && isElementEnd(next):
+			names = append(names, t.text)
+		case t.depth == 0 && next.is("=") && (i == 0 || prev.is(",")):
+			names = append(names, t.text)
+		}
+	}
+	return names
+}
+
+// isElementEnd reports whether t can follow the variable of a node or relationship
+func isElementEnd(t token) bool {
+	return t.is(":") || t.is(")") || t.is("]") || t.is("{") || t.is("*")
+}
+
+// projectionNames returns the names a WITH, RETURN or YIELD projection
+// makes available: aliases and bare variables
+func projectionNames(tokens []token) []string {
+	var names []string
+	for _, item := range projectionItems(tokens) {
+		switch {
+		case len(item) >= 2 && item[len(item)-2].is("AS"):
+			names = append(names, item[len(item)-1].text)
+		case len(item) == 1 && item[0].kind == tokenIdent:
+			names = append(names, item[0].text)
+		}
+	}
+	return names
+}
+
+// projectionItems splits a projection into its comma-separated items,
+// dropping a leading DISTINCT
+func projectionItems(tokens []token) [][]token {
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	var items [][]token
+	base := tokens[0].depth
+	start := 0
+	for i := 0; i <= len(tokens); i++ {
+		if i < len(tokens) && !(tokens[i].depth == base && tokens[i].is(",")) {
+			continue
+		}
+		item := tokens[start:i]
+		start = i + 1
+
+		if len(item) > 0 && item[0].is("DISTINCT") {
+			item = item[1:]
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// reservedWords are identifiers that can appear in expressions without being variables
+var reservedWords = map[string]bool{
+	"AND": true, "OR": true, "XOR": true, "NOT": true, "IN": true, "IS": true,
+	"NULL": true, "TRUE": true, "FALSE": true, "AS": true, "DISTINCT": true,
+	"CASE": true, "WHEN": true, "THEN": true, "ELSE": true, "END": true,
+	"STARTS": true, "ENDS": true, "WITH": true, "CONTAINS": true,
+	"ASC": true, "DESC": true, "ASCENDING": true, "DESCENDING": true,
+	"WHERE": true,
+}
+
+// subqueryKeywords introduce a {...} block with its own scope
+var subqueryKeywords = []string{"EXISTS", "COUNT", "COLLECT", "CALL"}
+
+// opener is an unclosed bracket seen while scanning an expression
+type opener struct {
+	text     string
+	function string // name of the function whose arguments the bracket opens
+	pattern  bool   // inside a pattern comprehension, which may bind new variables
+}
+
+// quantifiers are the functions that bind a variable with "x IN list"
+var quantifiers = []string{"all", "any", "none", "single", "reduce"}
+
+// isQuantifier reports whether name is one of the quantifier functions
+func isQuantifier(name string) bool {
+	for _, quantifier := range quantifiers {
+		if strings.EqualFold(name, quantifier) {
+			return true
+		}
+	}
+	return false
+}
+
+// references returns the variables an expression refers to, in order of
+// appearance. Property keys, labels, map keys, function names and variables
+// local to comprehensions are not references.
+func references(tokens []token) []string {
+	var names []string
+	var stack []opener
+	local := make(map[string]bool)
+	inLabel := false
+
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		prev, next := tokenAt(tokens, i-1), tokenAt(tokens, i+1)
+
+		if t.kind == tokenPunct {
+			switch t.text {
+			case "(", "[", "{":
+				if t.text == "{" && isAny(prev, subqueryKeywords) {
+					i = closingIndex(tokens, i)
+					continue
+				}
+				pattern := len(stack) > 0 && stack[len(stack)-1].pattern
+				if t.text == "[" && (next.is("(") || next.kind == tokenIdent && tokenAt(tokens, i+2).is("=")) {
+					pattern = true
+				}
+				function := ""
+				if t.text == "(" && prev.kind == tokenIdent {
+					function = prev.text
+				}
+				stack = append(stack, opener{text: t.text, function: function, pattern: pattern})
+			case ")", "]", "}":
+				if len(stack) > 0 {
+					stack = stack[:len(stack)-1]
+				}
+			}
+			if !isAny(t, []string{":", "|", "&", "!"}) {
+				inLabel = false
+			}
+			continue
+		}
+		if t.kind != tokenIdent {
+			inLabel = false
+			continue
+		}
+
+		var top opener
+		if len(stack) > 0 {
+			top = stack[len(stack)-1]
+		}
+		inMap := top.text == "{"
+		if prev.is(":") && !inMap || inLabel && isAny(prev, []string{"|", "&", "!"}) {
+			inLabel = true
+			continue
+		}
+		inLabel = false
+
+		switch {
+		case prev.is(".") || prev.is("AS"):
+			// Property key or alias
+		case !t.quoted && reservedWords[strings.ToUpper(t.text)]:
+		case isAny(t, subqueryKeywords) && next.is("{"):
+		case inMap && next.is(":"):
+			// Map key
+		case next.is("IN") && (prev.is("[") || prev.is("(") && isQuantifier(top.function) ||
+			prev.is(",") && strings.EqualFold(top.function, "reduce")):
+			// List comprehension, quantifier or reduce variable
+			local[t.text] = true
+		case next.is("=") && (prev.is("[") || prev.is("(") && strings.EqualFold(top.function, "reduce")):
+			// Path variable of a pattern comprehension or reduce accumulator
+			local[t.text] = true
+		case top.pattern && (prev.is("(") || prev.is("[")) && isElementEnd(next):
+			local[t.text] = true
+		default:
+			// Skip function names, including namespaced ones like apoc.coll.sum
+			j := i + 1
+			for tokenAt(tokens, j).is(".") && tokenAt(tokens, j+1).kind == tokenIdent {
+				j += 2
+			}
+			if tokenAt(tokens, j).is("(") {
+				i = j - 1
+				continue
+			}
+			if !local[t.text] {
+				names = append(names, t.text)
+			}
+		}
+	}
+	return names
+}
+
+// tokenAt returns tokens[i], or an empty punctuation token when i is out of range
+func tokenAt(tokens []token, i int) token {
+	if i < 0 || i >= len(tokens) {
+		return token{kind: tokenPunct}
+	}
+	return tokens[i]
+}
+
+// isAny reports whether t is any of the given keywords or punctuation
+func isAny(t token, texts []string) bool {
+	for _, text := range texts {
+		if t.is(text) {
+			return true
+		}
+	}
+	return false
+}
+
+// closingIndex returns the index of the bracket that closes tokens[open]
+func closingIndex(tokens []token, open int) int {
+	for i := open + 1; i < len(tokens); i++ {
+		if tokens[i].depth == tokens[open].depth && tokens[i].kind == tokenPunct {
+			return i
+		}
+	}
+	return len(tokens)
+}
@@ -0,0 +1,123 @@
+// Package validation checks rendered Cypher queries for common mistakes
+// before they are sent to the database.
+package validation
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+)
+
+// ValidationLevel selects which rules a Validator applies
+type ValidationLevel int
+
+const (
+	// ValidationLevelNone disables validation
+	ValidationLevelNone ValidationLevel = iota
+	// ValidationLevelBasic checks the query syntax, such as balanced brackets
+	ValidationLevelBasic
+	// ValidationLevelStrict additionally checks semantics, such as undefined variables
+	ValidationLevelStrict
+)
+
+// String returns the name of the validation level
+func (l ValidationLevel) String() string {
+	switch l {
+	case ValidationLevelNone:
+		return "none"
+	case ValidationLevelBasic:
+		return "basic"
+	case ValidationLevelStrict:
+		return "strict"
+	default:
+		return fmt.Sprintf("ValidationLevel(%d)", int(l))
+	}
+}
+
+// ValidationError describes a single rule violation.
+// It wraps core.ErrInvalidQuery so callers can test for it with errors.Is.
+type ValidationError struct {
+	Rule     string
+	Clause   int    // index of the offending clause, or -1 for the whole query
+	Keyword  string // keyword of the offending clause, e.g. "RETURN"
+	Variable string // offending variable, if any
+	Message  string
+}
+
+// Error returns the error message
+func (e *ValidationError) Error() string {
+	if e.Clause < 0 {
+		return fmt.Sprintf("%s: %s", e.Rule, e.Message)
+	}
+	return fmt.Sprintf("%s: clause %d (%s): %s", e.Rule, e.Clause, e.Keyword, e.Message)
+}
+
+// Unwrap returns the underlying error
+func (e *ValidationError) Unwrap() error {
+	return core.ErrInvalidQuery
+}
+
+// rule is a single validation check applied from a minimum level upwards
+type rule struct {
+	name  string
+	level ValidationLevel
+	check func(clauses []clause) []*ValidationError
+}
+
+// rules lists the clause-based checks in the order they are applied
+var rules = []rule{
+	{name: "undefined-variable", level: ValidationLevelStrict, check: checkUndefinedVariables},
+	{name: "with-scope", level: ValidationLevelStrict, check: checkWithScope},
+	{name: "aggregation-mixing", level: ValidationLevelStrict, check: checkAggregation},
+}
+
+// Validator checks Cypher queries against the rules of a validation level
+type Validator struct {
+	level ValidationLevel
+}
+
+// NewValidator creates a new Validator for the given level
+func NewValidator(level ValidationLevel) *Validator {
+	return &Validator{level: level}
+}
+
+// Level returns the validation level of the validator
+func (v *Validator) Level() ValidationLevel {
+	return v.level
+}
+
+// Validate checks a query and returns all violations joined into one error,
+// or nil if the query passes every rule of the validator's level
+func (v *Validator) Validate(query string) error {
+	if v.level <= ValidationLevelNone {
+		return nil
+	}
+
+	tokens := tokenize(query)
+	if err := checkBrackets(tokens); err != nil {
+		// Clause boundaries are meaningless when brackets don't match
+		return err
+	}
+
+	clauses := splitClauses(tokens)
+	var errs []error
+	for _, r := range rules {
+		if r.level > v.level {
+			continue
+		}
+		for _, err := range r.check(clauses) {
+			err.Rule = r.name
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateStatement checks the Cypher of a built statement
+func (v *Validator) ValidateStatement(statement core.Statement) error {
+	if statement == nil {
+		return nil
+	}
+	return v.Validate(statement.Cypher())
+}
@@ -0,0 +1,198 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+)
+
+func TestValidateBrackets(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		wantErr bool
+	}{
+		{"balanced", "MATCH (n:Person {name: 'John'}) RETURN [n]", false},
+		{"unclosed parenthesis", "MATCH (n:Person RETURN n", true},
+		{"unexpected bracket", "MATCH (n:Person]) RETURN n", true},
+		{"brackets in string", "MATCH (n) WHERE n.name = '(' RETURN n", false},
+		{"brackets in comment", "MATCH (n) // (\nRETURN n", false},
+	}
+
+	validator := NewValidator(ValidationLevelBasic)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate(tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate(%q) error = %v, wantErr %v", tt.query, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateUndefinedVariables(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		undefined []string
+	}{
+		{"typo in return", "MATCH (movie:Movie) RETURN m.title", []string{"m"}},
+		{"typo in where", "MATCH (p:Person) WHERE x.age > 30 RETURN p", []string{"x"}},
+		{"bare variable", "MATCH (p:Person) RETURN q", []string{"q"}},
+		{"reported once per clause", "MATCH (p) RETURN m.a, m.b", []string{"m"}},
+		{"bound by match", "MATCH (p:Person)-[r:ACTED_IN]->(m:Movie) RETURN p, r, m.title", nil},
+		{"bound by optional match", "MATCH (p) OPTIONAL MATCH (p)-->(f) RETURN f", nil},
+		{"path variable", "MATCH path = (a)-->(b) RETURN length(path)", nil},
+		{"bound by unwind", "UNWIND $names AS name MATCH (p {name: name}) RETURN p, name", nil},
+		{"bound by with alias", "MATCH (p) WITH p, count(*) AS total WHERE total > 1 RETURN p, total", nil},
+		{"bound by create", "CREATE (p:Person {name: $name}) RETURN p", nil},
+		{"bound by merge", "MERGE (p:Person {id: 1}) ON CREATE SET p.created = timestamp() RETURN p", nil},
+		{"bound by yield", "CALL db.labels() YIELD label RETURN label", nil},
+		{"bound by subquery", "MATCH (p) CALL { WITH p MATCH (p)-->(f) RETURN f } RETURN p, f", nil},
+		{"return alias in order by", "MATCH (p) RETURN p.name AS name ORDER BY name", nil},
+		{"label predicate", "MATCH (n) WHERE n:Person|Actor RETURN n", nil},
+		{"string operators", "MATCH (n) WHERE n.name STARTS WITH 'A' AND n.name ENDS WITH 'z' RETURN n", nil},
+		{"keyword as property key", "MATCH (n) RETURN n.limit, n.match", nil},
+		{"map projection", "MATCH (n) RETURN n {.name, total: size(n.items)}", nil},
+		{"list comprehension", "MATCH (n) RETURN [x IN n.items WHERE x > 1 | x * 2]", nil},
+		{"quantifier", "MATCH (n) WHERE any(x IN n.items WHERE x > 1) RETURN n", nil},
+		{"reduce", "MATCH (n) RETURN reduce(acc = 0, x IN n.items | acc + x)", nil},
+		{"pattern comprehension", "MATCH (n) RETURN [(n)-->(m:Movie) | m.title]", nil},
+		{"existential subquery", "MATCH (n) WHERE EXISTS { MATCH (n)-->(m) } RETURN n", nil},
+		{"namespaced function", "MATCH (n) RETURN apoc.coll.sum(n.items)", nil},
+		{"case expression", "MATCH (n) RETURN CASE WHEN n.age > 18 THEN 'adult' ELSE 'minor' END", nil},
+		{"parameters", "MATCH (n) WHERE n.name = $name RETURN n", nil},
+		{"union resets scope", "MATCH (a) RETURN a UNION MATCH (b) RETURN a", []string{"a"}},
+	}
+
+	validator := NewValidator(ValidationLevelStrict)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate(tt.query)
+			got := ruleVariables(err, "undefined-variable")
+			if strings.Join(got, ",") != strings.Join(tt.undefined, ",") {
+				t.Errorf("Validate(%q) undefined = %v, want %v (error: %v)", tt.query, got, tt.undefined, err)
+			}
+		})
+	}
+}
+
+func TestValidateWithScope(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		dropped []string
+	}{
+		{"dropped by with", "MATCH (p:Person)-->(m:Movie) WITH p RETURN p.name, m.title", []string{"m"}},
+		{"dropped in where", "MATCH (p)-->(m) WITH p WHERE m.year > 2000 RETURN p", []string{"m"}},
+		{"dropped by aggregation", "MATCH (p)-->(m) WITH p, count(m) AS movies RETURN m", []string{"m"}},
+		{"dropped in set", "MATCH (p)-->(m) WITH m SET p.seen = true", []string{"p"}},
+		{"carried over", "MATCH (p)-->(m) WITH p, m RETURN p, m", nil},
+		{"carried over by alias", "MATCH (p) WITH p AS person RETURN person", nil},
+		{"with star", "MATCH (p)-->(m) WITH *, p.name AS name RETURN m, name", nil},
+		{"rebound after with", "MATCH (p) WITH p MATCH (p)-->(m) RETURN m", nil},
+		{"order by before projection", "MATCH (p)-->(m) WITH p ORDER BY m.year RETURN p", nil},
+	}
+
+	validator := NewValidator(ValidationLevelStrict)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate(tt.query)
+			got := ruleVariables(err, "with-scope")
+			if strings.Join(got, ",") != strings.Join(tt.dropped, ",") {
+				t.Errorf("Validate(%q) dropped = %v, want %v (error: %v)", tt.query, got, tt.dropped, err)
+			}
+			if undefined := ruleVariables(err, "undefined-variable"); len(undefined) > 0 {
+				t.Errorf("Validate(%q) should not report dropped variables as undefined, got %v", tt.query, undefined)
+			}
+		})
+	}
+}
+
+func TestWithScopeErrorDetails(t *testing.T) {
+	err := NewValidator(ValidationLevelStrict).Validate("MATCH (p)-->(m) WITH p RETURN m.title")
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Validate() error should be a *ValidationError, got %v", err)
+	}
+	if validationErr.Clause != 2 || validationErr.Variable != "m" {
+		t.Errorf("ValidationError = %+v, want clause 2 variable m", validationErr)
+	}
+	if !strings.Contains(err.Error(), "WITH in clause 1") {
+		t.Errorf("Error() = %q, should name the WITH that dropped the variable", err.Error())
+	}
+}
+
+func TestValidationErrorDetails(t *testing.T) {
+	err := NewValidator(ValidationLevelStrict).Validate("MATCH (movie:Movie) RETURN m.title")
+	if err == nil {
+		t.Fatal("Validate() should fail for an undefined variable")
+	}
+	if !errors.Is(err, core.ErrInvalidQuery) {
+		t.Errorf("Validate() error should wrap core.ErrInvalidQuery")
+	}
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Validate() error should be a *ValidationError, got %T", err)
+	}
+	if validationErr.Clause != 1 || validationErr.Keyword != "RETURN" || validationErr.Variable != "m" {
+		t.Errorf("ValidationError = %+v, want clause 1 (RETURN) variable m", validationErr)
+	}
+	if !strings.Contains(err.Error(), "undefined-variable") {
+		t.Errorf("Error() = %q, should name the rule", err.Error())
+	}
+}
+
+func TestValidationLevels(t *testing.T) {
+	query := "MATCH (movie:Movie) RETURN m.title"
+
+	if err := NewValidator(ValidationLevelNone).Validate("MATCH (n"); err != nil {
+		t.Errorf("ValidationLevelNone should not report errors, got %v", err)
+	}
+	if err := NewValidator(ValidationLevelBasic).Validate(query); err != nil {
+		t.Errorf("ValidationLevelBasic should not check variables, got %v", err)
+	}
+	if err := NewValidator(ValidationLevelStrict).Validate(query); err == nil {
+		t.Errorf("ValidationLevelStrict should check variables")
+	}
+}
+
+func TestValidateStatement(t *testing.T) {
+	validator := NewValidator(ValidationLevelStrict)
+
+	if err := validator.ValidateStatement(core.NewStatement("MATCH (n) RETURN n", nil)); err != nil {
+		t.Errorf("ValidateStatement() error = %v", err)
+	}
+	if err := validator.ValidateStatement(core.NewStatement("MATCH (n) RETURN x", nil)); err == nil {
+		t.Errorf("ValidateStatement() should fail for an undefined variable")
+	}
+	if err := validator.ValidateStatement(nil); err != nil {
+		t.Errorf("ValidateStatement(nil) error = %v", err)
+	}
+}
+
+// ruleVariables collects the variables reported by a rule
+func ruleVariables(err error, ruleName string) []string {
+	if err == nil {
+		return nil
+	}
+
+	var names []string
+	var joined interface{ Unwrap() []error }
+	if errors.As(err, &joined) {
+		for _, e := range joined.Unwrap() {
+			names = append(names, ruleVariables(e, ruleName)...)
+		}
+		return names
+	}
+
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) && validationErr.Rule == ruleName {
+		names = append(names, validationErr.Variable)
+	}
+	return names
+}
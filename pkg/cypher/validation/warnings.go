@@ -0,0 +1,270 @@
+package validation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+)
+
+// Warning describes a query that runs but likely does not do what was
+// intended, like the notifications Neo4j returns with a result. Unlike a
+// ValidationError it does not make validation fail.
+type Warning struct {
+	Rule      string
+	Clause    int      // index of the clause the warning is about
+	Keyword   string   // keyword of that clause, e.g. "MATCH"
+	Variables []string // one variable of each disconnected part, if any
+	Message   string
+}
+
+// String returns the warning message
+func (w *Warning) String() string {
+	return fmt.Sprintf("%s: clause %d (%s): %s", w.Rule, w.Clause, w.Keyword, w.Message)
+}
+
+// warningRule is a single check that reports warnings from a minimum level upwards
+type warningRule struct {
+	name  string
+	level ValidationLevel
+	check func(clauses []clause) []*Warning
+}
+
+// warningRules lists the checks that report warnings in the order they are applied
+var warningRules = []warningRule{
+	{name: "cartesian-product", level: ValidationLevelStrict, check: checkCartesianProduct},
+}
+
+// Warnings checks a query against the warning rules of the validator's level.
+// A query with unbalanced brackets yields no warnings; Validate reports it.
+func (v *Validator) Warnings(query string) []*Warning {
+	if v.level <= ValidationLevelNone {
+		return nil
+	}
+
+	tokens := tokenize(query)
+	if checkBrackets(tokens) != nil {
+		return nil
+	}
+
+	clauses := splitClauses(tokens)
+	var warnings []*Warning
+	for _, r := range warningRules {
+		if r.level > v.level {
+			continue
+		}
+		for _, w := range r.check(clauses) {
+			w.Rule = r.name
+			warnings = append(warnings, w)
+		}
+	}
+	return warnings
+}
+
+// StatementWarnings checks the Cypher of a built statement against the warning rules
+func (v *Validator) StatementWarnings(statement core.Statement) []*Warning {
+	if statement == nil {
+		return nil
+	}
+	return v.Warnings(statement.Cypher())
+}
+
+// readingClauses are the clauses whose rows are combined before the next
+// clause runs; disconnected patterns among them form a cartesian product
+var readingClauses = map[string]bool{
+	"MATCH": true, "OPTIONAL MATCH": true, "WHERE": true, "UNWIND": true,
+}
+
+// connections groups variables into the parts of a query that are connected
+// by shared variables, remembering the clause in which each part started
+type connections struct {
+	parent  map[string]string
+	started map[string]int
+	unnamed int
+}
+
+// newConnections creates an empty set of connected parts
+func newConnections() *connections {
+	return &connections{parent: make(map[string]string), started: make(map[string]int)}
+}
+
+// find returns the representative of the part that contains name
+func (c *connections) find(name string) string {
+	for c.parent[name] != name {
+		c.parent[name] = c.parent[c.parent[name]]
+		name = c.parent[name]
+	}
+	return name
+}
+
+// add connects the given variables into one part, which starts in clause
+// unless one of the variables is already known. A pattern without variables
+// becomes a part of its own.
+func (c *connections) add(names []string, clause int) {
+	if len(names) == 0 {
+		c.unnamed++
+		names = []string{fmt.Sprintf("#%d", c.unnamed)}
+	}
+	for _, name := range names {
+		if _, ok := c.parent[name]; !ok {
+			c.parent[name] = name
+			c.started[name] = clause
+		}
+	}
+	for _, name := range names[1:] {
+		c.union(names[0], name)
+	}
+}
+
+// connect joins the parts of the given variables; unknown variables are ignored
+func (c *connections) connect(names []string) {
+	var known []string
+	for _, name := range names {
+		if _, ok := c.parent[name]; ok {
+			known = append(known, name)
+		}
+	}
+	for i := 1; i < len(known); i++ {
+		c.union(known[0], known[i])
+	}
+}
+
+// union merges the parts of a and b; the merged part starts with the earlier one
+func (c *connections) union(a, b string) {
+	rootA, rootB := c.find(a), c.find(b)
+	if rootA == rootB {
+		return
+	}
+	if c.started[rootB] < c.started[rootA] {
+		rootA, rootB = rootB, rootA
+	}
+	c.parent[rootB] = rootA
+}
+
+// part is a group of variables connected by shared variables
+type part struct {
+	names  []string // sorted, without the placeholders of patterns without variables
+	clause int      // index of the clause the part started in
+}
+
+// parts returns the connected parts ordered by the clause they started in
+func (c *connections) parts() []part {
+	members := make(map[string][]string)
+	for name := range c.parent {
+		root := c.find(name)
+		members[root] = append(members[root], name)
+	}
+
+	roots := make([]string, 0, len(members))
+	for root := range members {
+		roots = append(roots, root)
+	}
+	sort.Slice(roots, func(i, j int) bool {
+		if c.started[roots[i]] != c.started[roots[j]] {
+			return c.started[roots[i]] < c.started[roots[j]]
+		}
+		return roots[i] < roots[j]
+	})
+
+	result := make([]part, len(roots))
+	for i, root := range roots {
+		result[i].clause = c.started[root]
+		for _, name := range members[root] {
+			if !strings.HasPrefix(name, "#") {
+				result[i].names = append(result[i].names, name)
+			}
+		}
+		sort.Strings(result[i].names)
+	}
+	return result
+}
+
+// checkCartesianProduct flags MATCH patterns that share no variable with the
+// rest of the rows they are combined with, whether in one MATCH or across
+// consecutive ones. Neo4j then pairs every match of one part with every match
+// of the other. A WHERE condition that relates the parts connects them; a WITH, RETURN
+// or updating clause ends the rows the check is applied to.
+func checkCartesianProduct(clauses []clause) []*Warning {
+	var warnings []*Warning
+	parts := newConnections()
+
+	report := func() {
+		disconnected := parts.parts()
+		if len(disconnected) < 2 {
+			return
+		}
+
+		// The product starts with the first part that is not connected to the earlier ones
+		start := clauses[disconnected[1].clause]
+		var variables, descriptions []string
+		for _, p := range disconnected {
+			if len(p.names) == 0 {
+				descriptions = append(descriptions, "a pattern without variables")
+				continue
+			}
+			variables = append(variables, p.names[0])
+			descriptions = append(descriptions, "("+strings.Join(p.names, ", ")+")")
+		}
+		warnings = append(warnings, &Warning{
+			Clause:    start.index,
+			Keyword:   start.keyword,
+			Variables: variables,
+			Message: fmt.Sprintf("the patterns binding %s are not connected, which builds a cartesian product",
+				strings.Join(descriptions, " and ")),
+		})
+	}
+
+	for _, c := range clauses {
+		if readingClauses[c.keyword] {
+			switch c.keyword {
+			case "MATCH", "OPTIONAL MATCH":
+				for _, pattern := range projectionItems(c.tokens) {
+					parts.add(append(patternVariables(pattern), references(pattern)...), c.index)
+				}
+			case "UNWIND":
+				parts.add(append(bindings(c), references(c.tokens)...), c.index)
+			case "WHERE":
+				for _, condition := range conjuncts(c.tokens) {
+					parts.connect(references(condition))
+				}
+			}
+			continue
+		}
+
+		report()
+
+		// The rows are combined from here on, so what remains in scope is one part
+		var carried []string
+		if c.keyword != "UNION" && c.keyword != "UNION ALL" {
+			carried = bindings(c)
+			if c.keyword != "WITH" || hasStar(c.tokens) {
+				for name := range parts.parent {
+					if !strings.HasPrefix(name, "#") {
+						carried = append(carried, name)
+					}
+				}
+			}
+		}
+		parts = newConnections()
+		if len(carried) > 0 {
+			parts.add(carried, c.index)
+		}
+	}
+	report()
+	return warnings
+}
+
+// conjuncts splits a condition on its top-level ANDs, so that a.x = 1 AND
+// b.y = 2 does not relate a and b while a.id = b.ref does
+func conjuncts(tokens []token) [][]token {
+	var result [][]token
+	start := 0
+	for i, t := range tokens {
+		if t.depth == tokenAt(tokens, 0).depth && t.is("AND") {
+			result = append(result, tokens[start:i])
+			start = i + 1
+		}
+	}
+	return append(result, tokens[start:])
+}
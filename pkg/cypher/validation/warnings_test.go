@@ -0,0 +1,73 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nivohavi/go-cypher-dsl/pkg/cypher/core"
+)
+
+func TestCartesianProductWarnings(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		variables []string
+	}{
+		{"connected pattern", "MATCH (a)-[:KNOWS]->(b) RETURN a, b", nil},
+		{"shared variable", "MATCH (a)-->(b), (b)-->(c) RETURN c", nil},
+		{"consecutive matches", "MATCH (a) MATCH (a)-->(b) RETURN b", nil},
+		{"joined in where", "MATCH (a), (b) WHERE a.id = b.ref RETURN a, b", nil},
+		{"carried by with", "MATCH (a) WITH a MATCH (a)-->(b) RETURN b", nil},
+		{"unwound value", "UNWIND $rows AS row MATCH (n {id: row.id}) RETURN n", nil},
+		{"single match", "MATCH (a), (b) RETURN a, b", []string{"a", "b"}},
+		{"consecutive disconnected matches", "MATCH (a:Person) MATCH (b:Movie) RETURN a, b", []string{"a", "b"}},
+		{"unrelated conditions", "MATCH (a), (b) WHERE a.x = 1 AND b.y = 2 RETURN a", []string{"a", "b"}},
+		{"after with", "MATCH (a) WITH a MATCH (b) RETURN a, b", []string{"a", "b"}},
+		{"before create", "MATCH (a {id: 1}), (b {id: 2}) CREATE (a)-[:R]->(b)", []string{"a", "b"}},
+		{"union parts", "MATCH (a) RETURN a UNION MATCH (a) RETURN a", nil},
+	}
+
+	validator := NewValidator(ValidationLevelStrict)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings := validator.Warnings(tt.query)
+			var got []string
+			for _, w := range warnings {
+				if w.Rule == "cartesian-product" {
+					got = append(got, w.Variables...)
+				}
+			}
+			if strings.Join(got, ",") != strings.Join(tt.variables, ",") {
+				t.Errorf("Warnings(%q) variables = %v, want %v (warnings: %v)", tt.query, got, tt.variables, warnings)
+			}
+		})
+	}
+}
+
+func TestCartesianProductWarningDetails(t *testing.T) {
+	validator := NewValidator(ValidationLevelStrict)
+	query := "MATCH (a:Person)-[r]->(m) MATCH (b:Movie) RETURN a, b"
+
+	if err := validator.Validate(query); err != nil {
+		t.Errorf("Validate() error = %v, a cartesian product should only warn", err)
+	}
+
+	warnings := validator.Warnings(query)
+	if len(warnings) != 1 {
+		t.Fatalf("Warnings() = %v, want one warning", warnings)
+	}
+	w := warnings[0]
+	if w.Clause != 1 || w.Keyword != "MATCH" {
+		t.Errorf("Warning = %+v, want clause 1 (MATCH)", w)
+	}
+	if !strings.Contains(w.String(), "(a, m, r) and (b)") {
+		t.Errorf("String() = %q, should list the disconnected parts", w.String())
+	}
+
+	if got := NewValidator(ValidationLevelBasic).Warnings(query); got != nil {
+		t.Errorf("ValidationLevelBasic should not warn about cartesian products, got %v", got)
+	}
+	if got := validator.StatementWarnings(core.NewStatement(query, nil)); len(got) != 1 {
+		t.Errorf("StatementWarnings() = %v, want one warning", got)
+	}
+}